@@ -0,0 +1,50 @@
+#!/usr/bin/env bash
+
+# Sets the IRQ affinity and the RPS/XPS masks of a network device or of one of its queues.
+# The IRQ_AFFINITY (CPU list), RPS_MASK and XPS_MASK (CPU masks) environment variables
+# select the settings to apply, the unset ones are left untouched.
+
+function set_irq_affinity() {
+  [ -n "${IRQ_AFFINITY:-}" ] || return 0
+  # the queues can be re-created with new interrupts, e.g. when the number of channels changes
+  for irq in "${dev_path}"/device/msi_irqs/*; do
+    [ -e "${irq}" ] || continue
+    irq=$(basename "${irq}")
+    # some interrupts can not be moved, e.g. the kernel managed ones
+    echo "${IRQ_AFFINITY}" 2> /dev/null > "/proc/irq/${irq}/smp_affinity_list" ||
+      echo "Failed to set the affinity of IRQ ${irq} of ${dev_path} to ${IRQ_AFFINITY}" >&2
+  done
+  return 0
+}
+
+function set_queue_masks() {
+  local queue=${1}
+  # we do not fail when the 'echo' command fails, the device path might have changed in the meantime,
+  # e.g. because of a SR-IOV device renaming
+  case "$(basename "${queue}")" in
+  rx-*)
+    [ -z "${RPS_MASK:-}" ] || echo "${RPS_MASK}" 2> /dev/null > "${queue}/rps_cpus"
+    ;;
+  tx-*)
+    [ -z "${XPS_MASK:-}" ] || echo "${XPS_MASK}" 2> /dev/null > "${queue}/xps_cpus"
+    ;;
+  esac
+  return 0
+}
+
+path=${1}
+[ -n "${path}" ] || { echo "The device path argument is missing" >&2 ; exit 1; }
+# replace x2d with hyphen (-) which is an escaped character
+# that was added by systemd-escape in order to escape the systemd unit name that invokes this script
+path=${path//x2d/-}
+
+dev_path="/sys/${path%%/queues/*}"
+set_irq_affinity
+
+if [[ "${path}" =~ "/queues/" ]]; then
+  set_queue_masks "/sys/${path}"
+else
+  for queue in "${dev_path}"/queues/*; do
+    set_queue_masks "${queue}"
+  done
+fi
@@ -45,6 +45,8 @@ import (
 	"github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator/cmd/calculate"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator/cmd/explain"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/cmd/render"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/hypershift"
 	hcpcomponents "github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/hypershift/components"
@@ -110,6 +112,9 @@ func prepareCommands() {
 	if !config.InHyperShift() {
 		rootCmd.AddCommand(render.NewRenderCommand())
 		rootCmd.AddCommand(tunedrender.NewRenderBootCmdMCCommand())
+		rootCmd.AddCommand(tunedrender.NewRenderEffectiveProfileCommand())
+		rootCmd.AddCommand(explain.NewExplainCommand())
+		rootCmd.AddCommand(calculate.NewCalculateCommand())
 	}
 	rootCmd.AddCommand(operand.NewTunedCommand())
 }
@@ -164,6 +169,12 @@ func operatorRun() {
 		klog.Fatalf("failed to add new controller to the manager: %v", err)
 	}
 
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("failed to create Kubernetes client: %v", err)
+	}
+	// The metrics authorization settings are updated from OperatorConfig by the controller.
+	metrics.EnableAuth(kubeClient, config.OperatorNamespace())
 	if err := mgr.Add(metrics.Server{}); err != nil {
 		klog.Fatalf("unable to add metrics server as runnable under the manager: %v", err)
 	}
@@ -192,6 +203,10 @@ func operatorRun() {
 		if err = (&performancev2.PerformanceProfile{}).SetupWebhookWithManager(mgr); err != nil {
 			klog.Exitf("unable to create PerformanceProfile v2 webhook: %v", err)
 		}
+
+		if err = (&tunedv1.Tuned{}).SetupWebhookWithManager(mgr); err != nil {
+			klog.Exitf("unable to create Tuned webhook: %v", err)
+		}
 	} else {
 		operatorNamespace := config.OperatorNamespace()
 		fg, err := setupFeatureGates(context.TODO(), restConfig, operatorNamespace)
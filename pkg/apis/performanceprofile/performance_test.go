@@ -16,6 +16,10 @@ const (
 	crdFilename        = "../../../manifests/20-performance-profile.crd.yaml"
 	lastHeartbeatPath  = "/status/conditions/lastHeartbeatTime"
 	lastTransitionPath = "/status/conditions/lastTransitionTime"
+	// resource.Quantity is represented as int-or-string in the CRD
+	kubeReservedPath   = "/spec/kubelet/kubeReserved"
+	systemReservedPath = "/spec/kubelet/systemReserved"
+	numaReservedPath   = "/spec/numa/reservedMemory/nodes/memory"
 )
 
 var _ = Describe("PerformanceProfile CR(D) Schema", func() {
@@ -33,6 +37,11 @@ var _ = Describe("PerformanceProfile CR(D) Schema", func() {
 		pathOmissions := []string{
 			lastHeartbeatPath,
 			lastTransitionPath,
+			kubeReservedPath + "/memory",
+			kubeReservedPath + "/ephemeralStorage",
+			systemReservedPath + "/memory",
+			systemReservedPath + "/ephemeralStorage",
+			numaReservedPath,
 		}
 		missingEntries := getMissingEntries(schema, &performancev2.PerformanceProfile{}, pathOmissions...)
 		Expect(missingEntries).To(BeEmpty())
@@ -18,6 +18,7 @@ package v1
 
 import (
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -81,6 +82,14 @@ type PerformanceProfileSpec struct {
 	// kernel arguments that should be applied on top of the node.
 	// +optional
 	WorkloadHints *WorkloadHints `json:"workloadHints,omitempty"`
+	// Kubelet defines a set of kubelet resource reservations, eviction thresholds and resource managers settings.
+	// These settings take precedence over the same settings of the kubeletconfig.experimental annotation.
+	// +optional
+	Kubelet *Kubelet `json:"kubelet,omitempty"`
+	// Memory defines a set of memory subsystem tuning parameters, transparent huge pages,
+	// NUMA balancing, kernel same-page merging and virtual memory settings.
+	// +optional
+	Memory *Memory `json:"memory,omitempty"`
 }
 
 // CPUSet defines the set of CPUs(0-3,8-11).
@@ -131,8 +140,27 @@ type HugePages struct {
 	DefaultHugePagesSize *HugePageSize `json:"defaultHugepagesSize,omitempty"`
 	// Pages defines huge pages that we want to allocate at boot time.
 	Pages []HugePage `json:"pages,omitempty"`
+	// NUMAAllocation defines how the huge pages of the specific NUMA nodes are allocated,
+	// "Runtime" by a systemd service once the node booted or "Boot" via the per-node kernel boot
+	// parameter 'hugepages=<node>:<count>,...'. Allocating the pages at boot time avoids failures
+	// caused by the memory fragmentation, the systemd service still allocates the pages the kernel
+	// did not allocate, e.g. on kernels not supporting the per-node boot parameter.
+	// Defaults to "Runtime".
+	// +kubebuilder:validation:Enum=Runtime;Boot
+	// +optional
+	NUMAAllocation *HugePagesNUMAAllocation `json:"numaAllocation,omitempty"`
 }
 
+// HugePagesNUMAAllocation defines how the huge pages of the specific NUMA nodes are allocated.
+type HugePagesNUMAAllocation string
+
+const (
+	// HugePagesNUMAAllocationRuntime allocates the huge pages of the specific NUMA nodes by a systemd service.
+	HugePagesNUMAAllocationRuntime HugePagesNUMAAllocation = "Runtime"
+	// HugePagesNUMAAllocationBoot allocates the huge pages of the specific NUMA nodes via the kernel boot parameters.
+	HugePagesNUMAAllocationBoot HugePagesNUMAAllocation = "Boot"
+)
+
 // HugePage defines the number of allocated huge pages of the specific size.
 type HugePage struct {
 	// Size defines huge page size, maps to the 'hugepagesz' kernel boot parameter.
@@ -151,6 +179,60 @@ type NUMA struct {
 	// Operator defaults to "best-effort"
 	// +optional
 	TopologyPolicy *string `json:"topologyPolicy,omitempty"`
+	// ReservedMemory defines how the memory reserved for the system is distributed across the NUMA nodes
+	// by the static memory manager policy. By default, all the reserved memory is reserved on NUMA node 0.
+	// +optional
+	ReservedMemory *NUMAReservedMemory `json:"reservedMemory,omitempty"`
+}
+
+// ReservedMemoryMode defines how the reserved memory is distributed across the NUMA nodes.
+type ReservedMemoryMode string
+
+const (
+	// ReservedMemoryModeEvenSplit splits the reserved memory and hugepages evenly across the NUMA nodes.
+	ReservedMemoryModeEvenSplit ReservedMemoryMode = "EvenSplit"
+	// ReservedMemoryModeExplicit reserves the memory and hugepages specified for each NUMA node.
+	ReservedMemoryModeExplicit ReservedMemoryMode = "Explicit"
+)
+
+// NUMAReservedMemory defines the distribution of the reserved memory across the NUMA nodes.
+type NUMAReservedMemory struct {
+	// Mode defines how the reserved memory is distributed across the NUMA nodes, "EvenSplit" or "Explicit".
+	// +kubebuilder:validation:Enum=EvenSplit;Explicit
+	Mode ReservedMemoryMode `json:"mode"`
+	// NUMANodes defines the number of NUMA nodes of the nodes the reserved memory is split across
+	// in the "EvenSplit" mode.
+	// +optional
+	NUMANodes *int32 `json:"numaNodes,omitempty"`
+	// HugePages defines the hugepages reserved for the system in the "EvenSplit" mode.
+	// The pages are split evenly across the NUMA nodes, the remainder goes to the lowest NUMA nodes.
+	// +optional
+	HugePages []HugePagesReservation `json:"hugepages,omitempty"`
+	// Nodes defines the memory and hugepages reserved on each NUMA node in the "Explicit" mode.
+	// The reserved memory of all the NUMA nodes must add up to the kube-reserved, system-reserved
+	// and hard eviction memory.
+	// +optional
+	Nodes []NUMANodeReservedMemory `json:"nodes,omitempty"`
+}
+
+// NUMANodeReservedMemory defines the memory reserved on a NUMA node.
+type NUMANodeReservedMemory struct {
+	// Node defines the NUMA node ID.
+	Node int32 `json:"node"`
+	// Memory defines the amount of memory reserved on the NUMA node.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// HugePages defines the hugepages reserved on the NUMA node.
+	// +optional
+	HugePages []HugePagesReservation `json:"hugepages,omitempty"`
+}
+
+// HugePagesReservation defines the number of reserved huge pages of the specific size.
+type HugePagesReservation struct {
+	// Size defines the huge page size.
+	Size HugePageSize `json:"size"`
+	// Count defines the number of reserved huge pages.
+	Count int32 `json:"count"`
 }
 
 // Net defines a set of network related features
@@ -175,6 +257,25 @@ type Device struct {
 	// Network device ID (model) represnted as a 16 bit hexmadecimal number.
 	// +optional
 	DeviceID *string `json:"deviceID,omitempty"`
+	// Queues defines the number of combined channels of the matched network devices,
+	// overrides the reserved CPUs count set when the user level networking is enabled.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Queues *int32 `json:"queues,omitempty"`
+	// IRQAffinity defines the CPUs the interrupts of the matched physical network devices are pinned to.
+	// The CPUs must be a subset of the reserved and isolated CPUs.
+	// +optional
+	IRQAffinity *CPUSet `json:"irqAffinity,omitempty"`
+	// RPSCPUs defines the CPUs the receive packet steering (RPS) of all the receive queues
+	// of the matched physical network devices is set to. An empty set disables the RPS.
+	// The CPUs must be a subset of the reserved and isolated CPUs.
+	// +optional
+	RPSCPUs *CPUSet `json:"rpsCPUs,omitempty"`
+	// XPSCPUs defines the CPUs the transmit packet steering (XPS) of all the transmit queues
+	// of the matched physical network devices is set to. An empty set disables the XPS.
+	// The CPUs must be a subset of the reserved and isolated CPUs.
+	// +optional
+	XPSCPUs *CPUSet `json:"xpsCPUs,omitempty"`
 }
 
 // RealTimeKernel defines the set of parameters relevant for the real time kernel.
@@ -199,6 +300,120 @@ type WorkloadHints struct {
 	PerPodPowerManagement *bool `json:"perPodPowerManagement,omitempty"`
 }
 
+// Kubelet defines a set of kubelet related parameters.
+type Kubelet struct {
+	// KubeReserved defines the resources reserved for the Kubernetes system daemons.
+	// Defaults to 500Mi of memory.
+	// +optional
+	KubeReserved *ResourceReservation `json:"kubeReserved,omitempty"`
+	// SystemReserved defines the resources reserved for the non-Kubernetes system daemons.
+	// Defaults to 500Mi of memory.
+	// +optional
+	SystemReserved *ResourceReservation `json:"systemReserved,omitempty"`
+	// EvictionHard defines the hard eviction thresholds of the kubelet.
+	// Defaults to 100Mi of available memory, 10% of available nodefs, 15% of available imagefs
+	// and 5% of free nodefs inodes.
+	// +optional
+	EvictionHard *EvictionThresholds `json:"evictionHard,omitempty"`
+	// CPUManagerPolicyOptions defines additional options of the static CPU manager policy,
+	// for example "prefer-align-cpus-by-uncorecache": "true".
+	// "full-pcpus-only" defaults to "true" with the single-numa-node topology policy.
+	// +optional
+	CPUManagerPolicyOptions map[string]string `json:"cpuManagerPolicyOptions,omitempty"`
+	// MemoryManagerPolicy defines the memory manager policy, "None" or "Static".
+	// Defaults to "Static" with the restricted and single-numa-node topology policies, otherwise to "None".
+	// +optional
+	MemoryManagerPolicy *string `json:"memoryManagerPolicy,omitempty"`
+	// TopologyManagerPolicyOptions defines additional options of the topology manager policy,
+	// for example "prefer-closest-numa-nodes": "true".
+	// +optional
+	TopologyManagerPolicyOptions map[string]string `json:"topologyManagerPolicyOptions,omitempty"`
+	// TopologyManagerScope defines the granularity of the topology alignment, "container" or "pod".
+	// Defaults to "container".
+	// +optional
+	TopologyManagerScope *string `json:"topologyManagerScope,omitempty"`
+}
+
+// ResourceReservation defines the amount of resources reserved for system daemons.
+type ResourceReservation struct {
+	// Memory defines the amount of reserved memory.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// EphemeralStorage defines the amount of reserved local ephemeral storage.
+	// +optional
+	EphemeralStorage *resource.Quantity `json:"ephemeralStorage,omitempty"`
+}
+
+// EvictionThresholds defines the eviction thresholds of the kubelet eviction signals.
+// Each threshold is either a quantity, for example "100Mi", or a percentage, for example "10%".
+type EvictionThresholds struct {
+	// MemoryAvailable defines the threshold of the memory.available eviction signal.
+	// +optional
+	MemoryAvailable *string `json:"memoryAvailable,omitempty"`
+	// NodefsAvailable defines the threshold of the nodefs.available eviction signal.
+	// +optional
+	NodefsAvailable *string `json:"nodefsAvailable,omitempty"`
+	// NodefsInodesFree defines the threshold of the nodefs.inodesFree eviction signal.
+	// +optional
+	NodefsInodesFree *string `json:"nodefsInodesFree,omitempty"`
+	// ImagefsAvailable defines the threshold of the imagefs.available eviction signal.
+	// +optional
+	ImagefsAvailable *string `json:"imagefsAvailable,omitempty"`
+}
+
+// Memory defines a set of memory subsystem related parameters.
+type Memory struct {
+	// TransparentHugePages defines the transparent huge pages mode, "never", "madvise" or "always".
+	// Defaults to "never".
+	// +kubebuilder:validation:Enum=never;madvise;always
+	// +optional
+	TransparentHugePages *TransparentHugePagesMode `json:"transparentHugePages,omitempty"`
+	// NUMABalancing defines if the automatic NUMA balancing should be enabled (kernel.numa_balancing).
+	// The real time kernel does not support the automatic NUMA balancing. Defaults to false.
+	// +optional
+	NUMABalancing *bool `json:"numaBalancing,omitempty"`
+	// KSM defines if the kernel same-page merging should be enabled. The system setting is kept if not specified.
+	// +optional
+	KSM *bool `json:"ksm,omitempty"`
+	// StatInterval defines the interval in seconds the virtual memory statistics are updated at (vm.stat_interval).
+	// Defaults to 10 when the real time workload hint is enabled, the system setting is kept otherwise.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StatInterval *int32 `json:"statInterval,omitempty"`
+	// DirtyRatio defines the percentage of the available memory holding dirty data the processes
+	// writing the data start the writeback at (vm.dirty_ratio). Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	DirtyRatio *int32 `json:"dirtyRatio,omitempty"`
+	// DirtyBackgroundRatio defines the percentage of the available memory holding dirty data
+	// the background writeback starts at (vm.dirty_background_ratio). Must be lower than the dirty ratio.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	DirtyBackgroundRatio *int32 `json:"dirtyBackgroundRatio,omitempty"`
+	// Swappiness defines the tendency of the kernel to swap the anonymous memory out (vm.swappiness).
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=200
+	// +optional
+	Swappiness *int32 `json:"swappiness,omitempty"`
+}
+
+// TransparentHugePagesMode defines the transparent huge pages mode.
+type TransparentHugePagesMode string
+
+// Supported transparent huge pages modes.
+const (
+	// TransparentHugePagesNever disables the transparent huge pages.
+	TransparentHugePagesNever TransparentHugePagesMode = "never"
+	// TransparentHugePagesMadvise enables the transparent huge pages of the madvise(MADV_HUGEPAGE) regions only.
+	TransparentHugePagesMadvise TransparentHugePagesMode = "madvise"
+	// TransparentHugePagesAlways enables the transparent huge pages.
+	TransparentHugePagesAlways TransparentHugePagesMode = "always"
+)
+
 // PerformanceProfileStatus defines the observed state of PerformanceProfile.
 type PerformanceProfileStatus struct {
 	// Conditions represents the latest available observations of current state.
@@ -209,6 +424,24 @@ type PerformanceProfileStatus struct {
 	Tuned *string `json:"tuned,omitempty"`
 	// RuntimeClass contains the name of the RuntimeClass resource created by the operator.
 	RuntimeClass *string `json:"runtimeClass,omitempty"`
+	// HugePagesShortfalls lists the huge pages of the specific NUMA nodes the nodes failed to allocate
+	// as reported by the nodes.
+	// +optional
+	HugePagesShortfalls []HugePagesShortfall `json:"hugepagesShortfalls,omitempty"`
+}
+
+// HugePagesShortfall defines the huge pages of a specific NUMA node a node failed to allocate.
+type HugePagesShortfall struct {
+	// NodeName defines the name of the node.
+	NodeName string `json:"nodeName"`
+	// NUMANode defines the NUMA node ID.
+	NUMANode int32 `json:"numaNode"`
+	// Size defines the huge page size.
+	Size HugePageSize `json:"size"`
+	// Requested defines the number of huge pages requested on the NUMA node.
+	Requested int32 `json:"requested"`
+	// Allocated defines the number of huge pages allocated on the NUMA node.
+	Allocated int32 `json:"allocated"`
 }
 
 // +kubebuilder:object:root=true
@@ -64,6 +64,11 @@ func (curr *PerformanceProfile) ConvertTo(dstRaw conversion.Hub) error {
 				}
 			}
 		}
+
+		if curr.Spec.HugePages.NUMAAllocation != nil {
+			numaAllocation := v1.HugePagesNUMAAllocation(*curr.Spec.HugePages.NUMAAllocation)
+			dst.Spec.HugePages.NUMAAllocation = &numaAllocation
+		}
 	}
 
 	if curr.Spec.MachineConfigLabel != nil {
@@ -106,6 +111,8 @@ func (curr *PerformanceProfile) ConvertTo(dstRaw conversion.Hub) error {
 		if curr.Spec.NUMA.TopologyPolicy != nil {
 			dst.Spec.NUMA.TopologyPolicy = ptr.To[string](*curr.Spec.NUMA.TopologyPolicy)
 		}
+
+		dst.Spec.NUMA.ReservedMemory = convertNUMAReservedMemoryToHub(curr.Spec.NUMA.ReservedMemory)
 	}
 
 	// Convert Net fields
@@ -134,6 +141,22 @@ func (curr *PerformanceProfile) ConvertTo(dstRaw conversion.Hub) error {
 					device.InterfaceName = ptr.To[string](*d.InterfaceName)
 				}
 
+				if d.Queues != nil {
+					device.Queues = ptr.To(*d.Queues)
+				}
+
+				if d.IRQAffinity != nil {
+					device.IRQAffinity = ptr.To(v1.CPUSet(*d.IRQAffinity))
+				}
+
+				if d.RPSCPUs != nil {
+					device.RPSCPUs = ptr.To(v1.CPUSet(*d.RPSCPUs))
+				}
+
+				if d.XPSCPUs != nil {
+					device.XPSCPUs = ptr.To(v1.CPUSet(*d.XPSCPUs))
+				}
+
 				dst.Spec.Net.Devices = append(dst.Spec.Net.Devices, device)
 			}
 		}
@@ -143,6 +166,9 @@ func (curr *PerformanceProfile) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.GloballyDisableIrqLoadBalancing = ptr.To(*curr.Spec.GloballyDisableIrqLoadBalancing)
 	}
 
+	dst.Spec.Kubelet = convertKubeletToHub(curr.Spec.Kubelet)
+	dst.Spec.Memory = convertMemoryToHub(curr.Spec.Memory)
+
 	// Status
 	if curr.Status.Conditions != nil {
 		dst.Status.Conditions = make([]conditionsv1.Condition, len(curr.Status.Conditions))
@@ -157,6 +183,19 @@ func (curr *PerformanceProfile) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.RuntimeClass = ptr.To[string](*curr.Status.RuntimeClass)
 	}
 
+	if curr.Status.HugePagesShortfalls != nil {
+		dst.Status.HugePagesShortfalls = make([]v1.HugePagesShortfall, len(curr.Status.HugePagesShortfalls))
+		for i, sf := range curr.Status.HugePagesShortfalls {
+			dst.Status.HugePagesShortfalls[i] = v1.HugePagesShortfall{
+				NodeName:  sf.NodeName,
+				NUMANode:  sf.NUMANode,
+				Size:      v1.HugePageSize(sf.Size),
+				Requested: sf.Requested,
+				Allocated: sf.Allocated,
+			}
+		}
+	}
+
 	// +kubebuilder:docs-gen:collapse=rote conversion
 	return nil
 }
@@ -204,6 +243,11 @@ func (curr *PerformanceProfile) ConvertFrom(srcRaw conversion.Hub) error {
 				}
 			}
 		}
+
+		if src.Spec.HugePages.NUMAAllocation != nil {
+			numaAllocation := HugePagesNUMAAllocation(*src.Spec.HugePages.NUMAAllocation)
+			curr.Spec.HugePages.NUMAAllocation = &numaAllocation
+		}
 	}
 
 	if src.Spec.MachineConfigLabel != nil {
@@ -246,6 +290,8 @@ func (curr *PerformanceProfile) ConvertFrom(srcRaw conversion.Hub) error {
 		if src.Spec.NUMA.TopologyPolicy != nil {
 			curr.Spec.NUMA.TopologyPolicy = ptr.To[string](*src.Spec.NUMA.TopologyPolicy)
 		}
+
+		curr.Spec.NUMA.ReservedMemory = convertNUMAReservedMemoryFromHub(src.Spec.NUMA.ReservedMemory)
 	}
 
 	// Convert Net fields
@@ -274,6 +320,22 @@ func (curr *PerformanceProfile) ConvertFrom(srcRaw conversion.Hub) error {
 					device.InterfaceName = ptr.To[string](*d.InterfaceName)
 				}
 
+				if d.Queues != nil {
+					device.Queues = ptr.To(*d.Queues)
+				}
+
+				if d.IRQAffinity != nil {
+					device.IRQAffinity = ptr.To(CPUSet(*d.IRQAffinity))
+				}
+
+				if d.RPSCPUs != nil {
+					device.RPSCPUs = ptr.To(CPUSet(*d.RPSCPUs))
+				}
+
+				if d.XPSCPUs != nil {
+					device.XPSCPUs = ptr.To(CPUSet(*d.XPSCPUs))
+				}
+
 				curr.Spec.Net.Devices = append(curr.Spec.Net.Devices, device)
 			}
 		}
@@ -285,6 +347,9 @@ func (curr *PerformanceProfile) ConvertFrom(srcRaw conversion.Hub) error {
 		curr.Spec.GloballyDisableIrqLoadBalancing = ptr.To(true)
 	}
 
+	curr.Spec.Kubelet = convertKubeletFromHub(src.Spec.Kubelet)
+	curr.Spec.Memory = convertMemoryFromHub(src.Spec.Memory)
+
 	// Status
 	if src.Status.Conditions != nil {
 		curr.Status.Conditions = make([]conditionsv1.Condition, len(src.Status.Conditions))
@@ -299,6 +364,288 @@ func (curr *PerformanceProfile) ConvertFrom(srcRaw conversion.Hub) error {
 		curr.Status.RuntimeClass = ptr.To[string](*src.Status.RuntimeClass)
 	}
 
+	if src.Status.HugePagesShortfalls != nil {
+		curr.Status.HugePagesShortfalls = make([]HugePagesShortfall, len(src.Status.HugePagesShortfalls))
+		for i, sf := range src.Status.HugePagesShortfalls {
+			curr.Status.HugePagesShortfalls[i] = HugePagesShortfall{
+				NodeName:  sf.NodeName,
+				NUMANode:  sf.NUMANode,
+				Size:      HugePageSize(sf.Size),
+				Requested: sf.Requested,
+				Allocated: sf.Allocated,
+			}
+		}
+	}
+
 	// +kubebuilder:docs-gen:collapse=rote conversion
 	return nil
 }
+
+// convertKubeletToHub converts the kubelet settings to the Hub version (v1).
+func convertKubeletToHub(src *Kubelet) *v1.Kubelet {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(v1.Kubelet)
+	dst.KubeReserved = convertResourceReservationToHub(src.KubeReserved)
+	dst.SystemReserved = convertResourceReservationToHub(src.SystemReserved)
+
+	if src.EvictionHard != nil {
+		dst.EvictionHard = &v1.EvictionThresholds{
+			MemoryAvailable:  copyStringPtr(src.EvictionHard.MemoryAvailable),
+			NodefsAvailable:  copyStringPtr(src.EvictionHard.NodefsAvailable),
+			NodefsInodesFree: copyStringPtr(src.EvictionHard.NodefsInodesFree),
+			ImagefsAvailable: copyStringPtr(src.EvictionHard.ImagefsAvailable),
+		}
+	}
+
+	if src.CPUManagerPolicyOptions != nil {
+		dst.CPUManagerPolicyOptions = make(map[string]string)
+		for k, v := range src.CPUManagerPolicyOptions {
+			dst.CPUManagerPolicyOptions[k] = v
+		}
+	}
+
+	dst.MemoryManagerPolicy = copyStringPtr(src.MemoryManagerPolicy)
+
+	if src.TopologyManagerPolicyOptions != nil {
+		dst.TopologyManagerPolicyOptions = make(map[string]string)
+		for k, v := range src.TopologyManagerPolicyOptions {
+			dst.TopologyManagerPolicyOptions[k] = v
+		}
+	}
+
+	dst.TopologyManagerScope = copyStringPtr(src.TopologyManagerScope)
+
+	return dst
+}
+
+func convertResourceReservationToHub(src *ResourceReservation) *v1.ResourceReservation {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(v1.ResourceReservation)
+	if src.Memory != nil {
+		dst.Memory = ptr.To(src.Memory.DeepCopy())
+	}
+	if src.EphemeralStorage != nil {
+		dst.EphemeralStorage = ptr.To(src.EphemeralStorage.DeepCopy())
+	}
+
+	return dst
+}
+
+// convertKubeletFromHub converts the kubelet settings from the Hub version (v1).
+func convertKubeletFromHub(src *v1.Kubelet) *Kubelet {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(Kubelet)
+	dst.KubeReserved = convertResourceReservationFromHub(src.KubeReserved)
+	dst.SystemReserved = convertResourceReservationFromHub(src.SystemReserved)
+
+	if src.EvictionHard != nil {
+		dst.EvictionHard = &EvictionThresholds{
+			MemoryAvailable:  copyStringPtr(src.EvictionHard.MemoryAvailable),
+			NodefsAvailable:  copyStringPtr(src.EvictionHard.NodefsAvailable),
+			NodefsInodesFree: copyStringPtr(src.EvictionHard.NodefsInodesFree),
+			ImagefsAvailable: copyStringPtr(src.EvictionHard.ImagefsAvailable),
+		}
+	}
+
+	if src.CPUManagerPolicyOptions != nil {
+		dst.CPUManagerPolicyOptions = make(map[string]string)
+		for k, v := range src.CPUManagerPolicyOptions {
+			dst.CPUManagerPolicyOptions[k] = v
+		}
+	}
+
+	dst.MemoryManagerPolicy = copyStringPtr(src.MemoryManagerPolicy)
+
+	if src.TopologyManagerPolicyOptions != nil {
+		dst.TopologyManagerPolicyOptions = make(map[string]string)
+		for k, v := range src.TopologyManagerPolicyOptions {
+			dst.TopologyManagerPolicyOptions[k] = v
+		}
+	}
+
+	dst.TopologyManagerScope = copyStringPtr(src.TopologyManagerScope)
+
+	return dst
+}
+
+func convertResourceReservationFromHub(src *v1.ResourceReservation) *ResourceReservation {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(ResourceReservation)
+	if src.Memory != nil {
+		dst.Memory = ptr.To(src.Memory.DeepCopy())
+	}
+	if src.EphemeralStorage != nil {
+		dst.EphemeralStorage = ptr.To(src.EphemeralStorage.DeepCopy())
+	}
+
+	return dst
+}
+
+// convertMemoryToHub converts the memory settings to the Hub version (v1).
+func convertMemoryToHub(src *Memory) *v1.Memory {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(v1.Memory)
+	if src.TransparentHugePages != nil {
+		dst.TransparentHugePages = ptr.To(v1.TransparentHugePagesMode(*src.TransparentHugePages))
+	}
+	if src.NUMABalancing != nil {
+		dst.NUMABalancing = ptr.To(*src.NUMABalancing)
+	}
+	if src.KSM != nil {
+		dst.KSM = ptr.To(*src.KSM)
+	}
+	if src.StatInterval != nil {
+		dst.StatInterval = ptr.To(*src.StatInterval)
+	}
+	if src.DirtyRatio != nil {
+		dst.DirtyRatio = ptr.To(*src.DirtyRatio)
+	}
+	if src.DirtyBackgroundRatio != nil {
+		dst.DirtyBackgroundRatio = ptr.To(*src.DirtyBackgroundRatio)
+	}
+	if src.Swappiness != nil {
+		dst.Swappiness = ptr.To(*src.Swappiness)
+	}
+
+	return dst
+}
+
+// convertMemoryFromHub converts the memory settings from the Hub version (v1).
+func convertMemoryFromHub(src *v1.Memory) *Memory {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(Memory)
+	if src.TransparentHugePages != nil {
+		dst.TransparentHugePages = ptr.To(TransparentHugePagesMode(*src.TransparentHugePages))
+	}
+	if src.NUMABalancing != nil {
+		dst.NUMABalancing = ptr.To(*src.NUMABalancing)
+	}
+	if src.KSM != nil {
+		dst.KSM = ptr.To(*src.KSM)
+	}
+	if src.StatInterval != nil {
+		dst.StatInterval = ptr.To(*src.StatInterval)
+	}
+	if src.DirtyRatio != nil {
+		dst.DirtyRatio = ptr.To(*src.DirtyRatio)
+	}
+	if src.DirtyBackgroundRatio != nil {
+		dst.DirtyBackgroundRatio = ptr.To(*src.DirtyBackgroundRatio)
+	}
+	if src.Swappiness != nil {
+		dst.Swappiness = ptr.To(*src.Swappiness)
+	}
+
+	return dst
+}
+
+// convertNUMAReservedMemoryToHub converts the NUMA reserved memory settings to the Hub version (v1).
+func convertNUMAReservedMemoryToHub(src *NUMAReservedMemory) *v1.NUMAReservedMemory {
+	if src == nil {
+		return nil
+	}
+
+	dst := &v1.NUMAReservedMemory{
+		Mode:      v1.ReservedMemoryMode(src.Mode),
+		HugePages: convertHugePagesReservationsToHub(src.HugePages),
+	}
+	if src.NUMANodes != nil {
+		dst.NUMANodes = ptr.To[int32](*src.NUMANodes)
+	}
+
+	if src.Nodes != nil {
+		dst.Nodes = make([]v1.NUMANodeReservedMemory, len(src.Nodes))
+		for i, n := range src.Nodes {
+			dst.Nodes[i] = v1.NUMANodeReservedMemory{
+				Node:      n.Node,
+				HugePages: convertHugePagesReservationsToHub(n.HugePages),
+			}
+			if n.Memory != nil {
+				dst.Nodes[i].Memory = ptr.To(n.Memory.DeepCopy())
+			}
+		}
+	}
+
+	return dst
+}
+
+func convertHugePagesReservationsToHub(src []HugePagesReservation) []v1.HugePagesReservation {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1.HugePagesReservation, len(src))
+	for i, r := range src {
+		dst[i] = v1.HugePagesReservation{Size: v1.HugePageSize(r.Size), Count: r.Count}
+	}
+
+	return dst
+}
+
+// convertNUMAReservedMemoryFromHub converts the NUMA reserved memory settings from the Hub version (v1).
+func convertNUMAReservedMemoryFromHub(src *v1.NUMAReservedMemory) *NUMAReservedMemory {
+	if src == nil {
+		return nil
+	}
+
+	dst := &NUMAReservedMemory{
+		Mode:      ReservedMemoryMode(src.Mode),
+		HugePages: convertHugePagesReservationsFromHub(src.HugePages),
+	}
+	if src.NUMANodes != nil {
+		dst.NUMANodes = ptr.To[int32](*src.NUMANodes)
+	}
+
+	if src.Nodes != nil {
+		dst.Nodes = make([]NUMANodeReservedMemory, len(src.Nodes))
+		for i, n := range src.Nodes {
+			dst.Nodes[i] = NUMANodeReservedMemory{
+				Node:      n.Node,
+				HugePages: convertHugePagesReservationsFromHub(n.HugePages),
+			}
+			if n.Memory != nil {
+				dst.Nodes[i].Memory = ptr.To(n.Memory.DeepCopy())
+			}
+		}
+	}
+
+	return dst
+}
+
+func convertHugePagesReservationsFromHub(src []v1.HugePagesReservation) []HugePagesReservation {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]HugePagesReservation, len(src))
+	for i, r := range src {
+		dst[i] = HugePagesReservation{Size: HugePageSize(r.Size), Count: r.Count}
+	}
+
+	return dst
+}
+
+func copyStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return ptr.To[string](*s)
+}
@@ -22,13 +22,18 @@ import (
 	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,6 +44,7 @@ import (
 
 	"k8s.io/klog"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/cpuset"
 )
 
 const (
@@ -54,6 +60,21 @@ const (
 	aarch64           = "arm64"
 )
 
+// The kubelet policy options and the validators of their values.
+var cpuManagerPolicyOptions = map[string]func(string) error{
+	"full-pcpus-only":                  validateBoolOption,
+	"distribute-cpus-across-numa":      validateBoolOption,
+	"align-by-socket":                  validateBoolOption,
+	"distribute-cpus-across-cores":     validateBoolOption,
+	"strict-cpu-reservation":           validateBoolOption,
+	"prefer-align-cpus-by-uncorecache": validateBoolOption,
+}
+
+var topologyManagerPolicyOptions = map[string]func(string) error{
+	"prefer-closest-numa-nodes": validateBoolOption,
+	"max-allowable-numa-nodes":  validateIntOption,
+}
+
 var x86ValidHugepagesSizes = []string{
 	hugepagesSize2M,
 	hugepagesSize1G,
@@ -165,9 +186,12 @@ func (r *PerformanceProfile) ValidateBasicFields() field.ErrorList {
 	allErrs = append(allErrs, r.validateKernelPageSize(nodes)...)
 	allErrs = append(allErrs, r.validateHugePages(nodes)...)
 	allErrs = append(allErrs, r.validateNUMA()...)
+	allErrs = append(allErrs, r.validateNUMAReservedMemory(nodes)...)
 	allErrs = append(allErrs, r.validateNet()...)
 	allErrs = append(allErrs, r.validateWorkloadHints()...)
 	allErrs = append(allErrs, r.validateCpuFrequency()...)
+	allErrs = append(allErrs, r.validateKubelet()...)
+	allErrs = append(allErrs, r.validateMemory()...)
 
 	return allErrs
 }
@@ -464,6 +488,37 @@ func (r *PerformanceProfile) validateHugePages(nodes corev1.NodeList) field.Erro
 		allErrs = append(allErrs, r.validatePageDuplication(&page, r.Spec.HugePages.Pages[i+1:])...)
 	}
 
+	allErrs = append(allErrs, r.validateHugePagesNUMAAllocation()...)
+
+	return allErrs
+}
+
+// validateHugePagesNUMAAllocation makes sure the kernel boot parameters of the huge pages
+// allocated at boot time can be generated, the kernel only accepts a single 'hugepages'
+// parameter per page size.
+func (r *PerformanceProfile) validateHugePagesNUMAAllocation() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.HugePages.NUMAAllocation == nil || *r.Spec.HugePages.NUMAAllocation != HugePagesNUMAAllocationBoot {
+		return allErrs
+	}
+
+	withNode := map[HugePageSize]bool{}
+	withoutNode := map[HugePageSize]bool{}
+	for _, page := range r.Spec.HugePages.Pages {
+		if page.Node != nil {
+			withNode[page.Size] = true
+		} else {
+			withoutNode[page.Size] = true
+		}
+	}
+	for _, page := range r.Spec.HugePages.Pages {
+		if page.Node == nil && withNode[page.Size] && withoutNode[page.Size] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec.hugepages.pages"), r.Spec.HugePages.Pages,
+				fmt.Sprintf("the pages with the size %q must all either specify the NUMA node or not when allocated at boot time", page.Size)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -586,6 +641,142 @@ func (r *PerformanceProfile) validateNUMA() field.ErrorList {
 	return allErrs
 }
 
+func (r *PerformanceProfile) validateNUMAReservedMemory(nodes corev1.NodeList) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.NUMA == nil || r.Spec.NUMA.ReservedMemory == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("spec.numa.reservedMemory")
+	reserved := r.Spec.NUMA.ReservedMemory
+
+	if !r.staticMemoryManagerPolicy() {
+		allErrs = append(allErrs, field.Invalid(path, reserved.Mode, "the reserved memory can only be distributed across the NUMA nodes with the static memory manager policy"))
+	}
+
+	var numaNodeIDs []int32
+	switch reserved.Mode {
+	case ReservedMemoryModeEvenSplit:
+		if reserved.NUMANodes == nil || *reserved.NUMANodes <= 0 {
+			allErrs = append(allErrs, field.Required(path.Child("numaNodes"), "a positive number of NUMA nodes is required in the EvenSplit mode"))
+		} else {
+			numaNodeIDs = append(numaNodeIDs, *reserved.NUMANodes-1)
+		}
+		if len(reserved.Nodes) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("nodes"), "the reserved memory of the NUMA nodes can only be set in the Explicit mode"))
+		}
+		allErrs = append(allErrs, r.validateHugePagesReservations(path.Child("hugepages"), reserved.HugePages)...)
+
+	case ReservedMemoryModeExplicit:
+		if len(reserved.Nodes) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("nodes"), "the reserved memory of the NUMA nodes is required in the Explicit mode"))
+		}
+		if reserved.NUMANodes != nil || len(reserved.HugePages) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path, "numaNodes and hugepages can only be set in the EvenSplit mode"))
+		}
+		seen := sets.New[int32]()
+		for i, node := range reserved.Nodes {
+			nodePath := path.Child("nodes").Index(i)
+			if node.Node < 0 {
+				allErrs = append(allErrs, field.Invalid(nodePath.Child("node"), node.Node, "NUMA node ID can not be negative"))
+			} else if seen.Has(node.Node) {
+				allErrs = append(allErrs, field.Duplicate(nodePath.Child("node"), node.Node))
+			}
+			seen.Insert(node.Node)
+			numaNodeIDs = append(numaNodeIDs, node.Node)
+
+			if node.Memory != nil && node.Memory.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(nodePath.Child("memory"), node.Memory.String(), "reserved memory can not be negative"))
+			}
+			allErrs = append(allErrs, r.validateHugePagesReservations(nodePath.Child("hugepages"), node.HugePages)...)
+		}
+
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), reserved.Mode,
+			[]string{string(ReservedMemoryModeEvenSplit), string(ReservedMemoryModeExplicit)}))
+	}
+
+	// validate the NUMA nodes exist on the nodes which report their NUMA topology
+	if len(numaNodeIDs) > 0 {
+		maxID := slices.Max(numaNodeIDs)
+		for _, node := range nodes.Items {
+			numaNodes := getNUMANodesForNode(node)
+			if numaNodes == 0 {
+				continue
+			}
+			if reserved.Mode == ReservedMemoryModeEvenSplit && reserved.NUMANodes != nil && *reserved.NUMANodes != numaNodes {
+				allErrs = append(allErrs, field.Invalid(path.Child("numaNodes"), *reserved.NUMANodes,
+					fmt.Sprintf("the node %q has %d NUMA nodes", node.Name, numaNodes)))
+				break
+			}
+			if maxID >= numaNodes {
+				allErrs = append(allErrs, field.Invalid(path.Child("nodes"), maxID,
+					fmt.Sprintf("the node %q has only %d NUMA nodes", node.Name, numaNodes)))
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (r *PerformanceProfile) validateHugePagesReservations(path *field.Path, reservations []HugePagesReservation) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, reservation := range reservations {
+		if reservation.Count <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("count"), reservation.Count, "the number of reserved huge pages must be positive"))
+		}
+
+		allocated := false
+		if r.Spec.HugePages != nil {
+			for _, page := range r.Spec.HugePages.Pages {
+				if page.Size == reservation.Size {
+					allocated = true
+					break
+				}
+			}
+		}
+		if !allocated {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("size"), reservation.Size, "no huge pages of this size are allocated by spec.hugepages.pages"))
+		}
+	}
+
+	return allErrs
+}
+
+// staticMemoryManagerPolicy returns true if the profile configures the kubelet with the static memory manager policy.
+func (r *PerformanceProfile) staticMemoryManagerPolicy() bool {
+	if r.Spec.Kubelet != nil && r.Spec.Kubelet.MemoryManagerPolicy != nil {
+		return *r.Spec.Kubelet.MemoryManagerPolicy == kubeletconfigv1beta1.StaticMemoryManagerPolicy
+	}
+	if r.Spec.NUMA == nil || r.Spec.NUMA.TopologyPolicy == nil {
+		return false
+	}
+	policy := *r.Spec.NUMA.TopologyPolicy
+	return policy == kubeletconfigv1beta1.RestrictedTopologyManagerPolicy || policy == kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy
+}
+
+// getNUMANodesForNode returns the number of NUMA nodes of 'node' reported by the TuneD daemon
+// in the node's tuned Profile or 0 if unknown.
+func getNUMANodesForNode(node corev1.Node) int32 {
+	if validatorClient == nil {
+		return 0
+	}
+
+	profile := &tunedv1.Profile{}
+	key := client.ObjectKey{Namespace: ntoconfig.WatchNamespace(), Name: node.Name}
+	if err := validatorClient.Get(validatorContext, key, profile); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Warningf("failed to get the tuned Profile of node %q: %v", node.Name, err)
+		}
+		return 0
+	}
+
+	return profile.Status.NUMANodes
+}
+
 func (r *PerformanceProfile) validateNet() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -611,6 +802,56 @@ func (r *PerformanceProfile) validateNet() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec.net.devices"), r.Spec.Net.Devices, "device model ID can not be used without specifying the device vendor ID."))
 		}
 	}
+
+	for i, device := range r.Spec.Net.Devices {
+		path := field.NewPath("spec.net.devices").Index(i)
+		if device.Queues != nil && *device.Queues <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("queues"), *device.Queues, "the number of queues must be positive"))
+		}
+		allErrs = append(allErrs, r.validateDeviceCPUs(path.Child("irqAffinity"), device.IRQAffinity, false)...)
+		allErrs = append(allErrs, r.validateDeviceCPUs(path.Child("rpsCPUs"), device.RPSCPUs, true)...)
+		allErrs = append(allErrs, r.validateDeviceCPUs(path.Child("xpsCPUs"), device.XPSCPUs, true)...)
+	}
+
+	return allErrs
+}
+
+// validateDeviceCPUs validates the network device CPUs 'cpus' are a subset of the reserved and isolated CPUs.
+// An empty set is only valid when 'allowEmpty'.
+func (r *PerformanceProfile) validateDeviceCPUs(path *field.Path, cpus *CPUSet, allowEmpty bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cpus == nil {
+		return allErrs
+	}
+
+	set, err := cpuset.Parse(string(*cpus))
+	if err != nil {
+		return append(allErrs, field.Invalid(path, *cpus, fmt.Sprintf("failed to parse the CPUs: %v", err)))
+	}
+	if set.IsEmpty() {
+		if !allowEmpty {
+			allErrs = append(allErrs, field.Invalid(path, *cpus, "the CPUs can not be empty"))
+		}
+		return allErrs
+	}
+
+	if r.Spec.CPU == nil || r.Spec.CPU.Reserved == nil || r.Spec.CPU.Isolated == nil {
+		// validateCPUs reports the missing CPUs
+		return allErrs
+	}
+	reserved, err := cpuset.Parse(string(*r.Spec.CPU.Reserved))
+	if err != nil {
+		return allErrs
+	}
+	isolated, err := cpuset.Parse(string(*r.Spec.CPU.Isolated))
+	if err != nil {
+		return allErrs
+	}
+	if !set.IsSubsetOf(reserved.Union(isolated)) {
+		allErrs = append(allErrs, field.Invalid(path, *cpus, fmt.Sprintf("CPUs %s are neither reserved nor isolated", set.Difference(reserved.Union(isolated)))))
+	}
+
 	return allErrs
 }
 
@@ -671,6 +912,176 @@ func (r *PerformanceProfile) validateCpuFrequency() field.ErrorList {
 	return allErrs
 }
 
+func (r *PerformanceProfile) validateKubelet() field.ErrorList {
+	var allErrs field.ErrorList
+
+	kubelet := r.Spec.Kubelet
+	if kubelet == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("spec.kubelet")
+	allErrs = append(allErrs, validateResourceReservation(path.Child("kubeReserved"), kubelet.KubeReserved)...)
+	allErrs = append(allErrs, validateResourceReservation(path.Child("systemReserved"), kubelet.SystemReserved)...)
+
+	if thresholds := kubelet.EvictionHard; thresholds != nil {
+		evictionPath := path.Child("evictionHard")
+		for name, threshold := range map[string]*string{
+			"memoryAvailable":  thresholds.MemoryAvailable,
+			"nodefsAvailable":  thresholds.NodefsAvailable,
+			"nodefsInodesFree": thresholds.NodefsInodesFree,
+			"imagefsAvailable": thresholds.ImagefsAvailable,
+		} {
+			if threshold == nil {
+				continue
+			}
+			if err := validateEvictionThreshold(*threshold); err != nil {
+				allErrs = append(allErrs, field.Invalid(evictionPath.Child(name), *threshold, err.Error()))
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validatePolicyOptions(path.Child("cpuManagerPolicyOptions"), kubelet.CPUManagerPolicyOptions, cpuManagerPolicyOptions)...)
+	allErrs = append(allErrs, validatePolicyOptions(path.Child("topologyManagerPolicyOptions"), kubelet.TopologyManagerPolicyOptions, topologyManagerPolicyOptions)...)
+
+	if policy := kubelet.MemoryManagerPolicy; policy != nil &&
+		*policy != kubeletconfigv1beta1.NoneMemoryManagerPolicy &&
+		*policy != kubeletconfigv1beta1.StaticMemoryManagerPolicy {
+		allErrs = append(allErrs, field.NotSupported(path.Child("memoryManagerPolicy"), *policy,
+			[]string{kubeletconfigv1beta1.NoneMemoryManagerPolicy, kubeletconfigv1beta1.StaticMemoryManagerPolicy}))
+	}
+
+	if scope := kubelet.TopologyManagerScope; scope != nil &&
+		*scope != kubeletconfigv1beta1.ContainerTopologyManagerScope &&
+		*scope != kubeletconfigv1beta1.PodTopologyManagerScope {
+		allErrs = append(allErrs, field.NotSupported(path.Child("topologyManagerScope"), *scope,
+			[]string{kubeletconfigv1beta1.ContainerTopologyManagerScope, kubeletconfigv1beta1.PodTopologyManagerScope}))
+	}
+
+	return allErrs
+}
+
+func validateResourceReservation(path *field.Path, reservation *ResourceReservation) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if reservation == nil {
+		return allErrs
+	}
+
+	if reservation.Memory != nil && reservation.Memory.Sign() < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("memory"), reservation.Memory.String(), "reserved memory can not be negative"))
+	}
+	if reservation.EphemeralStorage != nil && reservation.EphemeralStorage.Sign() < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("ephemeralStorage"), reservation.EphemeralStorage.String(), "reserved ephemeral storage can not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateEvictionThreshold checks 'threshold' is a non-negative quantity or a percentage.
+func validateEvictionThreshold(threshold string) error {
+	if percentage, ok := strings.CutSuffix(threshold, "%"); ok {
+		value, err := strconv.ParseFloat(percentage, 64)
+		if err != nil || value < 0 || value > 100 {
+			return fmt.Errorf("eviction threshold percentage must be between 0%% and 100%%")
+		}
+		return nil
+	}
+
+	quantity, err := resource.ParseQuantity(threshold)
+	if err != nil {
+		return fmt.Errorf("eviction threshold must be a quantity or a percentage: %v", err)
+	}
+	if quantity.Sign() < 0 {
+		return fmt.Errorf("eviction threshold can not be negative")
+	}
+
+	return nil
+}
+
+func validatePolicyOptions(path *field.Path, options map[string]string, known map[string]func(string) error) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for name, value := range options {
+		validate, ok := known[name]
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(path, name, sets.List(sets.KeySet(known))))
+			continue
+		}
+		if err := validate(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateBoolOption(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("the option value must be a boolean")
+	}
+	return nil
+}
+
+func validateIntOption(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("the option value must be a non-negative integer")
+	}
+	return nil
+}
+
+func (r *PerformanceProfile) validateMemory() field.ErrorList {
+	var allErrs field.ErrorList
+
+	memory := r.Spec.Memory
+	if memory == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("spec.memory")
+	if thp := memory.TransparentHugePages; thp != nil &&
+		*thp != TransparentHugePagesNever && *thp != TransparentHugePagesMadvise && *thp != TransparentHugePagesAlways {
+		allErrs = append(allErrs, field.NotSupported(path.Child("transparentHugePages"), *thp,
+			[]string{string(TransparentHugePagesNever), string(TransparentHugePagesMadvise), string(TransparentHugePagesAlways)}))
+	}
+
+	if memory.NUMABalancing != nil && *memory.NUMABalancing &&
+		r.Spec.RealTimeKernel != nil && r.Spec.RealTimeKernel.Enabled != nil && *r.Spec.RealTimeKernel.Enabled {
+		allErrs = append(allErrs, field.Invalid(path.Child("numaBalancing"), *memory.NUMABalancing, "the real time kernel does not support the automatic NUMA balancing"))
+	}
+
+	if memory.StatInterval != nil && *memory.StatInterval < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("statInterval"), *memory.StatInterval, "stat interval must be at least 1 second"))
+	}
+
+	dirtyRatio := int32(components.DefaultDirtyRatio)
+	if memory.DirtyRatio != nil {
+		dirtyRatio = *memory.DirtyRatio
+		if dirtyRatio < 0 || dirtyRatio > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Child("dirtyRatio"), dirtyRatio, "dirty ratio must be between 0 and 100"))
+		}
+	}
+
+	dirtyBackgroundRatio := int32(components.DefaultDirtyBackgroundRatio)
+	if memory.DirtyBackgroundRatio != nil {
+		dirtyBackgroundRatio = *memory.DirtyBackgroundRatio
+		if dirtyBackgroundRatio < 0 || dirtyBackgroundRatio > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Child("dirtyBackgroundRatio"), dirtyBackgroundRatio, "dirty background ratio must be between 0 and 100"))
+		}
+	}
+
+	if (memory.DirtyRatio != nil || memory.DirtyBackgroundRatio != nil) && dirtyBackgroundRatio >= dirtyRatio {
+		allErrs = append(allErrs, field.Invalid(path.Child("dirtyBackgroundRatio"), dirtyBackgroundRatio,
+			fmt.Sprintf("dirty background ratio must be lower than the dirty ratio %d", dirtyRatio)))
+	}
+
+	if memory.Swappiness != nil && (*memory.Swappiness < 0 || *memory.Swappiness > 200) {
+		allErrs = append(allErrs, field.Invalid(path.Child("swappiness"), *memory.Swappiness, "swappiness must be between 0 and 200"))
+	}
+
+	return allErrs
+}
+
 func (r *PerformanceProfile) getNodesList() (corev1.NodeList, error) {
 	// Get the nodes from the client using the node selector in the profile
 	nodes := &corev1.NodeList{}
@@ -10,9 +10,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"k8s.io/utils/ptr"
 )
 
@@ -704,6 +709,43 @@ var _ = Describe("PerformanceProfile", func() {
 			)
 		})
 
+		When("pages of the specific NUMA nodes are allocated at boot time", func() {
+			BeforeEach(func() {
+				nodeSpecs := []NodeSpecifications{}
+				nodeSpecs = append(nodeSpecs, NodeSpecifications{architecture: amd64, cpuCapacity: 1000, name: "node"})
+				validatorClient = GetFakeValidatorClient(nodeSpecs)
+
+				profile.Spec.HugePages.NUMAAllocation = ptr.To(HugePagesNUMAAllocationBoot)
+			})
+
+			It("should allow the pages of a size on the specific NUMA nodes", func() {
+				nodes, err := profile.getNodesList()
+				Expect(err).To(BeNil())
+
+				profile.Spec.HugePages.Pages = append(profile.Spec.HugePages.Pages, HugePage{
+					Count: 128,
+					Size:  hugepagesSize2M,
+					Node:  ptr.To(int32(0)),
+				})
+				errors := profile.validateHugePages(nodes)
+				Expect(errors).To(BeEmpty(), "should not have validation error")
+			})
+
+			It("should raise the validation error when the pages of a size are also not NUMA node specific", func() {
+				nodes, err := profile.getNodesList()
+				Expect(err).To(BeNil())
+
+				profile.Spec.HugePages.Pages = append(profile.Spec.HugePages.Pages, HugePage{
+					Count: 128,
+					Size:  hugepagesSize1G,
+					Node:  ptr.To(int32(0)),
+				})
+				errors := profile.validateHugePages(nodes)
+				Expect(errors).NotTo(BeEmpty())
+				Expect(errors[0].Error()).To(ContainSubstring(fmt.Sprintf("the pages with the size %q must all either specify the NUMA node or not", hugepagesSize1G)))
+			})
+		})
+
 		When("pages have duplication", func() {
 			Context("with specified NUMA node", func() {
 				It("should raise the validation error (x86)", func() {
@@ -884,6 +926,29 @@ var _ = Describe("PerformanceProfile", func() {
 				Expect(errors[0].Error()).To(ContainSubstring("device model ID can not be used without specifying the device vendor ID."))
 			})
 		})
+		Context("with the device queues, IRQ affinity and RPS/XPS CPUs", func() {
+			It("should allow the reserved and isolated CPUs", func() {
+				profile.Spec.Net.Devices[0].Queues = ptr.To(int32(4))
+				profile.Spec.Net.Devices[0].IRQAffinity = ptr.To(CPUSet("0-1"))
+				profile.Spec.Net.Devices[0].RPSCPUs = ptr.To(CPUSet("2-5"))
+				profile.Spec.Net.Devices[0].XPSCPUs = ptr.To(CPUSet(""))
+				errors := profile.validateNet()
+				Expect(errors).To(BeEmpty(), "should not have validation errors with reserved and isolated CPUs")
+			})
+			It("should raise the validation errors for invalid values", func() {
+				profile.Spec.Net.Devices[0].Queues = ptr.To(int32(0))
+				profile.Spec.Net.Devices[0].IRQAffinity = ptr.To(CPUSet(""))
+				profile.Spec.Net.Devices[0].RPSCPUs = ptr.To(CPUSet("0-3,7"))
+				profile.Spec.Net.Devices[0].XPSCPUs = ptr.To(CPUSet("x"))
+				errors := profile.validateNet()
+				Expect(len(errors)).To(Equal(4))
+				Expect(errors[0].Error()).To(ContainSubstring("the number of queues must be positive"))
+				Expect(errors[1].Error()).To(ContainSubstring("spec.net.devices[0].irqAffinity"))
+				Expect(errors[1].Error()).To(ContainSubstring("the CPUs can not be empty"))
+				Expect(errors[2].Error()).To(ContainSubstring("CPUs 7 are neither reserved nor isolated"))
+				Expect(errors[3].Error()).To(ContainSubstring("failed to parse the CPUs"))
+			})
+		})
 
 		Describe("Workload hints validation", func() {
 			When("realtime kernel is enabled and realtime workload hint is explicitly disabled", func() {
@@ -924,6 +989,192 @@ var _ = Describe("PerformanceProfile", func() {
 		})
 	})
 
+	Describe("NUMA reserved memory validation", func() {
+		var nodes corev1.NodeList
+
+		BeforeEach(func() {
+			// the node reports 2 NUMA nodes in its tuned Profile
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(tunedv1.AddToScheme(scheme)).To(Succeed())
+			node := GetFakeNode(NodeSpecifications{architecture: amd64, cpuCapacity: 1000, name: "node"})
+			tunedProfile := &tunedv1.Profile{
+				ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: ntoconfig.WatchNamespace()},
+				Status:     tunedv1.ProfileStatus{NUMANodes: 2},
+			}
+			validatorClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&node, tunedProfile).Build()
+			nodes = corev1.NodeList{Items: []corev1.Node{node}}
+		})
+
+		It("should accept the even split of the reserved memory", func() {
+			profile.Spec.NUMA.ReservedMemory = &NUMAReservedMemory{
+				Mode:      ReservedMemoryModeEvenSplit,
+				NUMANodes: ptr.To[int32](2),
+				HugePages: []HugePagesReservation{{Size: HugePageSize1G, Count: 2}},
+			}
+			errors := profile.validateNUMAReservedMemory(nodes)
+			Expect(errors).To(BeEmpty(), "should not have validation errors with the even split of the reserved memory")
+		})
+
+		It("should accept the explicit reserved memory", func() {
+			profile.Spec.NUMA.ReservedMemory = &NUMAReservedMemory{
+				Mode: ReservedMemoryModeExplicit,
+				Nodes: []NUMANodeReservedMemory{
+					{Node: 0, Memory: ptr.To(resource.MustParse("1Gi"))},
+					{Node: 1, Memory: ptr.To(resource.MustParse("100Mi")), HugePages: []HugePagesReservation{{Size: HugePageSize1G, Count: 1}}},
+				},
+			}
+			errors := profile.validateNUMAReservedMemory(nodes)
+			Expect(errors).To(BeEmpty(), "should not have validation errors with the explicit reserved memory")
+		})
+
+		It("should reject the NUMA nodes the node does not have", func() {
+			profile.Spec.NUMA.ReservedMemory = &NUMAReservedMemory{
+				Mode:      ReservedMemoryModeEvenSplit,
+				NUMANodes: ptr.To[int32](4),
+			}
+			errors := profile.validateNUMAReservedMemory(nodes)
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Error()).To(ContainSubstring(`the node "node" has 2 NUMA nodes`))
+
+			profile.Spec.NUMA.ReservedMemory = &NUMAReservedMemory{
+				Mode:  ReservedMemoryModeExplicit,
+				Nodes: []NUMANodeReservedMemory{{Node: 0}, {Node: 3}},
+			}
+			errors = profile.validateNUMAReservedMemory(nodes)
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Error()).To(ContainSubstring(`the node "node" has only 2 NUMA nodes`))
+		})
+
+		It("should raise the validation errors for invalid settings", func() {
+			profile.Spec.NUMA.TopologyPolicy = ptr.To(kubeletconfigv1beta1.BestEffortTopologyManagerPolicy)
+			profile.Spec.NUMA.ReservedMemory = &NUMAReservedMemory{
+				Mode: ReservedMemoryModeExplicit,
+				Nodes: []NUMANodeReservedMemory{
+					{Node: 0, HugePages: []HugePagesReservation{{Size: "2M", Count: 0}}},
+					{Node: 0},
+				},
+			}
+			errors := profile.validateNUMAReservedMemory(nodes)
+
+			var fields []string
+			for _, err := range errors {
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf(
+				"spec.numa.reservedMemory",
+				"spec.numa.reservedMemory.nodes[0].hugepages[0].count",
+				"spec.numa.reservedMemory.nodes[0].hugepages[0].size",
+				"spec.numa.reservedMemory.nodes[1].node",
+			))
+		})
+	})
+
+	Describe("Kubelet validation", func() {
+		It("should accept valid kubelet settings", func() {
+			profile.Spec.Kubelet = &Kubelet{
+				KubeReserved: &ResourceReservation{
+					Memory:           ptr.To(resource.MustParse("1Gi")),
+					EphemeralStorage: ptr.To(resource.MustParse("1Gi")),
+				},
+				EvictionHard: &EvictionThresholds{
+					MemoryAvailable: ptr.To("200Mi"),
+					NodefsAvailable: ptr.To("12.5%"),
+				},
+				CPUManagerPolicyOptions:      map[string]string{"prefer-align-cpus-by-uncorecache": "true"},
+				MemoryManagerPolicy:          ptr.To(kubeletconfigv1beta1.StaticMemoryManagerPolicy),
+				TopologyManagerPolicyOptions: map[string]string{"max-allowable-numa-nodes": "16"},
+				TopologyManagerScope:         ptr.To(kubeletconfigv1beta1.PodTopologyManagerScope),
+			}
+			errors := profile.validateKubelet()
+			Expect(errors).To(BeEmpty(), "should not have validation errors with valid kubelet settings")
+		})
+
+		It("should raise the validation errors for invalid kubelet settings", func() {
+			profile.Spec.Kubelet = &Kubelet{
+				SystemReserved: &ResourceReservation{
+					Memory: ptr.To(resource.MustParse("-1Gi")),
+				},
+				EvictionHard: &EvictionThresholds{
+					MemoryAvailable:  ptr.To("100Foo"),
+					ImagefsAvailable: ptr.To("150%"),
+				},
+				CPUManagerPolicyOptions:      map[string]string{"full-pcpus-only": "yes please", "unknown-option": "true"},
+				MemoryManagerPolicy:          ptr.To("static"),
+				TopologyManagerPolicyOptions: map[string]string{"max-allowable-numa-nodes": "-1"},
+				TopologyManagerScope:         ptr.To("node"),
+			}
+			errors := profile.validateKubelet()
+			Expect(errors).To(HaveLen(8))
+
+			var fields []string
+			for _, err := range errors {
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf(
+				"spec.kubelet.systemReserved.memory",
+				"spec.kubelet.evictionHard.memoryAvailable",
+				"spec.kubelet.evictionHard.imagefsAvailable",
+				"spec.kubelet.cpuManagerPolicyOptions[full-pcpus-only]",
+				"spec.kubelet.cpuManagerPolicyOptions",
+				"spec.kubelet.memoryManagerPolicy",
+				"spec.kubelet.topologyManagerPolicyOptions[max-allowable-numa-nodes]",
+				"spec.kubelet.topologyManagerScope",
+			))
+		})
+	})
+
+	Describe("Memory validation", func() {
+		It("should accept valid memory settings", func() {
+			profile.Spec.RealTimeKernel = &RealTimeKernel{Enabled: ptr.To(false)}
+			profile.Spec.Memory = &Memory{
+				TransparentHugePages: ptr.To(TransparentHugePagesMadvise),
+				NUMABalancing:        ptr.To(true),
+				KSM:                  ptr.To(false),
+				StatInterval:         ptr.To[int32](1),
+				DirtyRatio:           ptr.To[int32](40),
+				DirtyBackgroundRatio: ptr.To[int32](10),
+				Swappiness:           ptr.To[int32](0),
+			}
+			errors := profile.validateMemory()
+			Expect(errors).To(BeEmpty(), "should not have validation errors with valid memory settings")
+		})
+
+		It("should raise the validation errors for invalid memory settings", func() {
+			profile.Spec.RealTimeKernel = &RealTimeKernel{Enabled: ptr.To(true)}
+			profile.Spec.Memory = &Memory{
+				TransparentHugePages: ptr.To(TransparentHugePagesMode("sometimes")),
+				NUMABalancing:        ptr.To(true),
+				StatInterval:         ptr.To[int32](0),
+				DirtyRatio:           ptr.To[int32](101),
+				Swappiness:           ptr.To[int32](201),
+			}
+			errors := profile.validateMemory()
+			Expect(errors).To(HaveLen(5))
+
+			var fields []string
+			for _, err := range errors {
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf(
+				"spec.memory.transparentHugePages",
+				"spec.memory.numaBalancing",
+				"spec.memory.statInterval",
+				"spec.memory.dirtyRatio",
+				"spec.memory.swappiness",
+			))
+		})
+
+		It("should reject the dirty background ratio not lower than the default dirty ratio", func() {
+			profile.Spec.Memory = &Memory{
+				DirtyBackgroundRatio: ptr.To[int32](10),
+			}
+			errors := profile.validateMemory()
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Field).To(Equal("spec.memory.dirtyBackgroundRatio"))
+		})
+	})
+
 	Describe("validation of validateFields function", func() {
 		It("should check all fields (x86)", func() {
 			nodeSpecs := []NodeSpecifications{}
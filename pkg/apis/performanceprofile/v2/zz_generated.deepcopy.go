@@ -84,6 +84,26 @@ func (in *Device) DeepCopyInto(out *Device) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IRQAffinity != nil {
+		in, out := &in.IRQAffinity, &out.IRQAffinity
+		*out = new(CPUSet)
+		**out = **in
+	}
+	if in.RPSCPUs != nil {
+		in, out := &in.RPSCPUs, &out.RPSCPUs
+		*out = new(CPUSet)
+		**out = **in
+	}
+	if in.XPSCPUs != nil {
+		in, out := &in.XPSCPUs, &out.XPSCPUs
+		*out = new(CPUSet)
+		**out = **in
+	}
 	return
 }
 
@@ -97,6 +117,42 @@ func (in *Device) DeepCopy() *Device {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionThresholds) DeepCopyInto(out *EvictionThresholds) {
+	*out = *in
+	if in.MemoryAvailable != nil {
+		in, out := &in.MemoryAvailable, &out.MemoryAvailable
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodefsAvailable != nil {
+		in, out := &in.NodefsAvailable, &out.NodefsAvailable
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodefsInodesFree != nil {
+		in, out := &in.NodefsInodesFree, &out.NodefsInodesFree
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagefsAvailable != nil {
+		in, out := &in.ImagefsAvailable, &out.ImagefsAvailable
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionThresholds.
+func (in *EvictionThresholds) DeepCopy() *EvictionThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareTuning) DeepCopyInto(out *HardwareTuning) {
 	*out = *in
@@ -159,6 +215,11 @@ func (in *HugePages) DeepCopyInto(out *HugePages) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NUMAAllocation != nil {
+		in, out := &in.NUMAAllocation, &out.NUMAAllocation
+		*out = new(HugePagesNUMAAllocation)
+		**out = **in
+	}
 	return
 }
 
@@ -172,6 +233,144 @@ func (in *HugePages) DeepCopy() *HugePages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePagesReservation) DeepCopyInto(out *HugePagesReservation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePagesReservation.
+func (in *HugePagesReservation) DeepCopy() *HugePagesReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePagesReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePagesShortfall) DeepCopyInto(out *HugePagesShortfall) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePagesShortfall.
+func (in *HugePagesShortfall) DeepCopy() *HugePagesShortfall {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePagesShortfall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kubelet) DeepCopyInto(out *Kubelet) {
+	*out = *in
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = new(ResourceReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = new(ResourceReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = new(EvictionThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUManagerPolicyOptions != nil {
+		in, out := &in.CPUManagerPolicyOptions, &out.CPUManagerPolicyOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MemoryManagerPolicy != nil {
+		in, out := &in.MemoryManagerPolicy, &out.MemoryManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologyManagerPolicyOptions != nil {
+		in, out := &in.TopologyManagerPolicyOptions, &out.TopologyManagerPolicyOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TopologyManagerScope != nil {
+		in, out := &in.TopologyManagerScope, &out.TopologyManagerScope
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kubelet.
+func (in *Kubelet) DeepCopy() *Kubelet {
+	if in == nil {
+		return nil
+	}
+	out := new(Kubelet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Memory) DeepCopyInto(out *Memory) {
+	*out = *in
+	if in.TransparentHugePages != nil {
+		in, out := &in.TransparentHugePages, &out.TransparentHugePages
+		*out = new(TransparentHugePagesMode)
+		**out = **in
+	}
+	if in.NUMABalancing != nil {
+		in, out := &in.NUMABalancing, &out.NUMABalancing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KSM != nil {
+		in, out := &in.KSM, &out.KSM
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StatInterval != nil {
+		in, out := &in.StatInterval, &out.StatInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DirtyRatio != nil {
+		in, out := &in.DirtyRatio, &out.DirtyRatio
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DirtyBackgroundRatio != nil {
+		in, out := &in.DirtyBackgroundRatio, &out.DirtyBackgroundRatio
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Swappiness != nil {
+		in, out := &in.Swappiness, &out.Swappiness
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Memory.
+func (in *Memory) DeepCopy() *Memory {
+	if in == nil {
+		return nil
+	}
+	out := new(Memory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NUMA) DeepCopyInto(out *NUMA) {
 	*out = *in
@@ -180,6 +379,11 @@ func (in *NUMA) DeepCopyInto(out *NUMA) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReservedMemory != nil {
+		in, out := &in.ReservedMemory, &out.ReservedMemory
+		*out = new(NUMAReservedMemory)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -193,6 +397,65 @@ func (in *NUMA) DeepCopy() *NUMA {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMANodeReservedMemory) DeepCopyInto(out *NUMANodeReservedMemory) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]HugePagesReservation, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMANodeReservedMemory.
+func (in *NUMANodeReservedMemory) DeepCopy() *NUMANodeReservedMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMANodeReservedMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMAReservedMemory) DeepCopyInto(out *NUMAReservedMemory) {
+	*out = *in
+	if in.NUMANodes != nil {
+		in, out := &in.NUMANodes, &out.NUMANodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]HugePagesReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NUMANodeReservedMemory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMAReservedMemory.
+func (in *NUMAReservedMemory) DeepCopy() *NUMAReservedMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMAReservedMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Net) DeepCopyInto(out *Net) {
 	*out = *in
@@ -356,6 +619,16 @@ func (in *PerformanceProfileSpec) DeepCopyInto(out *PerformanceProfileSpec) {
 		*out = new(WorkloadHints)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(Kubelet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(Memory)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -389,6 +662,11 @@ func (in *PerformanceProfileStatus) DeepCopyInto(out *PerformanceProfileStatus)
 		*out = new(string)
 		**out = **in
 	}
+	if in.HugePagesShortfalls != nil {
+		in, out := &in.HugePagesShortfalls, &out.HugePagesShortfalls
+		*out = make([]HugePagesShortfall, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -423,6 +701,32 @@ func (in *RealTimeKernel) DeepCopy() *RealTimeKernel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReservation) DeepCopyInto(out *ResourceReservation) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReservation.
+func (in *ResourceReservation) DeepCopy() *ResourceReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadHints) DeepCopyInto(out *WorkloadHints) {
 	*out = *in
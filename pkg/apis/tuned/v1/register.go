@@ -35,6 +35,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TunedList{},
 		&Profile{},
 		&ProfileList{},
+		&OperatorConfig{},
+		&OperatorConfigList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
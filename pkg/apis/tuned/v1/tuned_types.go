@@ -3,6 +3,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 )
@@ -22,13 +23,30 @@ const (
 	// Name of the NTO operand for versioning in ClusterOperator.
 	TunedOperandName = "ocp-tuned"
 
+	// OperatorConfigResourceName is the name of the cluster-wide OperatorConfig resource
+	// used by the Node Tuning Operator.
+	OperatorConfigResourceName = "cluster"
+
 	// TunedBootcmdlineAnnotationKey is a Node-specific annotation denoting kernel command-line parameters
 	// calculated by TuneD for the current profile applied to that Node.
 	TunedBootcmdlineAnnotationKey string = "tuned.openshift.io/bootcmdline"
 
 	// TunedDeferredUpdate request the tuned daemons to defer the update of the rendered profile
-	// until the next restart.
+	// until the next restart or, if set to "window", until the next maintenance window.
 	TunedDeferredUpdate string = "tuned.openshift.io/deferred"
+
+	// TunedPauseAnnotationKey is a Node-specific annotation which, when set to "true", pauses the
+	// updates of the Node's Profile by the operator.  The TuneD daemon keeps the last applied profile.
+	TunedPauseAnnotationKey string = "tuned.openshift.io/pause"
+
+	// TunedProfileOverrideAnnotationKey is a Node-specific annotation forcing the TuneD profile
+	// of the Node regardless of the recommend rules of the Tuned CRs.  Meant for debugging.
+	TunedProfileOverrideAnnotationKey string = "tuned.openshift.io/profile-override"
+
+	// TunedRolloutAnnotationKey is a Profile annotation set by the operator to the name of the Tuned CR
+	// whose rolloutStrategy governed the last update of the Profile.  Used to resume the rollouts after
+	// the operator restarts.
+	TunedRolloutAnnotationKey string = "tuned.openshift.io/rollout"
 )
 
 /////////////////////////////////////////////////////////////////////////////////
@@ -65,6 +83,40 @@ type TunedSpec struct {
 	// Selection logic for all Tuned profiles.
 	// +optional
 	Recommend []TunedRecommend `json:"recommend"`
+	// Strategy for rolling out Profile changes to the Nodes selected by this Tuned CR.
+	// If omitted, all Profiles selected by this Tuned CR are updated at once.
+	// +optional
+	RolloutStrategy *TunedRolloutStrategy `json:"rolloutStrategy,omitempty"`
+	// Maintenance window during which deferred Profile changes are applied in place.
+	// Only used when the Tuned CR is annotated with tuned.openshift.io/deferred: window.
+	// +optional
+	MaintenanceWindow *TunedMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// Recurring time window for applying deferred Profile changes.
+type TunedMaintenanceWindow struct {
+	// Start of the maintenance window in the standard 5-field cron format
+	// (minute hour day-of-month month day-of-week) evaluated in UTC.
+	// For example, "0 2 * * *" opens the maintenance window every day at 02:00 UTC.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration of the maintenance window, for example "2h".
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Strategy for progressively rolling out Profile changes.
+type TunedRolloutStrategy struct {
+	// Maximum number of Profiles selected by this Tuned CR that can be updated
+	// and not yet reported as applied at the same time.  Value can be an absolute
+	// number (ex: 5) or a percentage of the selected Profiles (ex: 10%).  The absolute
+	// number is calculated from the percentage by rounding up.  Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Halt the rollout when any of the Profiles updated during the rollout reports
+	// Degraded=True.  Defaults to true.
+	// +optional
+	PauseOnDegraded *bool `json:"pauseOnDegraded,omitempty"`
 }
 
 // A Tuned profile.
@@ -97,17 +149,33 @@ type TunedRecommend struct {
 	// Optional operand configuration.
 	// +optional
 	Operand OperandConfig `json:"operand,omitempty"`
+
+	// If true, the Tuned profile is stacked on top of the Tuned profile selected
+	// by the highest-priority matching non-additive rule instead of competing with it.
+	// All matching additive rules are merged in the priority order: options of the
+	// higher-priority profiles override those of the lower-priority ones and all
+	// of them override the options of the selected profile.  The operand
+	// configuration of additive rules is ignored.
+	// +optional
+	Additive bool `json:"additive,omitempty"`
 }
 
 // Rules governing application of a Tuned profile.
 type TunedMatch struct {
-	// Node or Pod label name.
-	Label *string `json:"label"`
+	// Node or Pod label name. If omitted, only labelSelector is considered.
+	// +optional
+	Label *string `json:"label,omitempty"`
 	// Node or Pod label value. If omitted, the presence of label name is enough to match.
 	Value *string `json:"value,omitempty"`
 	// Match type: [node/pod]. If omitted, "node" is assumed.
 	// +kubebuilder:validation:Enum={"node","pod"}
 	Type *string `json:"type,omitempty"`
+	// Node or Pod label selector supporting matchLabels and matchExpressions
+	// (In, NotIn, Exists, DoesNotExist). If specified together with label,
+	// both need to match. For the "pod" match type, the label and labelSelector
+	// need to match the labels of the same Pod running on the Node.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
 	// Additional rules governing application of the tuned profile connected by logical AND operator.
 	Match []TunedMatch `json:"match,omitempty"`
@@ -118,12 +186,28 @@ type OperandConfig struct {
 	// +optional
 	Debug bool `json:"debug,omitempty"`
 
-	// klog logging verbosity
+	// klog logging verbosity.  If omitted, the operandVerbosity of the
+	// OperatorConfig is used.
 	// +optional
-	Verbosity int `json:"verbosity,omitempty"`
+	Verbosity *int `json:"verbosity,omitempty"`
 
 	// +optional
 	TuneDConfig TuneDConfig `json:"tunedConfig,omitempty"`
+
+	// Periodic verification of the tuning applied by the TuneD daemon.
+	// If omitted, the applied tuning is not verified.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+}
+
+// Configuration of the periodic detection of runtime tuning drift.
+type DriftDetection struct {
+	// Interval between verifications of the applied tuning, for example "10m".  Defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Re-apply the TuneD profile when a drift is detected: true/false (default is false)
+	// +optional
+	Reapply bool `json:"reapply,omitempty"`
 }
 
 // Global configuration for the TuneD daemon as defined in tuned-main.conf
@@ -140,11 +224,42 @@ type TunedStatus struct {
 	// +patchStrategy=merge
 	// +optional
 	Conditions []StatusCondition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+
+	// profiles summarizes the state of the Profiles of the Nodes selected by
+	// this Tuned CR per TuneD profile
+	// +optional
+	Profiles []TunedProfileStatus `json:"profiles,omitempty"`
+}
+
+// TunedProfileStatus summarizes the state of the Profiles of the Nodes which
+// use a TuneD profile selected by a Tuned CR.
+type TunedProfileStatus struct {
+	// Name of the TuneD profile.
+	Name string `json:"name"`
+	// Number of Nodes using the TuneD profile.
+	Nodes int32 `json:"nodes"`
+	// Number of Nodes which applied the TuneD profile.
+	Applied int32 `json:"applied"`
+	// Number of Nodes which reported errors applying the TuneD profile.
+	Degraded int32 `json:"degraded"`
+	// Number of Nodes waiting for the next node restart to apply the TuneD profile.
+	Deferred int32 `json:"deferred"`
+	// Names of up to 10 Nodes which reported errors applying the TuneD profile.
+	// +optional
+	FailingNodes []string `json:"failingNodes,omitempty"`
 }
 
 const (
 	// Tuned CR was validated and no problems with it were found.
 	TunedValid ConditionType = "Valid"
+
+	// The rollout of Profile changes for this Tuned CR was halted due to
+	// one or more degraded Profiles.  Only set when rolloutStrategy is used.
+	TunedRolloutHalted ConditionType = "RolloutHalted"
+
+	// Different TuneD profiles with the same priority recommended by this and
+	// possibly other Tuned CRs match the same Node(s).
+	TunedPriorityConflict ConditionType = "PriorityConflict"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -180,6 +295,9 @@ type ProfileSpec struct {
 type ProfileConfig struct {
 	// TuneD profile to apply
 	TunedProfile string `json:"tunedProfile"`
+	// TuneD profiles stacked on top of TunedProfile in the order of their application
+	// +optional
+	AdditiveTunedProfiles []string `json:"additiveTunedProfiles,omitempty"`
 	// option to debug TuneD daemon execution
 	// +optional
 	Debug bool `json:"debug"`
@@ -191,6 +309,12 @@ type ProfileConfig struct {
 	// Name of the cloud provider as taken from the Node providerID: <ProviderName>://<ProviderSpecificNodeID>
 	// +optional
 	ProviderName string `json:"providerName,omitempty"`
+	// Maintenance window for applying deferred updates as defined in the Tuned CR.
+	// +optional
+	MaintenanceWindow *TunedMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Periodic verification of the tuning applied by the TuneD daemon.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
 }
 
 // ProfileStatus is the status for a Profile resource; the status is for internal use only
@@ -208,8 +332,79 @@ type ProfileStatus struct {
 	// If set, this represents the .metadata.generation that the conditions were set based upon.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+
+	// The time the deferred update is scheduled to be applied at, i.e. the start of the next
+	// maintenance window.  Only set when an update is deferred until a maintenance window.
+	// +optional
+	NextApplyTime *metav1.Time `json:"nextApplyTime,omitempty"`
+
+	// diagnostics are the warnings and errors issued by the Tuned daemon during
+	// the last profile application.  The list is deduplicated and bounded.
+	// +listType=atomic
+	// +optional
+	Diagnostics []TunedDiagnostic `json:"diagnostics,omitempty"`
+
+	// numaNodes is the number of online NUMA nodes of the node as detected by the
+	// Tuned daemon.  Zero when unknown.
+	// +optional
+	NUMANodes int32 `json:"numaNodes,omitempty"`
+
+	// hugePages are the huge pages allocated on the NUMA nodes of the node as
+	// detected by the Tuned daemon.  Only the page sizes with pages allocated are listed.
+	// +listType=atomic
+	// +optional
+	HugePages []NUMAHugePages `json:"hugePages,omitempty"`
+}
+
+// NUMAHugePages is the number of huge pages of a size allocated on a NUMA node.
+type NUMAHugePages struct {
+	// node is the NUMA node ID.
+	Node int32 `json:"node"`
+	// sizeKB is the huge page size in kilobytes.
+	SizeKB int64 `json:"sizeKB"`
+	// count is the number of huge pages allocated.
+	Count int32 `json:"count"`
+}
+
+// TunedDiagnostic is a warning or an error issued by the Tuned daemon.
+// +k8s:deepcopy-gen=true
+type TunedDiagnostic struct {
+	// severity of the message, one of Warning, Error.
+	// +kubebuilder:validation:Enum=Warning;Error
+	// +kubebuilder:validation:Required
+	// +required
+	Severity DiagnosticSeverity `json:"severity"`
+
+	// plugin is the Tuned plugin or module which issued the message, e.g. "sysctl".
+	// +optional
+	Plugin string `json:"plugin,omitempty"`
+
+	// message issued by the Tuned daemon.
+	// +kubebuilder:validation:Required
+	// +required
+	Message string `json:"message"`
+
+	// count is the number of times the message was issued.
+	// +optional
+	Count int32 `json:"count,omitempty"`
+
+	// timestamp is the time the message was first issued.
+	// +kubebuilder:validation:Required
+	// +required
+	Timestamp metav1.Time `json:"timestamp"`
 }
 
+// DiagnosticSeverity is the severity of a message issued by the Tuned daemon.
+type DiagnosticSeverity string
+
+const (
+	// DiagnosticSeverityWarning is a message issued at the Tuned WARNING log level.
+	DiagnosticSeverityWarning DiagnosticSeverity = "Warning"
+
+	// DiagnosticSeverityError is a message issued at the Tuned ERROR log level.
+	DiagnosticSeverityError DiagnosticSeverity = "Error"
+)
+
 // StatusCondition represents a partial state of the per-node Profile application.
 // +k8s:deepcopy-gen=true
 type StatusCondition struct {
@@ -250,6 +445,19 @@ const (
 	// application.  To conclude the profile application was successful,
 	// both TunedProfileApplied and TunedDegraded need to be queried.
 	TunedDegraded ConditionType = "Degraded"
+
+	// TunedDrifted indicates the runtime tuning of the node differs from the
+	// tuning applied by the Tuned daemon.  Only reported when drift detection
+	// is enabled.
+	TunedDrifted ConditionType = "Drifted"
+
+	// TunedPaused indicates the operator does not update the Profile due to
+	// the tuned.openshift.io/pause Node annotation.  Only reported when set.
+	TunedPaused ConditionType = "Paused"
+
+	// TunedProfileOverridden indicates the TuneD profile was forced by the
+	// tuned.openshift.io/profile-override Node annotation.  Only reported when set.
+	TunedProfileOverridden ConditionType = "ProfileOverridden"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -259,3 +467,140 @@ type ProfileList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Profile `json:"items"`
 }
+
+/////////////////////////////////////////////////////////////////////////////////
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// OperatorConfig holds the cluster-wide configuration of the Node Tuning Operator.
+// Only the instance named "cluster" is used by the operator.  Settings which are
+// not specified keep the operator defaults.
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired configuration of the Node Tuning Operator.
+	Spec OperatorConfigSpec `json:"spec,omitempty"`
+	// status reports the configuration in effect.
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+type OperatorConfigSpec struct {
+	// Scheduling and resources of the TuneD daemon pods.
+	// +optional
+	DaemonSet DaemonSetConfig `json:"daemonSet,omitempty"`
+
+	// Default klog logging verbosity of the TuneD daemon pods.  Used for the
+	// Nodes whose recommended Tuned profile does not set the operand verbosity.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OperandVerbosity int `json:"operandVerbosity,omitempty"`
+
+	// Period of the full resynchronization of the operator and the TuneD
+	// daemon pods, for example "10m".  Defaults to the RESYNC_PERIOD
+	// environment variable of the operator or 10m if unset.
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// Authentication and authorization of the operator and TuneD daemon
+	// metrics endpoints.
+	// +optional
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+}
+
+// MetricsConfig holds the settings of the metrics endpoints authorization.
+// Clients authenticate by a client certificate or a bearer token and must be
+// allowed the verb on the resource or the non-resource URL of the endpoint.
+type MetricsConfig struct {
+	// Verb the clients must be allowed to perform.  Defaults to "get".
+	// +optional
+	Verb string `json:"verb,omitempty"`
+
+	// Resource in the operator namespace the clients must be allowed the verb
+	// on.  If omitted, the clients must be allowed the verb on the non-resource
+	// URL "/metrics".
+	// +optional
+	Resource *MetricsResourceAttributes `json:"resource,omitempty"`
+
+	// Common names of the client certificates allowed to scrape the metrics,
+	// for example the Prometheus client certificate.  If set, clients without
+	// an allowed client certificate are denied.
+	// +optional
+	ClientCertificateAllowlist []string `json:"clientCertificateAllowlist,omitempty"`
+}
+
+// MetricsResourceAttributes identify the resource the metrics clients must
+// be allowed to access.
+type MetricsResourceAttributes struct {
+	// API group of the resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Resource type, for example "services".
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+
+	// Subresource, for example "proxy".
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+
+	// Name of the resource, any resource of the type if omitted.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// DaemonSetConfig holds the settings of the TuneD DaemonSet pods.
+type DaemonSetConfig struct {
+	// Tolerations of the TuneD daemon pods.  If omitted, the pods tolerate all taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Additional node selector of the TuneD daemon pods.  The pods always select
+	// only Linux Nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Compute resources of the TuneD daemon container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Priority class of the TuneD daemon pods.  Defaults to system-node-critical.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// OperatorConfigStatus reports the configuration in effect, i.e. including the
+// operator defaults.
+type OperatorConfigStatus struct {
+	// The .metadata.generation the status was reported for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Settings of the TuneD DaemonSet pods in effect.
+	// +optional
+	DaemonSet DaemonSetConfig `json:"daemonSet,omitempty"`
+
+	// Default klog logging verbosity of the TuneD daemon pods in effect.
+	// +optional
+	OperandVerbosity int `json:"operandVerbosity,omitempty"`
+
+	// Resynchronization period in effect.
+	// +optional
+	ResyncPeriod metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// Metrics endpoints authorization in effect.
+	// +optional
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfigList is a list of OperatorConfig resources.
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
@@ -0,0 +1,230 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/ini.v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// TuneD profile configuration file name.
+	tunedConfFile = "tuned.conf"
+)
+
+var (
+	// ProfileNamesSeparatorRegex separates the TuneD profile names in "include"
+	// statements and recommended profiles.
+	ProfileNamesSeparatorRegex = regexp.MustCompile(`[\s,;]+`)
+
+	validatorContext = context.TODO()
+
+	// Directory with the stock TuneD profiles shipped with the operator image.
+	tunedProfilesDirSystem = "/usr/lib/tuned"
+)
+
+// ProfileIniLoad loads TuneD profile INI data 'data' with the options
+// mimicking the TuneD configuration parser.
+func ProfileIniLoad(data []byte) (*ini.File, error) {
+	return ini.LoadSources(ini.LoadOptions{
+		AllowBooleanKeys:           true,
+		AllowPythonMultilineValues: true,
+		IgnoreContinuation:         true,
+		KeyValueDelimiters:         "=",
+		PreserveSurroundedQuote:    true,
+		SpaceBeforeInlineComment:   true,
+	}, data)
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateCreate() (admission.Warnings, error) {
+	klog.Infof("Create validation for the Tuned %q", r.Name)
+
+	return r.validateCreateOrUpdate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	klog.Infof("Update validation for the Tuned %q", r.Name)
+
+	return r.validateCreateOrUpdate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateDelete() (admission.Warnings, error) {
+	return admission.Warnings{}, nil
+}
+
+func (r *Tuned) validateCreateOrUpdate() (admission.Warnings, error) {
+	tunedList := &TunedList{}
+	if err := validatorClient.List(validatorContext, tunedList, client.InNamespace(r.Namespace)); err != nil {
+		return admission.Warnings{}, apierrors.NewInternalError(err)
+	}
+
+	stockProfiles, err := stockProfilesGet(tunedProfilesDirSystem)
+	if err != nil {
+		// Do not reject Tuned CRs only because the stock profiles cannot be determined.
+		klog.Warningf("unable to list stock TuneD profiles: %v", err)
+	}
+
+	allErrs := r.validateProfiles(tunedList, stockProfiles)
+	allErrs = append(allErrs, r.validateMaintenanceWindow()...)
+	if len(allErrs) == 0 {
+		return admission.Warnings{}, nil
+	}
+
+	return admission.Warnings{}, apierrors.NewInvalid(Kind("Tuned"), r.Name, allErrs)
+}
+
+// validateMaintenanceWindow validates the maintenance window of Tuned CR 'r'.
+func (r *Tuned) validateMaintenanceWindow() field.ErrorList {
+	var allErrs field.ErrorList
+
+	path := field.NewPath("spec", "maintenanceWindow")
+	window := r.Spec.MaintenanceWindow
+	if window == nil {
+		if r.Annotations[TunedDeferredUpdate] == "window" {
+			allErrs = append(allErrs, field.Required(path, fmt.Sprintf("maintenanceWindow is required for %s=window", TunedDeferredUpdate)))
+		}
+		return allErrs
+	}
+
+	if _, err := cron.ParseStandard(window.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("schedule"), window.Schedule, fmt.Sprintf("invalid cron schedule: %v", err)))
+	}
+	if window.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), window.Duration.String(), "duration must be positive"))
+	}
+
+	return allErrs
+}
+
+// validateProfiles validates the TuneD profiles defined and recommended by
+// Tuned CR 'r' against the other Tuned CRs 'tunedList' in the same namespace
+// and the stock TuneD profiles 'stockProfiles'.  The checks involving stock
+// profiles are skipped when 'stockProfiles' is nil.
+func (r *Tuned) validateProfiles(tunedList *TunedList, stockProfiles map[string]bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// TuneD profile name -> name of the Tuned CR defining it.  Tuned CR 'r' replaces its stored version.
+	otherProfiles := map[string]string{}
+	otherData := map[string]string{}
+	for _, tuned := range tunedList.Items {
+		if tuned.Name == r.Name {
+			continue
+		}
+		for _, profile := range tuned.Spec.Profile {
+			if profile.Name == nil || profile.Data == nil {
+				continue
+			}
+			otherProfiles[*profile.Name] = tuned.Name
+			otherData[*profile.Name] = *profile.Data
+		}
+	}
+
+	profiles := map[string]string{}
+	for i, profile := range r.Spec.Profile {
+		path := field.NewPath("spec", "profile").Index(i)
+		if profile.Name == nil || profile.Data == nil {
+			continue
+		}
+		name := *profile.Name
+
+		if tunedName, found := otherProfiles[name]; found && otherData[name] != *profile.Data {
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), name,
+				fmt.Sprintf("TuneD profile %q with different content is already defined in Tuned %q", name, tunedName)))
+		}
+		if data, found := profiles[name]; found && data != *profile.Data {
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), name,
+				fmt.Sprintf("TuneD profile %q with different content is defined more than once", name)))
+		}
+		profiles[name] = *profile.Data
+
+		cfg, err := ProfileIniLoad([]byte(*profile.Data))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("data"), name, fmt.Sprintf("invalid INI data: %v", err)))
+			continue
+		}
+
+		if stockProfiles == nil || !cfg.Section("main").HasKey("include") {
+			continue
+		}
+		includes := cfg.Section("main").Key("include").String()
+		if strings.Contains(includes, "${") {
+			// Includes using TuneD variables or built-in functions cannot be checked here.
+			continue
+		}
+		for _, include := range ProfileNamesSeparatorRegex.Split(includes, -1) {
+			if len(include) == 0 || strings.HasPrefix(include, "-") {
+				// Conditional includes cannot be checked here.
+				continue
+			}
+			if !isKnownProfile(include, r, otherProfiles, stockProfiles) {
+				allErrs = append(allErrs, field.Invalid(path.Child("data"), include,
+					fmt.Sprintf("TuneD profile %q includes %q which is neither a custom nor a stock TuneD profile", name, include)))
+			}
+		}
+	}
+
+	for i, recommend := range r.Spec.Recommend {
+		if recommend.Profile == nil || stockProfiles == nil {
+			continue
+		}
+		// Multiple TuneD profiles can be recommended, e.g. "openshift-node cpu-partitioning".
+		for _, name := range ProfileNamesSeparatorRegex.Split(*recommend.Profile, -1) {
+			if len(name) == 0 || isKnownProfile(name, r, otherProfiles, stockProfiles) {
+				continue
+			}
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "recommend").Index(i).Child("profile"), *recommend.Profile,
+				fmt.Sprintf("the recommended TuneD profile %q is neither defined by any Tuned CR nor a stock TuneD profile", name)))
+		}
+	}
+
+	return allErrs
+}
+
+// isKnownProfile returns true if TuneD profile 'name' is defined by Tuned CR 'r',
+// by any of the other Tuned CRs 'otherProfiles' or if it is a stock profile.
+func isKnownProfile(name string, r *Tuned, otherProfiles map[string]string, stockProfiles map[string]bool) bool {
+	for _, profile := range r.Spec.Profile {
+		if profile.Name != nil && *profile.Name == name {
+			return true
+		}
+	}
+	if _, found := otherProfiles[name]; found {
+		return true
+	}
+
+	return stockProfiles[name]
+}
+
+// stockProfilesGet returns the names of the stock TuneD profiles in directory 'dir'.
+func stockProfilesGet(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := map[string]bool{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), tunedConfFile)); err == nil {
+			profiles[entry.Name()] = true
+		}
+	}
+
+	return profiles, nil
+}
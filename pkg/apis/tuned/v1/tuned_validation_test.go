@@ -0,0 +1,285 @@
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "openshift-cluster-node-tuning-operator"
+
+func newTestTuned(name string, profiles map[string]string, recommend ...string) *Tuned {
+	tuned := &Tuned{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+	}
+	for profileName, data := range profiles {
+		tuned.Spec.Profile = append(tuned.Spec.Profile, TunedProfile{
+			Name: newString(profileName),
+			Data: newString(data),
+		})
+	}
+	for _, profile := range recommend {
+		tuned.Spec.Recommend = append(tuned.Spec.Recommend, TunedRecommend{
+			Profile: newString(profile),
+		})
+	}
+
+	return tuned
+}
+
+func newString(s string) *string {
+	return &s
+}
+
+func TestValidateProfiles(t *testing.T) {
+	stockProfiles := map[string]bool{
+		"openshift-node": true,
+		"realtime":       true,
+	}
+	others := &TunedList{
+		Items: []Tuned{
+			*newTestTuned("default", map[string]string{
+				"openshift-node": "[main]\nsummary=Optimize systems running OpenShift (provider specific parent profile)\n",
+			}),
+			*newTestTuned("other", map[string]string{
+				"other-profile": "[main]\nsummary=Other profile\n",
+			}, "other-profile"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		tuned         *Tuned
+		stockProfiles map[string]bool
+		errors        []string
+	}{
+		{
+			name: "valid custom profile including a stock profile",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=openshift-node\n[sysctl]\nvm.dirty_ratio=10\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "valid recommend of a profile defined by another Tuned CR",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=other-profile,realtime\n",
+			}, "other-profile"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "update of a Tuned CR with the same name",
+			tuned: newTestTuned("other", map[string]string{
+				"other-profile": "[main]\nsummary=Updated profile\n",
+			}, "other-profile"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "conditional includes and built-in functions are not checked",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=-missing,${f:virt_check:virtual:missing}\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "Python-style multi-line value",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\n[bootloader]\ncmdline=+nohz=on\n  rcu_nocbs=1-3\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "includes separated by semicolons and whitespace",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=openshift-node;other-profile realtime\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "recommend of multiple profiles",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\n",
+			}, "openshift-node custom"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "recommend of multiple profiles with an unknown one",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\n",
+			}, "custom missing"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.recommend[0].profile"},
+		},
+		{
+			name: "recommend of an unknown profile",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\n",
+			}, "missing"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.recommend[0].profile"},
+		},
+		{
+			name: "duplicate profile with conflicting content",
+			tuned: newTestTuned("custom", map[string]string{
+				"other-profile": "[main]\nsummary=Conflicting profile\n",
+			}, "other-profile"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.profile[0].name"},
+		},
+		{
+			name: "duplicate profile with the same content",
+			tuned: newTestTuned("custom", map[string]string{
+				"other-profile": "[main]\nsummary=Other profile\n",
+			}, "other-profile"),
+			stockProfiles: stockProfiles,
+		},
+		{
+			name: "invalid INI data",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main\nsummary=Custom\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.profile[0].data"},
+		},
+		{
+			name: "unknown include",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=openshift-node, missing\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.profile[0].data"},
+		},
+		{
+			name: "unknown include separated by a semicolon",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=openshift-node;missing\n",
+			}, "custom"),
+			stockProfiles: stockProfiles,
+			errors:        []string{"spec.profile[0].data"},
+		},
+		{
+			name: "stock profiles unknown",
+			tuned: newTestTuned("custom", map[string]string{
+				"custom": "[main]\nsummary=Custom\ninclude=missing\n",
+			}, "missing"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.tuned.validateProfiles(others, tc.stockProfiles)
+			if len(errs) != len(tc.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tc.errors), len(errs), errs)
+			}
+			for i, err := range errs {
+				if err.Field != tc.errors[i] {
+					t.Errorf("expected error for field %q, got %q: %v", tc.errors[i], err.Field, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, profile := range []string{"openshift-node", "realtime"} {
+		if err := os.MkdirAll(filepath.Join(dir, profile), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, profile, tunedConfFile), []byte("[main]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A directory without a TuneD profile configuration file.
+	if err := os.MkdirAll(filepath.Join(dir, "recommend.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldDir := tunedProfilesDirSystem
+	tunedProfilesDirSystem = dir
+	defer func() { tunedProfilesDirSystem = oldDir }()
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	validatorClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newTestTuned("other", map[string]string{"other-profile": "[main]\nsummary=Other profile\n"}),
+	).Build()
+	defer func() { validatorClient = nil }()
+
+	valid := newTestTuned("custom", map[string]string{
+		"custom": "[main]\nsummary=Custom\ninclude=openshift-node,other-profile\n",
+	}, "custom", "realtime")
+	if _, err := valid.ValidateCreate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := newTestTuned("custom", map[string]string{
+		"custom": "[main]\nsummary=Custom\ninclude=recommend.d\n",
+	}, "custom")
+	_, err := invalid.ValidateCreate()
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected an invalid error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), `"recommend.d" which is neither a custom nor a stock TuneD profile`) {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		deferred string
+		window   *TunedMaintenanceWindow
+		errors   []string
+	}{
+		{
+			name: "no maintenance window",
+		},
+		{
+			name:     "valid maintenance window",
+			deferred: "window",
+			window:   &TunedMaintenanceWindow{Schedule: "0 2 * * 1-5", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		},
+		{
+			name:     "missing maintenance window",
+			deferred: "window",
+			errors:   []string{"spec.maintenanceWindow"},
+		},
+		{
+			name:   "invalid schedule and duration",
+			window: &TunedMaintenanceWindow{Schedule: "0 2 * *"},
+			errors: []string{"spec.maintenanceWindow.schedule", "spec.maintenanceWindow.duration"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tuned := newTestTuned("custom", nil)
+			tuned.Spec.MaintenanceWindow = tc.window
+			if tc.deferred != "" {
+				tuned.Annotations = map[string]string{TunedDeferredUpdate: tc.deferred}
+			}
+			errs := tuned.validateMaintenanceWindow()
+			if len(errs) != len(tc.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tc.errors), len(errs), errs)
+			}
+			for i, err := range errs {
+				if err.Field != tc.errors[i] {
+					t.Errorf("expected error for field %q, got %q: %v", tc.errors[i], err.Field, err)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ type Listers struct {
 	ClusterOperators   configlisters.ClusterOperatorLister
 	TunedResources     ntolisters.TunedNamespaceLister
 	TunedProfiles      ntolisters.ProfileNamespaceLister
+	OperatorConfigs    ntolisters.OperatorConfigLister
 	MachineConfigs     mcfglisters.MachineConfigLister
 	MachineConfigPools mcfglisters.MachineConfigPoolLister
 }
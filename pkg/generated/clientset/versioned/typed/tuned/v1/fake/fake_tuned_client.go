@@ -27,6 +27,10 @@ type FakeTunedV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTunedV1) OperatorConfigs() v1.OperatorConfigInterface {
+	return &FakeOperatorConfigs{c}
+}
+
 func (c *FakeTunedV1) Profiles(namespace string) v1.ProfileInterface {
 	return &FakeProfiles{c, namespace}
 }
@@ -17,6 +17,8 @@ limitations under the License.
 
 package v1
 
+type OperatorConfigExpansion interface{}
+
 type ProfileExpansion interface{}
 
 type TunedExpansion interface{}
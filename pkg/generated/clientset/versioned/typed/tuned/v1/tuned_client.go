@@ -27,6 +27,7 @@ import (
 
 type TunedV1Interface interface {
 	RESTClient() rest.Interface
+	OperatorConfigsGetter
 	ProfilesGetter
 	TunedsGetter
 }
@@ -36,6 +37,10 @@ type TunedV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TunedV1Client) OperatorConfigs() OperatorConfigInterface {
+	return newOperatorConfigs(c)
+}
+
 func (c *TunedV1Client) Profiles(namespace string) ProfileInterface {
 	return newProfiles(c, namespace)
 }
@@ -52,6 +52,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=tuned.openshift.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("operatorconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tuned().V1().OperatorConfigs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("profiles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tuned().V1().Profiles().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("tuneds"):
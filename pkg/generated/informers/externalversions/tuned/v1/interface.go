@@ -23,6 +23,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// OperatorConfigs returns a OperatorConfigInformer.
+	OperatorConfigs() OperatorConfigInformer
 	// Profiles returns a ProfileInformer.
 	Profiles() ProfileInformer
 	// Tuneds returns a TunedInformer.
@@ -40,6 +42,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// OperatorConfigs returns a OperatorConfigInformer.
+func (v *version) OperatorConfigs() OperatorConfigInformer {
+	return &operatorConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Profiles returns a ProfileInformer.
 func (v *version) Profiles() ProfileInformer {
 	return &profileInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
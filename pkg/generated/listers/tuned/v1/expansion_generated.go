@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1
 
+// OperatorConfigListerExpansion allows custom methods to be added to
+// OperatorConfigLister.
+type OperatorConfigListerExpansion interface{}
+
 // ProfileListerExpansion allows custom methods to be added to
 // ProfileLister.
 type ProfileListerExpansion interface{}
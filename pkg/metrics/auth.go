@@ -0,0 +1,270 @@
+package metrics
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	// AuthConfigEnv is the environment variable passing the metrics authorization
+	// settings (JSON-encoded tunedv1.MetricsConfig) to the operand.
+	AuthConfigEnv = "METRICS_AUTH_CONFIG"
+	// AuthDefaultVerb is the verb the metrics clients must be allowed by default.
+	AuthDefaultVerb = "get"
+
+	// How long to cache the results of the TokenReviews and SubjectAccessReviews.
+	// Prometheus scrapes each endpoint every minute, do not ask the API server every time.
+	authCacheTTL = 2 * time.Minute
+	// Maximum number of cached results.
+	authCacheMax = 1024
+)
+
+// authorizer authenticates the metrics clients by their client certificate or
+// bearer token (TokenReview) and authorizes them (SubjectAccessReview) similarly
+// to kube-rbac-proxy.
+type authorizer struct {
+	client    kubernetes.Interface
+	namespace string // namespace of the resource in the SubjectAccessReviews
+
+	mu     sync.Mutex
+	config tunedv1.MetricsConfig
+	users  map[string]authCacheEntry
+	// Token hash: ^^^^^^
+	decisions map[string]authCacheEntry
+	// User and request attributes: ^^^^^^
+}
+
+type authCacheEntry struct {
+	user    *authenticationv1.UserInfo // authenticated user or nil
+	allowed bool
+	expires time.Time
+}
+
+// EnableAuth turns on the authentication and authorization of the metrics clients
+// using 'client' for the TokenReviews and SubjectAccessReviews.  Resources are
+// authorized in namespace 'namespace'.  Call before the metrics server starts.
+func EnableAuth(client kubernetes.Interface, namespace string) {
+	server.auth = &authorizer{
+		client:    client,
+		namespace: namespace,
+		config:    tunedv1.MetricsConfig{Verb: AuthDefaultVerb},
+		users:     map[string]authCacheEntry{},
+		decisions: map[string]authCacheEntry{},
+	}
+}
+
+// SetAuthConfig sets the metrics authorization settings 'cfg'.
+func SetAuthConfig(cfg tunedv1.MetricsConfig) {
+	a := server.auth
+	if a == nil {
+		return
+	}
+	if cfg.Verb == "" {
+		cfg.Verb = AuthDefaultVerb
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if equalMetricsConfig(a.config, cfg) {
+		return
+	}
+	klog.Infof("metrics server authorization: verb %q, resource %v, client certificate allowlist %v", cfg.Verb, cfg.Resource, cfg.ClientCertificateAllowlist)
+	a.config = cfg
+	clear(a.decisions)
+}
+
+// AuthConfigFromEnv returns the metrics authorization settings passed in the
+// AuthConfigEnv environment variable.
+func AuthConfigFromEnv() tunedv1.MetricsConfig {
+	var cfg tunedv1.MetricsConfig
+
+	env := os.Getenv(AuthConfigEnv)
+	if env == "" {
+		return cfg
+	}
+	if err := json.Unmarshal([]byte(env), &cfg); err != nil {
+		klog.Errorf("failed to parse %s, using the defaults: %v", AuthConfigEnv, err)
+		return tunedv1.MetricsConfig{}
+	}
+	return cfg
+}
+
+func equalMetricsConfig(a, b tunedv1.MetricsConfig) bool {
+	if a.Verb != b.Verb || !slices.Equal(a.ClientCertificateAllowlist, b.ClientCertificateAllowlist) {
+		return false
+	}
+	if a.Resource == nil || b.Resource == nil {
+		return a.Resource == b.Resource
+	}
+	return *a.Resource == *b.Resource
+}
+
+// handler wraps handler 'next' and only passes it the requests of authenticated
+// and authorized clients.
+func (a *authorizer) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		cfg := a.config
+		a.mu.Unlock()
+
+		user, err := a.authenticate(r, cfg)
+		if err != nil {
+			klog.V(2).Infof("metrics client %s unauthenticated: %v", r.RemoteAddr, err)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := a.authorize(r, cfg, user)
+		if err != nil {
+			klog.Errorf("failed to authorize metrics client %s: %v", user.Username, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			klog.V(2).Infof("metrics client %s forbidden", user.Username)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate returns the user of request 'r' identified by a verified client
+// certificate or a bearer token.
+func (a *authorizer) authenticate(r *http.Request, cfg tunedv1.MetricsConfig) (*authenticationv1.UserInfo, error) {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		cert := r.TLS.VerifiedChains[0][0]
+		if len(cfg.ClientCertificateAllowlist) > 0 && !slices.Contains(cfg.ClientCertificateAllowlist, cert.Subject.CommonName) {
+			return nil, fmt.Errorf("client certificate %q not allowed", cert.Subject.CommonName)
+		}
+		return &authenticationv1.UserInfo{
+			Username: cert.Subject.CommonName,
+			Groups:   cert.Subject.Organization,
+		}, nil
+	}
+	if len(cfg.ClientCertificateAllowlist) > 0 {
+		return nil, fmt.Errorf("no client certificate")
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("no client certificate or bearer token")
+	}
+
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+	if entry, ok := a.cached(a.users, key); ok {
+		if entry.user == nil {
+			return nil, fmt.Errorf("invalid bearer token")
+		}
+		return entry.user, nil
+	}
+
+	tr := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	tr, err := a.client.AuthenticationV1().TokenReviews().Create(r.Context(), tr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TokenReview: %v", err)
+	}
+
+	var user *authenticationv1.UserInfo
+	if tr.Status.Authenticated {
+		user = &tr.Status.User
+	}
+	a.cache(a.users, key, authCacheEntry{user: user})
+	if user == nil {
+		return nil, fmt.Errorf("invalid bearer token: %s", tr.Status.Error)
+	}
+
+	return user, nil
+}
+
+// authorize returns true if 'user' is allowed the verb on the resource or
+// the non-resource URL of request 'r' set by 'cfg'.
+func (a *authorizer) authorize(r *http.Request, cfg tunedv1.MetricsConfig, user *authenticationv1.UserInfo) (bool, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  map[string]authorizationv1.ExtraValue{},
+		},
+	}
+	for k, v := range user.Extra {
+		sar.Spec.Extra[k] = authorizationv1.ExtraValue(v)
+	}
+	if res := cfg.Resource; res != nil {
+		sar.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   a.namespace,
+			Verb:        cfg.Verb,
+			Group:       res.Group,
+			Resource:    res.Resource,
+			Subresource: res.Subresource,
+			Name:        res.Name,
+		}
+	} else {
+		sar.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{
+			Path: r.URL.Path,
+			Verb: cfg.Verb,
+		}
+	}
+
+	spec, err := json.Marshal(sar.Spec)
+	if err != nil {
+		return false, err
+	}
+	key := string(spec)
+	if entry, ok := a.cached(a.decisions, key); ok {
+		return entry.allowed, nil
+	}
+
+	sar, err = a.client.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create SubjectAccessReview: %v", err)
+	}
+	a.cache(a.decisions, key, authCacheEntry{allowed: sar.Status.Allowed})
+
+	return sar.Status.Allowed, nil
+}
+
+// cached returns the unexpired entry 'key' of 'cache'.
+func (a *authorizer) cached(cache map[string]authCacheEntry, key string) (authCacheEntry, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return authCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// cache stores 'entry' as 'key' in 'cache'.
+func (a *authorizer) cache(cache map[string]authCacheEntry, key string, entry authCacheEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(cache) >= authCacheMax {
+		// Simply start over, this only happens when many different clients scrape the metrics.
+		clear(cache)
+	}
+	entry.expires = time.Now().Add(authCacheTTL)
+	cache[key] = entry
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestAuthHandler(t *testing.T) {
+	const (
+		validToken = "valid"
+		allowedSA  = "system:serviceaccount:openshift-monitoring:prometheus-k8s"
+	)
+
+	client := fake.NewSimpleClientset()
+	tokenReviews := 0
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tokenReviews++
+		tr := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if tr.Spec.Token == validToken {
+			tr.Status.Authenticated = true
+			tr.Status.User = authenticationv1.UserInfo{Username: allowedSA}
+		}
+		return true, tr, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == allowedSA && sar.Spec.NonResourceAttributes != nil &&
+			sar.Spec.NonResourceAttributes.Verb == "get" && sar.Spec.NonResourceAttributes.Path == "/metrics"
+		return true, sar, nil
+	})
+
+	a := &authorizer{
+		client:    client,
+		namespace: "test",
+		config:    tunedv1.MetricsConfig{Verb: AuthDefaultVerb},
+		users:     map[string]authCacheEntry{},
+		decisions: map[string]authCacheEntry{},
+	}
+	h := a.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	clientCert := func(cn string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+
+	tests := []struct {
+		name      string
+		allowlist []string
+		token     string
+		tls       *tls.ConnectionState
+		want      int
+	}{
+		{
+			name: "no credentials",
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:  "invalid token",
+			token: "invalid",
+			want:  http.StatusUnauthorized,
+		},
+		{
+			name:  "valid token",
+			token: validToken,
+			want:  http.StatusOK,
+		},
+		{
+			name: "client certificate",
+			tls:  clientCert(allowedSA),
+			want: http.StatusOK,
+		},
+		{
+			name: "client certificate forbidden",
+			tls:  clientCert("system:anonymous"),
+			want: http.StatusForbidden,
+		},
+		{
+			name:      "client certificate allowlisted",
+			allowlist: []string{allowedSA},
+			tls:       clientCert(allowedSA),
+			want:      http.StatusOK,
+		},
+		{
+			name:      "client certificate not allowlisted",
+			allowlist: []string{allowedSA},
+			tls:       clientCert("system:admin"),
+			want:      http.StatusUnauthorized,
+		},
+		{
+			name:      "token with allowlist",
+			allowlist: []string{allowedSA},
+			token:     validToken,
+			want:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a.config.ClientCertificateAllowlist = tc.allowlist
+
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			r.TLS = tc.tls
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Errorf("want status %d, have %d", tc.want, w.Code)
+			}
+		})
+	}
+
+	if tokenReviews != 2 {
+		t.Errorf("want 2 TokenReviews, have %d", tokenReviews)
+	}
+}
@@ -1,6 +1,10 @@
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // When adding metric names, see https://prometheus.io/docs/practices/naming/#metric-names
 const (
@@ -9,6 +13,19 @@ const (
 	buildInfoQuery         = "nto_build_info"
 	degradedInfoQuery      = "nto_degraded_info"
 	invalidTunedExistQuery = "nto_invalid_tuned_exist_info"
+	priorityConflictQuery  = "nto_priority_conflict_exist_info"
+	profilesQuery          = "nto_profiles"
+	reconcileDurationQuery = "nto_reconcile_duration_seconds"
+	workqueueDepthQuery    = "nto_workqueue_depth"
+	workqueueRetriesQuery  = "nto_workqueue_retries_total"
+	tunedRolloutQuery      = "nto_tuned_rollout_duration_seconds"
+	machineConfigsQuery    = "nto_machine_configs_managed"
+
+	// Profile states reported by the profilesQuery metric.
+	ProfileStateApplied             = "applied"
+	ProfileStateDegraded            = "degraded"
+	ProfileStateDeferred            = "deferred"
+	ProfileStateBootcmdlineConflict = "bootcmdline-conflict"
 
 	// MetricsPort is the IP port supplied to the HTTP server used for Prometheus,
 	// and matches what is specified in the corresponding Service and ServiceMonitor.
@@ -49,6 +66,55 @@ var (
 			Help: "Does any invalid/misconfigured custom Tuned resource exist (1) or not (0)?",
 		},
 	)
+	priorityConflictExist = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: priorityConflictQuery,
+			Help: "Do different TuneD profiles with the same priority match any node (1) or not (0)?",
+		},
+	)
+	profiles = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profilesQuery,
+			Help: "The number of Profiles in a given state.",
+		},
+		[]string{"state"},
+	)
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    reconcileDurationQuery,
+			Help:    "The time it took to reconcile a workqueue key of a given kind.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"kind"},
+	)
+	workqueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: workqueueDepthQuery,
+			Help: "The number of workqueue keys of a given kind waiting to be reconciled.",
+		},
+		[]string{"kind"},
+	)
+	workqueueRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: workqueueRetriesQuery,
+			Help: "The number of times a workqueue key of a given kind was requeued after a failed reconcile.",
+		},
+		[]string{"kind"},
+	)
+	tunedRollout = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name: tunedRolloutQuery,
+			Help: "The time from a Tuned resource change until all Profiles it selects were applied.",
+			// Rollouts may wait for TuneD daemon restarts and progressive rollout steps.
+			Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+		},
+	)
+	machineConfigsManaged = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: machineConfigsQuery,
+			Help: "The number of MachineConfigs managed by the Node Tuning Operator.",
+		},
+	)
 )
 
 func init() {
@@ -58,6 +124,13 @@ func init() {
 		buildInfo,
 		degradedState,
 		invalidTunedExist,
+		priorityConflictExist,
+		profiles,
+		reconcileDuration,
+		workqueueDepth,
+		workqueueRetries,
+		tunedRollout,
+		machineConfigsManaged,
 	)
 }
 
@@ -101,3 +174,50 @@ func InvalidTunedExist(enable bool) {
 	}
 	invalidTunedExist.Set(0)
 }
+
+// PriorityConflictExist indicates whether different TuneD profiles with the same
+// priority match any node or not.
+func PriorityConflictExist(enable bool) {
+	if enable {
+		priorityConflictExist.Set(1)
+		return
+	}
+	priorityConflictExist.Set(0)
+}
+
+// ProfileStates exposes the number of Profiles in each state.  Profiles with
+// a kernel command-line conflict are also counted in one of the other states.
+func ProfileStates(applied, degraded, deferred, bootcmdlineConflict int) {
+	profiles.WithLabelValues(ProfileStateApplied).Set(float64(applied))
+	profiles.WithLabelValues(ProfileStateDegraded).Set(float64(degraded))
+	profiles.WithLabelValues(ProfileStateDeferred).Set(float64(deferred))
+	profiles.WithLabelValues(ProfileStateBootcmdlineConflict).Set(float64(bootcmdlineConflict))
+}
+
+// ReconcileDuration records duration 'd' of a reconcile of a workqueue key of kind 'kind'.
+func ReconcileDuration(kind string, d time.Duration) {
+	reconcileDuration.WithLabelValues(kind).Observe(d.Seconds())
+}
+
+// WorkqueueDepth exposes the number of workqueue keys of kind 'kind' waiting
+// to be reconciled.
+func WorkqueueDepth(kind string, depth int) {
+	workqueueDepth.WithLabelValues(kind).Set(float64(depth))
+}
+
+// WorkqueueRetry keeps track of the number of failed reconciles of workqueue
+// keys of kind 'kind' which were requeued.
+func WorkqueueRetry(kind string) {
+	workqueueRetries.WithLabelValues(kind).Inc()
+}
+
+// TunedRolloutDuration records duration 'd' between a Tuned resource change
+// and all the Profiles it selects being applied.
+func TunedRolloutDuration(d time.Duration) {
+	tunedRollout.Observe(d.Seconds())
+}
+
+// MachineConfigsManaged exposes the number of MachineConfigs managed by the operator.
+func MachineConfigsManaged(n int) {
+	machineConfigsManaged.Set(float64(n))
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics of the TuneD daemon exposed by the operand on each node.
+const (
+	tunedProfileApplyDurationQuery  = "nto_tuned_profile_apply_duration_seconds"
+	tunedReloadsQuery               = "nto_tuned_reloads_total"
+	tunedRestartsQuery              = "nto_tuned_restarts_total"
+	tunedCrashesQuery               = "nto_tuned_crashes_total"
+	tunedDeferredUpdatePendingQuery = "nto_tuned_deferred_update_pending_info"
+	tunedSysctlOverridesQuery       = "nto_tuned_sysctl_overrides_total"
+	tunedLastAppliedQuery           = "nto_tuned_last_successful_apply_timestamp_seconds"
+
+	// OperandMetricsPort is the IP port supplied to the HTTP server of the operand.
+	// The operand runs in the host network namespace, the port must not clash
+	// with MetricsPort.
+	OperandMetricsPort = 60001
+)
+
+var (
+	operandRegistry           = prometheus.NewRegistry()
+	tunedProfileApplyDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name: tunedProfileApplyDurationQuery,
+			Help: "The time it took the TuneD daemon to (re)apply a TuneD profile.",
+			// TuneD profiles are usually applied within seconds, some plugins might take minutes.
+			Buckets: prometheus.ExponentialBuckets(0.25, 2, 12),
+		},
+	)
+	tunedReloads = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedReloadsQuery,
+			Help: "The number of times the TuneD daemon was reloaded.",
+		},
+	)
+	tunedRestarts = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedRestartsQuery,
+			Help: "The number of times the TuneD daemon was restarted.",
+		},
+	)
+	tunedCrashes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedCrashesQuery,
+			Help: "The number of times the TuneD daemon exited unexpectedly.",
+		},
+	)
+	tunedDeferredUpdatePending = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: tunedDeferredUpdatePendingQuery,
+			Help: "Is a deferred TuneD profile update pending (1) or not (0)?",
+		},
+	)
+	tunedSysctlOverrides = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedSysctlOverridesQuery,
+			Help: "The number of sysctl values set by TuneD detected to be overridden by a sysctl configuration file.",
+		},
+	)
+	tunedLastApplied = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: tunedLastAppliedQuery,
+			Help: "The Unix time a TuneD profile was last applied without errors.",
+		},
+	)
+)
+
+func init() {
+	operandRegistry.MustRegister(
+		tunedProfileApplyDuration,
+		tunedReloads,
+		tunedRestarts,
+		tunedCrashes,
+		tunedDeferredUpdatePending,
+		tunedSysctlOverrides,
+		tunedLastApplied,
+	)
+}
+
+// TunedProfileApplied records the TuneD daemon finished applying a TuneD profile
+// after duration 'd'.  The time of the last application is only recorded when
+// the profile was applied 'successful'ly.
+func TunedProfileApplied(d time.Duration, successful bool) {
+	tunedProfileApplyDuration.Observe(d.Seconds())
+	if successful {
+		tunedLastApplied.SetToCurrentTime()
+	}
+}
+
+// TunedReloaded keeps track of the number of TuneD daemon reloads.
+func TunedReloaded() {
+	tunedReloads.Inc()
+}
+
+// TunedRestarted keeps track of the number of TuneD daemon restarts.
+func TunedRestarted() {
+	tunedRestarts.Inc()
+}
+
+// TunedCrashed keeps track of the number of unexpected TuneD daemon exits.
+func TunedCrashed() {
+	tunedCrashes.Inc()
+}
+
+// TunedDeferredUpdatePending indicates whether a deferred TuneD profile update
+// waits to be applied.
+func TunedDeferredUpdatePending(pending bool) {
+	if pending {
+		tunedDeferredUpdatePending.Set(1)
+		return
+	}
+	tunedDeferredUpdatePending.Set(0)
+}
+
+// TunedSysctlOverride keeps track of the number of sysctl values set by TuneD
+// detected to be overridden.
+func TunedSysctlOverride() {
+	tunedSysctlOverrides.Inc()
+}
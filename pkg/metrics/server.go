@@ -15,6 +15,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/fsnotify.v1"
 )
@@ -32,6 +33,7 @@ const (
 type Server struct {
 	caBundle   string
 	caBundleCh chan string
+	auth       *authorizer // nil unless EnableAuth was called
 }
 
 var (
@@ -51,14 +53,14 @@ func DumpCA(caBundle string) {
 	}
 }
 
-func buildServer(port int, caBundle string) *http.Server {
+func buildServer(port int, reg *prometheus.Registry, caBundle string) *http.Server {
 	if port <= 0 {
 		klog.Error("invalid port for metric server")
 		return nil
 	}
 
 	handler := promhttp.HandlerFor(
-		registry,
+		reg,
 		promhttp.HandlerOpts{
 			ErrorHandling: promhttp.HTTPErrorOnError,
 		},
@@ -68,6 +70,10 @@ func buildServer(port int, caBundle string) *http.Server {
 	caCertPool := x509.NewCertPool()
 	if caCertPool.AppendCertsFromPEM([]byte(caBundle)) {
 		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if server.auth != nil {
+			// Clients can also authenticate by a bearer token.
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
 		tlsConfig.ClientCAs = caCertPool
 		// Default minimum version is TLS 1.2, previous versions are insecure and deprecated.
 		tlsConfig.MinVersion = tls.VersionTLS12
@@ -84,7 +90,9 @@ func buildServer(port int, caBundle string) *http.Server {
 	} else {
 		klog.Errorf("failed to parse root certificate bundle of the metrics server, client authentication will be disabled")
 	}
-	if tlsConfig.ClientCAs == nil {
+	if server.auth != nil {
+		handler = server.auth.handler(handler)
+	} else if tlsConfig.ClientCAs == nil {
 		klog.Infof("continuing without client authentication")
 	}
 
@@ -125,6 +133,17 @@ func (Server) Start(ctx context.Context) error {
 // and restarted with the current files.  Every non-nil return from this function is fatal
 // and will restart the whole operator.
 func RunServer(port int, ctx context.Context) error {
+	return runServer(ctx, port, registry)
+}
+
+// RunOperandServer starts the server exposing the TuneD daemon metrics of the operand.
+// The certificate rotation and client authentication work the same way as for RunServer.
+func RunOperandServer(ctx context.Context) error {
+	return runServer(ctx, OperandMetricsPort, operandRegistry)
+}
+
+// runServer serves the metrics in registry 'reg' on port 'port' until 'ctx' is done.
+func runServer(ctx context.Context, port int, reg *prometheus.Registry) error {
 	// Set up and start the file watcher.
 	watcher, err := fsnotify.NewWatcher()
 	if watcher == nil || err != nil {
@@ -137,11 +156,11 @@ func RunServer(port int, ctx context.Context) error {
 		}
 
 		// Wait for the root certificate bundle of the metrics server for client authentication.
-		// The bundle is sent from a ConfigMap via a channel by the operator.
+		// The bundle is sent from a ConfigMap via a channel by the operator or the operand.
 		server.caBundle = <-server.caBundleCh
 	}
 
-	srv := buildServer(port, server.caBundle)
+	srv := buildServer(port, reg, server.caBundle)
 	if srv == nil {
 		return fmt.Errorf("failed to build server with port %d", port)
 	}
@@ -180,7 +199,7 @@ func RunServer(port int, ctx context.Context) error {
 			// Restart the metrics server.
 			klog.Infof("restarting metrics server to rotate certificates")
 			stopServer(srv)
-			srv = buildServer(port, server.caBundle)
+			srv = buildServer(port, reg, server.caBundle)
 			go startServer(srv)
 		}
 	}
@@ -0,0 +1,323 @@
+package calculate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	assets "github.com/openshift/cluster-node-tuning-operator/assets/tuned"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+const (
+	outputText = "text"
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+var (
+	manifestScheme = runtime.NewScheme()
+	runtimeDecoder runtime.Decoder
+)
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(manifestScheme))
+	utilruntime.Must(mcfgv1.Install(manifestScheme))
+	utilruntime.Must(tunedv1.AddToScheme(manifestScheme))
+	runtimeDecoder = serializer.NewCodecFactory(manifestScheme).UniversalDeserializer()
+}
+
+// manifestObjects are the objects needed for profile calculation.
+type manifestObjects struct {
+	tuneds []*tunedv1.Tuned
+	nodes  []*corev1.Node
+	pods   []*corev1.Pod
+	pools  []*mcfgv1.MachineConfigPool
+}
+
+// NodeProfile is the result of the profile calculation for a single Node.
+type NodeProfile struct {
+	NodeName            string                `json:"nodeName"`
+	TunedName           string                `json:"tunedName,omitempty"`
+	TunedProfile        string                `json:"tunedProfile"`
+	AdditiveProfiles    []string              `json:"additiveProfiles,omitempty"`
+	ProfileOverride     bool                  `json:"profileOverride,omitempty"`
+	Deferred            util.DeferMode        `json:"deferred,omitempty"`
+	MachineConfigLabels map[string]string     `json:"machineConfigLabels,omitempty"`
+	Operand             tunedv1.OperandConfig `json:"operand"`
+	Error               string                `json:"error,omitempty"`
+}
+
+func calculate(inputDirs []string, nodeNames []string, output string, w io.Writer) error {
+	objs, err := loadManifests(inputDirs)
+	if err != nil {
+		return err
+	}
+
+	if !hasDefaultTuned(objs.tuneds) {
+		klog.Infof("no Tuned %s found in input dirs %s, using the default one", tunedv1.TunedDefaultResourceName, strings.Join(inputDirs, ","))
+		if err := decodeManifestsFromFile("default-cr-tuned.yaml", bytes.NewReader(assets.DefaultCrTuned), runtimeDecoder, objs); err != nil {
+			return err
+		}
+	}
+
+	// Append any missing default manifests (i.e. `master`/`worker`)
+	objs.pools = util.AppendMissingDefaultMCPManifests(objs.pools)
+
+	pc, err := operator.NewStaticProfileCalculator(objs.tuneds, objs.nodes, objs.pods, objs.pools)
+	if err != nil {
+		return err
+	}
+
+	if len(nodeNames) == 0 {
+		for _, node := range objs.nodes {
+			nodeNames = append(nodeNames, node.Name)
+		}
+		sort.Strings(nodeNames)
+	}
+
+	profiles := []NodeProfile{}
+	for _, nodeName := range nodeNames {
+		profiles = append(profiles, calculateNodeProfile(pc, objs.nodes, nodeName))
+	}
+
+	return printProfiles(profiles, output, w)
+}
+
+// calculateNodeProfile calculates the profile for Node 'nodeName' using
+// ProfileCalculator 'pc'.  Profile calculation errors are reported as part
+// of the result.
+func calculateNodeProfile(pc *operator.ProfileCalculator, nodes []*corev1.Node, nodeName string) NodeProfile {
+	np := NodeProfile{
+		NodeName: nodeName,
+	}
+
+	found := false
+	for _, node := range nodes {
+		if node.Name == nodeName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		np.Error = fmt.Sprintf("Node %s not found in input dirs", nodeName)
+		return np
+	}
+
+	computed, err := pc.CalculateProfile(nodeName)
+	if err != nil {
+		np.Error = err.Error()
+	}
+	np.TunedName = computed.TunedName
+	np.TunedProfile = computed.TunedProfileName
+	np.AdditiveProfiles = computed.AdditiveProfileNames
+	np.ProfileOverride = computed.ProfileOverride != ""
+	np.Deferred = computed.Deferred
+	np.MachineConfigLabels = computed.MCLabels
+	np.Operand = computed.Operand
+
+	return np
+}
+
+func hasDefaultTuned(tuneds []*tunedv1.Tuned) bool {
+	for _, tuned := range tuneds {
+		if tuned.Name == tunedv1.TunedDefaultResourceName {
+			return true
+		}
+	}
+	return false
+}
+
+// loadManifests loads Tuned CRs, Nodes, Pods and MachineConfigPools from all
+// YAML/JSON files in directories 'inputDirs'.  Other files and objects are ignored.
+func loadManifests(inputDirs []string) (*manifestObjects, error) {
+	filePaths, err := util.ListFilesFromMultiplePaths(inputDirs)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing files: %w", err)
+	}
+	klog.V(4).Infof("listed files: %v", filePaths)
+
+	objs := &manifestObjects{}
+	for _, path := range filePaths {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			klog.V(4).Infof("skipping file %q with no manifest extension", path)
+			continue
+		}
+
+		if err := decodeManifestsFromPath(path, objs); err != nil {
+			return nil, err
+		}
+	}
+
+	return objs, nil
+}
+
+func decodeManifestsFromPath(path string, objs *manifestObjects) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return decodeManifestsFromFile(path, file, runtimeDecoder, objs)
+}
+
+func decodeManifestsFromFile(filename string, r io.Reader, runtimeDecoder runtime.Decoder, objs *manifestObjects) error {
+	manifests, err := util.ParseManifests(filename, r)
+	if err != nil {
+		return fmt.Errorf("error parsing manifests from %s: %w", filename, err)
+	}
+
+	klog.V(4).Infof("decoding manifests for file %s...", filename)
+	for idx, m := range manifests {
+		if err := decodeManifest(filename, idx, m.Raw, runtimeDecoder, objs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeManifest decodes a single manifest 'raw' and adds the objects needed
+// for profile calculation to 'objs'.  Lists of objects, as found in must-gathers,
+// are decoded item by item.
+func decodeManifest(filename string, idx int, raw []byte, runtimeDecoder runtime.Decoder, objs *manifestObjects) error {
+	obji, err := runtime.Decode(runtimeDecoder, raw)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+			klog.V(4).Infof("skipping %q [%d] manifest because it is not part of expected api group: %v", filename, idx+1, err)
+			return nil
+		}
+		return fmt.Errorf("error parsing %q [%d] manifest: %w", filename, idx+1, err)
+	}
+
+	switch obj := obji.(type) {
+	case *tunedv1.Tuned:
+		klog.V(2).Infof("adding Tuned %q from %q", obj.Name, filename)
+		objs.tuneds = append(objs.tuneds, obj)
+	case *tunedv1.TunedList:
+		for i := range obj.Items {
+			objs.tuneds = append(objs.tuneds, &obj.Items[i])
+		}
+	case *corev1.Node:
+		klog.V(2).Infof("adding Node %q from %q", obj.Name, filename)
+		objs.nodes = append(objs.nodes, obj)
+	case *corev1.NodeList:
+		for i := range obj.Items {
+			objs.nodes = append(objs.nodes, &obj.Items[i])
+		}
+	case *corev1.Pod:
+		objs.pods = append(objs.pods, obj)
+	case *corev1.PodList:
+		for i := range obj.Items {
+			objs.pods = append(objs.pods, &obj.Items[i])
+		}
+	case *mcfgv1.MachineConfigPool:
+		klog.V(2).Infof("adding MachineConfigPool %q from %q", obj.Name, filename)
+		objs.pools = append(objs.pools, obj)
+	case *mcfgv1.MachineConfigPoolList:
+		for i := range obj.Items {
+			objs.pools = append(objs.pools, &obj.Items[i])
+		}
+	case *corev1.List:
+		for _, item := range obj.Items {
+			if err := decodeManifest(filename, idx, item.Raw, runtimeDecoder, objs); err != nil {
+				return err
+			}
+		}
+	default:
+		klog.V(4).Infof("skipping %q [%d] manifest because of unhandled %T", filename, idx+1, obji)
+	}
+
+	return nil
+}
+
+func printProfiles(profiles []NodeProfile, output string, w io.Writer) error {
+	switch output {
+	case outputJSON:
+		b, err := json.MarshalIndent(profiles, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case outputYAML:
+		b, err := yaml.Marshal(profiles)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE\tTUNED\tPROFILE\tDEFERRED\tMACHINECONFIG LABELS\tDEBUG\tVERBOSITY\tREAPPLY SYSCTL")
+	for _, np := range profiles {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\t%d\t%s\n",
+			np.NodeName,
+			valueOrNone(np.TunedName),
+			valueOrNone(util.StackedTunedProfiles(np.TunedProfile, np.AdditiveProfiles)),
+			valueOrNone(string(np.Deferred)),
+			valueOrNone(labelsString(np.MachineConfigLabels)),
+			np.Operand.Debug,
+			ptr.Deref(np.Operand.Verbosity, 0),
+			reapplySysctlString(np.Operand.TuneDConfig.ReapplySysctl),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, np := range profiles {
+		if len(np.Error) > 0 {
+			fmt.Fprintf(w, "Warning: Node %s: %s\n", np.NodeName, np.Error)
+		}
+	}
+
+	return nil
+}
+
+func labelsString(l map[string]string) string {
+	pairs := []string{}
+	for k, v := range l {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func reapplySysctlString(reapplySysctl *bool) string {
+	if reapplySysctl == nil {
+		return "<default>"
+	}
+	return strconv.FormatBool(*reapplySysctl)
+}
+
+func valueOrNone(s string) string {
+	if len(s) == 0 {
+		return "<none>"
+	}
+	return s
+}
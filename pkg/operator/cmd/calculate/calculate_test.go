@@ -0,0 +1,68 @@
+package calculate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeNames []string
+		want      []NodeProfile
+	}{
+		{
+			name: "all nodes",
+			want: []NodeProfile{
+				{
+					NodeName:     "worker-0",
+					TunedName:    "custom",
+					TunedProfile: "openshift-db",
+					Deferred:     util.DeferAlways,
+					Operand:      tunedv1.OperandConfig{Verbosity: ptr.To(3)},
+				},
+				{
+					NodeName:            "worker-1",
+					TunedName:           "custom",
+					TunedProfile:        "openshift-rt",
+					Deferred:            util.DeferAlways,
+					MachineConfigLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker-rt"},
+				},
+			},
+		},
+		{
+			name:      "unknown node",
+			nodeNames: []string{"worker-2"},
+			want: []NodeProfile{
+				{
+					NodeName: "worker-2",
+					Error:    "Node worker-2 not found in input dirs",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := calculate([]string{"testdata/must-gather"}, tc.nodeNames, outputJSON, &buf); err != nil {
+				t.Fatalf("calculate() failed: %v", err)
+			}
+
+			got := []NodeProfile{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal output %q: %v", buf.String(), err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("calculate() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
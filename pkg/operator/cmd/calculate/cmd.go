@@ -0,0 +1,74 @@
+package calculate
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+type calculateOpts struct {
+	inputDirs []string
+	nodeNames []string
+	output    string
+}
+
+func NewCalculateCommand() *cobra.Command {
+	calculateOpts := calculateOpts{
+		output: outputText,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "calculate",
+		Short: "Calculate TuneD profiles offline from a must-gather or a manifest directory",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := calculateOpts.Validate(); err != nil {
+				klog.Fatal(err)
+			}
+
+			if err := calculateOpts.Run(); err != nil {
+				klog.Fatal(err)
+			}
+		},
+	}
+
+	addKlogFlags(cmd)
+	calculateOpts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *calculateOpts) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&c.inputDirs, "input-dir", c.inputDirs, "Must-gather or manifest directory with Tuned, Node, Pod and MachineConfigPool manifests. (Can use it more than once to define multiple directories)")
+	fs.StringArrayVar(&c.nodeNames, "node", c.nodeNames, "Name of the node to calculate the TuneD profile for. (Can use it more than once; all nodes if omitted)")
+	fs.StringVarP(&c.output, "output", "o", c.output, "Output format: text, yaml or json.")
+}
+
+func (c *calculateOpts) Validate() error {
+	var err string
+	if len(c.inputDirs) == 0 {
+		err += "input-dir must be specified. "
+	}
+	switch c.output {
+	case outputText, outputYAML, outputJSON:
+	default:
+		err += "output must be one of text, yaml or json. "
+	}
+
+	if len(err) == 0 {
+		return nil
+	}
+	return errors.New(err)
+}
+
+func (c *calculateOpts) Run() error {
+	return calculate(c.inputDirs, c.nodeNames, c.output, os.Stdout)
+}
+
+func addKlogFlags(cmd *cobra.Command) {
+	fs := flag.NewFlagSet("", flag.PanicOnError)
+	klog.InitFlags(fs)
+	cmd.Flags().AddGoFlagSet(fs)
+}
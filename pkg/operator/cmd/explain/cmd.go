@@ -0,0 +1,72 @@
+package explain
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+type explainOpts struct {
+	nodeName string
+	output   string
+}
+
+func NewExplainCommand() *cobra.Command {
+	explainOpts := explainOpts{
+		output: outputText,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain why a node got its TuneD profile",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := explainOpts.Validate(); err != nil {
+				klog.Fatal(err)
+			}
+
+			if err := explainOpts.Run(); err != nil {
+				klog.Fatal(err)
+			}
+		},
+	}
+
+	addKlogFlags(cmd)
+	explainOpts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (e *explainOpts) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&e.nodeName, "node", e.nodeName, "Name of the node to explain the TuneD profile selection for.")
+	fs.StringVarP(&e.output, "output", "o", e.output, "Output format: text, yaml or json.")
+}
+
+func (e *explainOpts) Validate() error {
+	var err string
+	if len(e.nodeName) == 0 {
+		err += "node must be specified. "
+	}
+	switch e.output {
+	case outputText, outputYAML, outputJSON:
+	default:
+		err += "output must be one of text, yaml or json. "
+	}
+
+	if len(err) == 0 {
+		return nil
+	}
+	return errors.New(err)
+}
+
+func (e *explainOpts) Run() error {
+	return explain(e.nodeName, e.output, os.Stdout)
+}
+
+func addKlogFlags(cmd *cobra.Command) {
+	fs := flag.NewFlagSet("", flag.PanicOnError)
+	klog.InitFlags(fs)
+	cmd.Flags().AddGoFlagSet(fs)
+}
@@ -0,0 +1,155 @@
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeset "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	mcfgclientset "github.com/openshift/client-go/machineconfiguration/clientset/versioned"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	tunedset "github.com/openshift/cluster-node-tuning-operator/pkg/generated/clientset/versioned"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
+)
+
+const (
+	outputText = "text"
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+func explain(nodeName, output string, w io.Writer) error {
+	kubeconfig, err := ntoclient.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubeset.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	tunedClient, err := tunedset.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	mcClient, err := mcfgclientset.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	tunedList, err := tunedClient.TunedV1().Tuneds(ntoconfig.WatchNamespace()).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list Tuned: %v", err)
+	}
+	tuneds := []*tunedv1.Tuned{}
+	for i := range tunedList.Items {
+		tuneds = append(tuneds, &tunedList.Items[i])
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Node %s: %v", nodeName, err)
+	}
+
+	podList, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list Pods on Node %s: %v", nodeName, err)
+	}
+	pods := []*corev1.Pod{}
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+
+	poolList, err := mcClient.MachineconfigurationV1().MachineConfigPools().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list MachineConfigPools: %v", err)
+	}
+	pools := []*mcfgv1.MachineConfigPool{}
+	for i := range poolList.Items {
+		pools = append(pools, &poolList.Items[i])
+	}
+
+	pc, err := operator.NewStaticProfileCalculator(tuneds, []*corev1.Node{node}, pods, pools)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := pc.ExplainProfile(nodeName)
+	if err != nil {
+		return err
+	}
+
+	return printExplanation(explanation, output, w)
+}
+
+func printExplanation(explanation *operator.ProfileExplanation, output string, w io.Writer) error {
+	switch output {
+	case outputJSON:
+		b, err := json.MarshalIndent(explanation, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case outputYAML:
+		b, err := yaml.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Node: %s\n", explanation.NodeName)
+	fmt.Fprintf(&sb, "TuneD profile: %s\n", explanation.TunedProfile)
+	if len(explanation.AdditiveProfiles) > 0 {
+		fmt.Fprintf(&sb, "Additive TuneD profiles: %s\n", strings.Join(explanation.AdditiveProfiles, " "))
+	}
+	sb.WriteString("Recommend entries in priority order:\n")
+	for _, e := range explanation.Evaluations {
+		priority := "<none>"
+		if e.Priority != nil {
+			priority = fmt.Sprintf("%d", *e.Priority)
+		}
+		result := "no match"
+		if e.Matched {
+			result = "matched by " + e.MatchedBy
+		}
+		if e.Selected {
+			result += ", SELECTED"
+		}
+		if e.Additive {
+			result += " (additive)"
+		}
+		fmt.Fprintf(&sb, "  [%s] Tuned %s, profile %s: %s\n", priority, e.TunedName, e.Profile, result)
+		for _, d := range e.Details {
+			fmt.Fprintf(&sb, "      %s\n", d)
+		}
+	}
+	for _, c := range explanation.Conflicts {
+		fmt.Fprintf(&sb, "Warning: %s\n", c)
+	}
+	if explanation.ProfileOverride != "" {
+		fmt.Fprintf(&sb, "Warning: TuneD profile overridden by %s by Node annotation %s\n", explanation.ProfileOverride, tunedv1.TunedProfileOverrideAnnotationKey)
+	}
+	if explanation.Paused {
+		fmt.Fprintf(&sb, "Warning: Profile updates paused by Node annotation %s\n", tunedv1.TunedPauseAnnotationKey)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	configapiv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -61,6 +62,8 @@ const (
 	wqKindProfile           = "profile"
 	wqKindConfigMap         = "configmap"
 	wqKindMachineConfigPool = "machineconfigpool"
+	wqKindOperatorConfig    = "operatorconfig"
+	wqKindTunedStatus       = "tunedstatus"
 )
 
 // Controller is the controller implementation for Tuned resources
@@ -69,7 +72,7 @@ type Controller struct {
 
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens.
-	workqueue workqueue.TypedRateLimitingInterface[wqKey]
+	workqueue *instrumentedQueue
 
 	listers *ntoclient.Listers
 	clients *ntoclient.Clients
@@ -87,6 +90,33 @@ type Controller struct {
 	// tracked as having kernel command-line conflict due to belonging
 	// to the same MCP.
 	bootcmdlineConflict map[string]bool
+
+	// rollout is the internal operator's cache of Profiles updated
+	// progressively based on the Tuned CRs' rolloutStrategy.
+	rollout rolloutState
+
+	// priorityConflicts is the internal operator's cache of different
+	// TuneD profiles with the same priority matching the same Node.
+	priorityConflicts map[string][]PriorityConflict
+	// Node name:    ^^^^^^
+
+	// resyncPeriod is the resync period of the informers started by the
+	// operator.  The informers are restarted when it differs from the resync
+	// period set by the OperatorConfig.
+	resyncPeriod time.Duration
+	// informers holds the context the informers are started with.
+	informers struct {
+		ctx    context.Context    // the parent context of the informers
+		cancel context.CancelFunc // stops the informers
+	}
+
+	// started is the time the controller started.
+	started time.Time
+
+	// tunedChanges is the internal operator's cache of Tuned CR changes
+	// used to time their rollout.
+	tunedChanges map[string]tunedChange
+	// Tuned CR name: ^^^^^^
 }
 
 type wqKey struct {
@@ -110,7 +140,7 @@ func NewController() (*Controller, error) {
 	}
 	controller := &Controller{
 		kubeconfig: kubeconfig,
-		workqueue:  workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[wqKey]()),
+		workqueue:  newInstrumentedQueue(workqueue.DefaultTypedControllerRateLimiter[wqKey]()),
 		listers:    listers,
 		clients:    clients,
 		pc:         NewProfileCalculator(listers, clients),
@@ -118,6 +148,10 @@ func NewController() (*Controller, error) {
 	}
 
 	controller.bootcmdlineConflict = map[string]bool{}
+	controller.rollout = newRolloutState()
+	controller.priorityConflicts = map[string][]PriorityConflict{}
+	controller.resyncPeriod = ntoconfig.ResyncPeriod()
+	controller.tunedChanges = map[string]tunedChange{}
 
 	// Initial event to bootstrap CR if it doesn't exist.
 	controller.workqueue.AddRateLimited(wqKey{kind: wqKindTuned, name: tunedv1.TunedDefaultResourceName})
@@ -191,7 +225,10 @@ func (c *Controller) eventProcessor() {
 		func() {
 			defer c.workqueue.Done(workqueueKey)
 
-			if err := c.sync(workqueueKey); err != nil {
+			start := time.Now()
+			err := c.sync(workqueueKey)
+			metrics.ReconcileDuration(workqueueKey.kind, time.Since(start))
+			if err != nil {
 				requeued := c.workqueue.NumRequeues(workqueueKey)
 				// Limit retries to maxRetries.  After that, stop trying.
 				if requeued < maxRetries {
@@ -199,6 +236,7 @@ func (c *Controller) eventProcessor() {
 
 					// Re-enqueue the workqueueKey.  Based on the rate limiter on the queue
 					// and the re-enqueue history, the workqueueKey will be processed later again.
+					metrics.WorkqueueRetry(workqueueKey.kind)
 					c.workqueue.AddRateLimited(workqueueKey)
 					return
 				}
@@ -367,6 +405,20 @@ func (c *Controller) sync(key wqKey) error {
 		}
 		return nil
 
+	case key.kind == wqKindOperatorConfig:
+		klog.V(2).Infof("sync(): OperatorConfig %s", key.name)
+
+		if key.name != tunedv1.OperatorConfigResourceName {
+			klog.Warningf("only OperatorConfig/%s is used; ignoring OperatorConfig/%s", tunedv1.OperatorConfigResourceName, key.name)
+			return nil
+		}
+		err = c.syncDaemonSet(cr)
+		if err != nil {
+			return fmt.Errorf("failed to sync DaemonSet: %v", err)
+		}
+		// The default operand verbosity may have changed.
+		return c.enqueueProfileUpdates()
+
 	case key.kind == wqKindProfile:
 		klog.V(2).Infof("sync(): Profile %s", key.name)
 
@@ -374,6 +426,19 @@ func (c *Controller) sync(key wqKey) error {
 		if err != nil {
 			return fmt.Errorf("failed to sync Profile %s: %v", key.name, err)
 		}
+		err = c.syncProfileMetrics()
+		if err != nil {
+			return fmt.Errorf("failed to sync Profile metrics: %v", err)
+		}
+		return nil
+
+	case key.kind == wqKindTunedStatus:
+		klog.V(2).Infof("sync(): Tuned %s status", key.name)
+
+		err = c.syncTunedStatus(key.name)
+		if err != nil {
+			return fmt.Errorf("failed to sync Tuned %s status: %v", key.name, err)
+		}
 		return nil
 
 	default:
@@ -422,6 +487,10 @@ func (c *Controller) sync(key wqKey) error {
 		return err
 	}
 
+	if key.kind == wqKindTuned {
+		c.tunedChangeObserve(key.name)
+	}
+
 	err = c.enqueueProfileUpdates()
 	if err != nil {
 		return err
@@ -536,6 +605,19 @@ func (c *Controller) syncDaemonSet(tuned *tunedv1.Tuned) error {
 	dsMf := ntomf.TunedDaemonSet()
 	dsMf.ObjectMeta.OwnerReferences = getDefaultTunedRefs(tuned)
 
+	cfg, err := c.operatorConfig()
+	if err != nil {
+		return err
+	}
+	effective := effectiveOperatorConfig(cfg, dsMf)
+	applyOperatorConfig(dsMf, effective)
+	metrics.SetAuthConfig(effective.Metrics)
+	if effective.ResyncPeriod.Duration != c.resyncPeriod {
+		if err := c.restartInformers(effective.ResyncPeriod.Duration); err != nil {
+			return err
+		}
+	}
+
 	ds, err := c.listers.DaemonSets.Get(dsMf.Name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -545,7 +627,7 @@ func (c *Controller) syncDaemonSet(tuned *tunedv1.Tuned) error {
 				return fmt.Errorf("failed to create DaemonSet: %v", err)
 			}
 			// DaemonSet created successfully
-			return nil
+			return c.syncOperatorConfigStatus(cfg, effective)
 		}
 
 		return fmt.Errorf("failed to get DaemonSet %s: %v", dsMf.Name, err)
@@ -567,6 +649,11 @@ func (c *Controller) syncDaemonSet(tuned *tunedv1.Tuned) error {
 		update = true
 	}
 
+	if !daemonSetConfigEqual(ds, dsMf) {
+		klog.V(2).Infof("syncDaemonSet(): DaemonSet %s differs from OperatorConfig %s, updating", ds.Name, tunedv1.OperatorConfigResourceName)
+		update = true
+	}
+
 	if update {
 		// Update the DaemonSet
 		ds = ds.DeepCopy() // never update the objects from cache
@@ -577,13 +664,13 @@ func (c *Controller) syncDaemonSet(tuned *tunedv1.Tuned) error {
 			return fmt.Errorf("failed to update DaemonSet: %v", err)
 		}
 		// DaemonSet created successfully
-		return nil
+		return c.syncOperatorConfigStatus(cfg, effective)
 	}
 
 	// DaemonSet comparison is non-trivial and expensive
 	klog.V(2).Infof("syncDaemonSet(): found DaemonSet %s [%s], not changing it", ds.Name, operatorReleaseVersion)
 
-	return nil
+	return c.syncOperatorConfigStatus(cfg, effective)
 }
 
 func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
@@ -597,10 +684,22 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 		// Remove Profiles for Nodes which no longer exist.
 		if errors.IsNotFound(err) {
 			klog.V(2).Infof("syncProfile(): deleting Profile %s", nodeName)
+			tunedNames := c.priorityConflictsTunedNames(nodeName)
+			tunedNames[c.rollout.selected[nodeName]] = true
+			if profile, err := c.listers.TunedProfiles.Get(nodeName); err == nil {
+				c.additiveTunedNames(tunedNames, profile.Spec.Config.AdditiveTunedProfiles)
+			}
+			c.rollout.profileRemove(nodeName)
+			c.priorityConflictsSet(nodeName, nil)
 			err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Delete(context.TODO(), nodeName, metav1.DeleteOptions{})
 			if err != nil && errors.IsNotFound(err) {
 				err = nil
 			}
+			if err != nil {
+				return err
+			}
+			c.enqueueTunedStatuses(tunedNames)
+			return nil
 		}
 		return err
 	}
@@ -620,7 +719,7 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 	if ntoconfig.InHyperShift() {
 		computed, err = c.pc.calculateProfileHyperShift(nodeName)
 	} else {
-		computed, err = c.pc.calculateProfile(nodeName)
+		computed, err = c.pc.calculateProfile(nodeName, nil)
 	}
 	switch err.(type) {
 	case nil:
@@ -633,8 +732,30 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 		return err
 	}
 
+	if computed.Operand.Verbosity == nil {
+		// Tuned CRs take precedence over the cluster-wide default operand verbosity.
+		cfg, err := c.operatorConfig()
+		if err != nil {
+			return err
+		}
+		if cfg != nil {
+			computed.Operand.Verbosity = ptr.To(cfg.Spec.OperandVerbosity)
+		}
+	}
+	verbosity := ptr.Deref(computed.Operand.Verbosity, 0)
+
 	metrics.ProfileCalculated(profileMf.Name, computed.TunedProfileName)
 
+	// Tuned CRs whose status may be affected by this Profile.
+	tunedNames := c.priorityConflictsTunedNames(nodeName)
+	tunedNames[c.rollout.selected[nodeName]] = true
+	tunedNames[computed.TunedName] = true
+	c.rollout.selected[nodeName] = computed.TunedName
+	c.priorityConflictsSet(nodeName, computed.PriorityConflicts)
+	for tunedName := range c.priorityConflictsTunedNames(nodeName) {
+		tunedNames[tunedName] = true
+	}
+
 	profile, err := c.listers.TunedProfiles.Get(profileMf.Name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -650,9 +771,12 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 			klog.V(2).Infof("syncProfile(): Profile %s not found, creating one [%s]", profileMf.Name, computed.TunedProfileName)
 			profileMf.Annotations = updateDeferredAnnotation(profileMf.Annotations, computed.Deferred)
 			profileMf.Spec.Config.TunedProfile = computed.TunedProfileName
+			profileMf.Spec.Config.AdditiveTunedProfiles = computed.AdditiveProfileNames
 			profileMf.Spec.Config.Debug = computed.Operand.Debug
-			profileMf.Spec.Config.Verbosity = computed.Operand.Verbosity
+			profileMf.Spec.Config.Verbosity = verbosity
 			profileMf.Spec.Config.TuneDConfig = computed.Operand.TuneDConfig
+			profileMf.Spec.Config.DriftDetection = computed.Operand.DriftDetection
+			profileMf.Spec.Config.MaintenanceWindow = computed.MaintenanceWindow
 			profileMf.Spec.Profile = computed.AllProfiles
 			profileMf.Status.Conditions = tunedpkg.InitializeStatusConditions()
 			_, err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Create(context.TODO(), profileMf, metav1.CreateOptions{})
@@ -674,6 +798,23 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 		return fmt.Errorf("failed to sync OperatorStatus: %v", err)
 	}
 
+	// Profiles also carry the state reported in the status of the Tuned CRs which selected them
+	// or recommended the additive TuneD profiles stacked on them.
+	c.additiveTunedNames(tunedNames, profile.Spec.Config.AdditiveTunedProfiles)
+	c.additiveTunedNames(tunedNames, computed.AdditiveProfileNames)
+	c.enqueueTunedStatuses(tunedNames)
+
+	// Report the Node annotation overrides in the Profile conditions.
+	paused := c.pc.state.paused[nodeName]
+	profile, err = c.syncProfileOverrideConditions(profile, paused, computed.ProfileOverride)
+	if err != nil {
+		return err
+	}
+	if paused {
+		klog.V(2).Infof("syncProfile(): updates of Profile %s paused by Node annotation %s", nodeName, tunedv1.TunedPauseAnnotationKey)
+		return nil
+	}
+
 	providerName, err := c.getProviderName(nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to get ProviderName: %v", err)
@@ -685,7 +826,7 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 		// based matching was used, or we don't know the NodePool, so we should not sync the
 		// MachineConfigs.
 		if computed.NodePoolName != "" {
-			if profile.Status.TunedProfile == computed.TunedProfileName && profileApplied(profile) {
+			if profile.Status.TunedProfile == util.StackedTunedProfiles(computed.TunedProfileName, computed.AdditiveProfileNames) && profileApplied(profile) {
 				// Synchronize MachineConfig only once the (calculated) TuneD profile 'tunedProfileName'
 				// has been successfully applied.
 				err := c.syncMachineConfigHyperShift(computed.NodePoolName, profile)
@@ -699,7 +840,7 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 			// The TuneD daemon profile 'tunedProfileName' for nodeName matched with MachineConfig
 			// labels 'mcLabels' set for additional machine configuration.  Sync the operator-created
 			// MachineConfig based on 'mcLabels'.
-			if profile.Status.TunedProfile == computed.TunedProfileName && profileApplied(profile) {
+			if profile.Status.TunedProfile == util.StackedTunedProfiles(computed.TunedProfileName, computed.AdditiveProfileNames) && profileApplied(profile) {
 				// Synchronize MachineConfig only once the (calculated) TuneD profile 'tunedProfileName'
 				// has been successfully applied.
 				err := c.syncMachineConfig(computed.MCLabels, profile)
@@ -714,30 +855,46 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 
 	// Minimize updates
 	if profile.Spec.Config.TunedProfile == computed.TunedProfileName &&
+		reflect.DeepEqual(profile.Spec.Config.AdditiveTunedProfiles, computed.AdditiveProfileNames) &&
 		profile.Spec.Config.Debug == computed.Operand.Debug &&
-		profile.Spec.Config.Verbosity == computed.Operand.Verbosity &&
+		profile.Spec.Config.Verbosity == verbosity &&
 		reflect.DeepEqual(profile.Spec.Config.TuneDConfig, computed.Operand.TuneDConfig) &&
+		reflect.DeepEqual(profile.Spec.Config.DriftDetection, computed.Operand.DriftDetection) &&
+		reflect.DeepEqual(profile.Spec.Config.MaintenanceWindow, computed.MaintenanceWindow) &&
 		reflect.DeepEqual(profile.Spec.Profile, computed.AllProfiles) &&
 		util.GetDeferredUpdateAnnotation(profile.Annotations) == util.GetDeferredUpdateAnnotation(anns) &&
 		profile.Spec.Config.ProviderName == providerName {
 		klog.V(2).Infof("syncProfile(): no need to update Profile %s", nodeName)
+		c.tunedChangesApplied()
+		return nil
+	}
+
+	if !c.rolloutAllowed(computed, profile) {
+		// Progressive rollout of the Tuned CR changes, retry later.
+		klog.V(2).Infof("syncProfile(): postponing update of Profile %s [%s] due to Tuned %s rolloutStrategy", nodeName, computed.TunedProfileName, computed.TunedName)
+		c.workqueue.AddAfter(wqKey{kind: wqKindProfile, namespace: ntoconfig.WatchNamespace(), name: nodeName}, rolloutRequeuePeriod)
 		return nil
 	}
+
 	profile = profile.DeepCopy() // never update the objects from cache
-	profile.Annotations = anns
+	profile.Annotations = c.updateRolloutAnnotation(anns, computed.TunedName)
 	profile.Spec.Config.TunedProfile = computed.TunedProfileName
+	profile.Spec.Config.AdditiveTunedProfiles = computed.AdditiveProfileNames
 	profile.Spec.Config.Debug = computed.Operand.Debug
-	profile.Spec.Config.Verbosity = computed.Operand.Verbosity
+	profile.Spec.Config.Verbosity = verbosity
 	profile.Spec.Config.TuneDConfig = computed.Operand.TuneDConfig
+	profile.Spec.Config.DriftDetection = computed.Operand.DriftDetection
+	profile.Spec.Config.MaintenanceWindow = computed.MaintenanceWindow
 	profile.Spec.Config.ProviderName = providerName
 	profile.Spec.Profile = computed.AllProfiles
 	profile.Status.Conditions = tunedpkg.InitializeStatusConditions()
 
 	klog.V(2).Infof("syncProfile(): updating Profile %s [%s]", profile.Name, computed.TunedProfileName)
-	_, err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Update(context.TODO(), profile, metav1.UpdateOptions{})
+	profileUpdated, err := c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Update(context.TODO(), profile, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to update Profile %s: %v", profile.Name, err)
 	}
+	c.rolloutTrack(computed.TunedName, profileUpdated)
 	klog.Infof("updated profile %s [%s] (deferred=%v)", profile.Name, computed.TunedProfileName, util.GetDeferredUpdateAnnotation(profile.Annotations))
 
 	return nil
@@ -990,6 +1147,7 @@ func (c *Controller) pruneMachineConfigs() error {
 		return err
 	}
 
+	managed := 0
 	for _, mc := range mcList {
 		if mc.ObjectMeta.Annotations != nil {
 			if _, ok := mc.ObjectMeta.Annotations[GeneratedByControllerVersionAnnotationKey]; !ok {
@@ -998,6 +1156,7 @@ func (c *Controller) pruneMachineConfigs() error {
 			// mc's annotations have the controller/operator key
 
 			if mcNames[mc.ObjectMeta.Name] {
+				managed++
 				continue
 			}
 			// This MachineConfig has this operator's annotations and it is not currently used by any
@@ -1012,6 +1171,7 @@ func (c *Controller) pruneMachineConfigs() error {
 			klog.Infof("deleted MachineConfig %s", mc.ObjectMeta.Name)
 		}
 	}
+	metrics.MachineConfigsManaged(managed)
 
 	return nil
 }
@@ -1031,6 +1191,7 @@ func (c *Controller) pruneMachineConfigsHyperShift() error {
 		return err
 	}
 
+	managed := 0
 	for _, cm := range cmList.Items {
 		if cm.ObjectMeta.Annotations != nil {
 			if _, ok := cm.ObjectMeta.Annotations[GeneratedByControllerVersionAnnotationKey]; !ok {
@@ -1038,6 +1199,7 @@ func (c *Controller) pruneMachineConfigsHyperShift() error {
 			}
 			// mc's annotations have the controller/operator key
 			if mcNames[cm.ObjectMeta.Name] {
+				managed++
 				continue
 			}
 
@@ -1052,6 +1214,7 @@ func (c *Controller) pruneMachineConfigsHyperShift() error {
 			klog.Infof("deleted MachineConfig ConfigMap %s", cm.ObjectMeta.Name)
 		}
 	}
+	metrics.MachineConfigsManaged(managed)
 
 	return nil
 }
@@ -1198,7 +1361,7 @@ func (c *Controller) enableNodeInformer(enable bool) error {
 			informer        corev1informers.NodeInformer
 		)
 		c.node.stopCh = make(chan struct{})
-		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, ntoconfig.ResyncPeriod(), kubeinformers.WithNamespace(corev1.NamespaceAll))
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, c.resyncPeriod, kubeinformers.WithNamespace(corev1.NamespaceAll))
 
 		informer = informerFactory.Core().V1().Nodes()
 		c.listers.Nodes = informer.Lister()
@@ -1229,7 +1392,7 @@ func (c *Controller) enablePodInformer(enable bool) error {
 			informer        corev1informers.PodInformer
 		)
 		c.pod.stopCh = make(chan struct{})
-		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, ntoconfig.ResyncPeriod(), kubeinformers.WithNamespace(corev1.NamespaceAll))
+		informerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, c.resyncPeriod, kubeinformers.WithNamespace(corev1.NamespaceAll))
 
 		informer = informerFactory.Core().V1().Pods()
 		c.listers.Pods = informer.Lister()
@@ -1331,9 +1494,53 @@ func (c *Controller) run(ctx context.Context) error {
 	// Start the informer factories to begin populating the informer caches
 	klog.Info("starting Tuned controller")
 
-	configInformerFactory := configinformers.NewSharedInformerFactory(c.clients.ConfigClientSet, ntoconfig.ResyncPeriod())
-	kubeNTOInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, ntoconfig.ResyncPeriod(), kubeinformers.WithNamespace(ntoconfig.WatchNamespace()))
-	tunedInformerFactory := tunedinformers.NewSharedInformerFactoryWithOptions(c.clients.Tuned, ntoconfig.ResyncPeriod(), tunedinformers.WithNamespace(ntoconfig.WatchNamespace()))
+	c.started = time.Now()
+	c.resyncPeriod = c.initialResyncPeriod(ctx)
+	klog.Infof("using resync period %v", c.resyncPeriod)
+
+	c.informers.ctx = ctx
+	if err := c.startInformers(); err != nil {
+		return err
+	}
+
+	// Resume the Profile rollouts of the previous operator instance.
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list Profiles: %v", err)
+	}
+	c.rollout.restore(profileList)
+
+	// Remove this code in the future.  This is for cleanup during upgrades only.
+	// The rendered resource is no longer used.
+	if err := c.removeTunedRendered(); err != nil {
+		klog.Error(err)
+	}
+
+	klog.V(1).Info("starting events processor")
+	go wait.Until(c.eventProcessor, time.Second, ctx.Done())
+	klog.Info("started events processor/controller")
+
+	<-ctx.Done()
+	if err := c.enableNodeInformer(false); err != nil {
+		klog.Errorf("failed to disable Node informer: %v", err)
+	}
+	if err := c.enablePodInformer(false); err != nil {
+		klog.Errorf("failed to disable Pod informer: %v", err)
+	}
+	klog.Info("shutting down events processor/controller")
+	return nil
+}
+
+// startInformers creates the informers with the resync period c.resyncPeriod,
+// starts them and waits for their caches to sync.  The Node and Pod informers
+// are enabled on demand, see enableNodeInformer and enablePodInformer.
+func (c *Controller) startInformers() error {
+	ctx, cancel := context.WithCancel(c.informers.ctx)
+	c.informers.cancel = cancel
+
+	configInformerFactory := configinformers.NewSharedInformerFactory(c.clients.ConfigClientSet, c.resyncPeriod)
+	kubeNTOInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube, c.resyncPeriod, kubeinformers.WithNamespace(ntoconfig.WatchNamespace()))
+	tunedInformerFactory := tunedinformers.NewSharedInformerFactoryWithOptions(c.clients.Tuned, c.resyncPeriod, tunedinformers.WithNamespace(ntoconfig.WatchNamespace()))
 
 	coInformer := configInformerFactory.Config().V1().ClusterOperators()
 	c.listers.ClusterOperators = coInformer.Lister()
@@ -1366,13 +1573,23 @@ func (c *Controller) run(ctx context.Context) error {
 		tpInformer.Informer().HasSynced,
 	}
 
+	if !ntoconfig.InHyperShift() {
+		// The OperatorConfig CRD is not installed in HyperShift.
+		ocInformer := tunedInformerFactory.Tuned().V1().OperatorConfigs()
+		c.listers.OperatorConfigs = ocInformer.Lister()
+		if _, err := ocInformer.Informer().AddEventHandler(c.informerEventHandler(wqKey{kind: wqKindOperatorConfig})); err != nil {
+			return err
+		}
+		InformerFuncs = append(InformerFuncs, ocInformer.Informer().HasSynced)
+	}
+
 	var tunedConfigMapInformerFactory kubeinformers.SharedInformerFactory
 	var mcfgConfigMapInformerFactory kubeinformers.SharedInformerFactory
 	var mcfgInformerFactory mcfginformers.SharedInformerFactory
 	var caConfigMapInformerFactory kubeinformers.SharedInformerFactory
 	if ntoconfig.InHyperShift() {
 		tunedConfigMapInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.ManagementKube,
-			ntoconfig.ResyncPeriod(),
+			c.resyncPeriod,
 			kubeinformers.WithNamespace(ntoconfig.OperatorNamespace()),
 			kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
 				opts.LabelSelector = tunedConfigMapLabel + "=true"
@@ -1384,7 +1601,7 @@ func (c *Controller) run(ctx context.Context) error {
 		}
 
 		mcfgConfigMapInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.ManagementKube,
-			ntoconfig.ResyncPeriod(),
+			c.resyncPeriod,
 			kubeinformers.WithNamespace(ntoconfig.OperatorNamespace()),
 			kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
 				opts.LabelSelector = operatorGeneratedMachineConfig + "=true"
@@ -1397,7 +1614,7 @@ func (c *Controller) run(ctx context.Context) error {
 
 		InformerFuncs = append(InformerFuncs, tunedConfigMapInformer.Informer().HasSynced, mcfgConfigMapInformer.Informer().HasSynced)
 	} else {
-		mcfgInformerFactory = mcfginformers.NewSharedInformerFactory(c.clients.MC, ntoconfig.ResyncPeriod())
+		mcfgInformerFactory = mcfginformers.NewSharedInformerFactory(c.clients.MC, c.resyncPeriod)
 		mcInformer := mcfgInformerFactory.Machineconfiguration().V1().MachineConfigs()
 
 		c.listers.MachineConfigs = mcInformer.Lister()
@@ -1410,7 +1627,7 @@ func (c *Controller) run(ctx context.Context) error {
 		InformerFuncs = append(InformerFuncs, mcInformer.Informer().HasSynced, mcpInformer.Informer().HasSynced)
 
 		caConfigMapInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube,
-			ntoconfig.ResyncPeriod(),
+			c.resyncPeriod,
 			kubeinformers.WithNamespace(metrics.AuthConfigMapNamespace),
 			kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
 				opts.FieldSelector = "metadata.name=" + "extension-apiserver-authentication"
@@ -1425,7 +1642,7 @@ func (c *Controller) run(ctx context.Context) error {
 
 	configInformerFactory.Start(ctx.Done())  // ClusterOperator
 	kubeNTOInformerFactory.Start(ctx.Done()) // DaemonSet
-	tunedInformerFactory.Start(ctx.Done())   // Tuned/Profile
+	tunedInformerFactory.Start(ctx.Done())   // Tuned/Profile/OperatorConfig
 
 	if ntoconfig.InHyperShift() {
 		tunedConfigMapInformerFactory.Start(ctx.Done())
@@ -1442,24 +1659,38 @@ func (c *Controller) run(ctx context.Context) error {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
-	// Remove this code in the future.  This is for cleanup during upgrades only.
-	// The rendered resource is no longer used.
-	if err := c.removeTunedRendered(); err != nil {
-		klog.Error(err)
-	}
+	return nil
+}
 
-	klog.V(1).Info("starting events processor")
-	go wait.Until(c.eventProcessor, time.Second, ctx.Done())
-	klog.Info("started events processor/controller")
+// restartInformers restarts the informers with the resync period 'resyncPeriod'.
+// The informers and listers are replaced by the events processor between the
+// syncs, so that no sync uses them in the meantime.
+func (c *Controller) restartInformers(resyncPeriod time.Duration) error {
+	klog.Infof("resync period changed from %v to %v, restarting the informers", c.resyncPeriod, resyncPeriod)
+	c.informers.cancel()
+	c.resyncPeriod = resyncPeriod
 
-	<-ctx.Done()
-	if err := c.enableNodeInformer(false); err != nil {
-		klog.Errorf("failed to disable Node informer: %v", err)
+	if err := c.startInformers(); err != nil {
+		return err
 	}
-	if err := c.enablePodInformer(false); err != nil {
-		klog.Errorf("failed to disable Pod informer: %v", err)
+
+	// Restart the enabled Node and Pod informers too.  The ProfileCalculator
+	// internal data structures are kept, the new informers only refresh them.
+	if c.node.informerEnabled {
+		close(c.node.stopCh)
+		c.node.informerEnabled = false
+		if err := c.enableNodeInformer(true); err != nil {
+			return fmt.Errorf("failed to restart Node informer: %v", err)
+		}
 	}
-	klog.Info("shutting down events processor/controller")
+	if c.pod.informerEnabled {
+		close(c.pod.stopCh)
+		c.pod.informerEnabled = false
+		if err := c.enablePodInformer(true); err != nil {
+			return fmt.Errorf("failed to restart Pod informer: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,249 @@
+package operator
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	mcfglisters "github.com/openshift/client-go/machineconfiguration/listers/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	ntolisters "github.com/openshift/cluster-node-tuning-operator/pkg/generated/listers/tuned/v1"
+)
+
+const (
+	// The recommend entry was selected by its match rules.
+	MatchedByMatch = "match"
+	// The recommend entry has neither match rules nor machineConfigLabels.
+	MatchedByCatchAll = "catch-all"
+	// The recommend entry was selected by its machineConfigLabels.
+	MatchedByMachineConfigLabels = "machineConfigLabels"
+)
+
+// RecommendEvaluation is the result of evaluating a single Tuned CR recommend entry for a Node.
+type RecommendEvaluation struct {
+	TunedName string  `json:"tunedName"`
+	Profile   string  `json:"profile"`
+	Priority  *uint64 `json:"priority,omitempty"`
+	Matched   bool    `json:"matched"`
+	MatchedBy string  `json:"matchedBy,omitempty"`
+	Selected  bool    `json:"selected"`
+	Additive  bool    `json:"additive,omitempty"`
+	// Human-readable records of evaluating the match rules and machineConfigLabels.
+	Details []string `json:"details,omitempty"`
+}
+
+// ProfileExplanation describes how the TuneD profile was selected for a Node.
+type ProfileExplanation struct {
+	NodeName     string `json:"nodeName"`
+	TunedProfile string `json:"tunedProfile"`
+	// TuneD profiles of the additive recommend entries stacked on top of TunedProfile.
+	AdditiveProfiles []string `json:"additiveProfiles,omitempty"`
+	// All recommend entries in the order of their evaluation (priority).
+	Evaluations []RecommendEvaluation `json:"evaluations"`
+	// Matching recommend entries with the same priority as the selected one.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// TuneD profile forced by the Node's profile override annotation regardless of the recommend entries.
+	ProfileOverride string `json:"profileOverride,omitempty"`
+	// Profile updates are paused by the Node's pause annotation.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// profileTrace records how calculateProfile and additiveProfiles evaluated the
+// recommend entries.  Methods of a nil *profileTrace record nothing.
+type profileTrace struct {
+	// Recommend entries in the order of their evaluation (priority).
+	evaluations []RecommendEvaluation
+	// The recommended TuneD profile and the additive profiles before any profile override.
+	tunedProfile     string
+	additiveProfiles []string
+}
+
+// start initializes the trace for recommend entries 'recommendAll'.
+func (t *profileTrace) start(recommendAll []TunedRecommendInfo) {
+	if t == nil {
+		return
+	}
+	t.evaluations = make([]RecommendEvaluation, len(recommendAll))
+	for i, recommend := range recommendAll {
+		t.evaluations[i] = RecommendEvaluation{
+			TunedName: recommend.TunedName,
+			Priority:  recommend.Priority,
+			Additive:  recommend.Additive,
+		}
+		if recommend.Profile != nil {
+			t.evaluations[i].Profile = *recommend.Profile
+		}
+	}
+}
+
+// details returns the records of evaluating the match rules of recommend entry 'i'
+// or nil if not tracing.
+func (t *profileTrace) details(i int) *[]string {
+	if t == nil {
+		return nil
+	}
+	return &t.evaluations[i].Details
+}
+
+// detail adds a record to the details of recommend entry 'i'.
+func (t *profileTrace) detail(i int, format string, args ...interface{}) {
+	if t == nil {
+		return
+	}
+	t.evaluations[i].Details = append(t.evaluations[i].Details, fmt.Sprintf(format, args...))
+}
+
+// matchedByMatch records recommend entry 'i' matched by its match rules or
+// as a catch-all entry.
+func (t *profileTrace) matchedByMatch(i int, catchAll bool) {
+	if t == nil {
+		return
+	}
+	t.evaluations[i].Matched = true
+	t.evaluations[i].MatchedBy = MatchedByMatch
+	if catchAll {
+		t.evaluations[i].MatchedBy = MatchedByCatchAll
+	}
+}
+
+// machineConfigLabels records the result of matching the machineConfigLabels
+// 'machineConfigLabels' of recommend entry 'i' against MachineConfigPools 'pools'.
+func (t *profileTrace) machineConfigLabels(i int, machineConfigLabels map[string]string, pools []*mcfgv1.MachineConfigPool, matched bool) {
+	if t == nil {
+		return
+	}
+	if !matched {
+		t.detail(i, "machineConfigLabels %v: not selected by the Node's MachineConfigPool(s) %v", machineConfigLabels, poolNames(pools))
+		return
+	}
+	t.evaluations[i].Matched = true
+	t.evaluations[i].MatchedBy = MatchedByMachineConfigLabels
+	t.detail(i, "machineConfigLabels %v: selected by the Node's MachineConfigPool(s) %v", machineConfigLabels, poolNames(pools))
+}
+
+// selected records recommend entry 'i' as selected.  Indexes out of range
+// (no entry selected) are ignored.
+func (t *profileTrace) selected(i int) {
+	if t == nil || i < 0 || i >= len(t.evaluations) {
+		return
+	}
+	t.evaluations[i].Selected = true
+}
+
+// recommended records the recommended TuneD profile 'tunedProfile' and the
+// additive TuneD profiles 'additiveProfiles' stacked on top of it.
+func (t *profileTrace) recommended(tunedProfile string, additiveProfiles []string) {
+	if t == nil {
+		return
+	}
+	t.tunedProfile = tunedProfile
+	t.additiveProfiles = additiveProfiles
+}
+
+// ExplainProfile calculates the TuneD profile for Node 'nodeName' and records
+// why each of the recommend entries of all Tuned CRs matched or not and which
+// ones selected the TuneD profiles.
+func (pc *ProfileCalculator) ExplainProfile(nodeName string) (*ProfileExplanation, error) {
+	trace := &profileTrace{}
+	computed, err := pc.calculateProfile(nodeName, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &ProfileExplanation{
+		NodeName:         nodeName,
+		TunedProfile:     trace.tunedProfile,
+		AdditiveProfiles: trace.additiveProfiles,
+		Evaluations:      trace.evaluations,
+		ProfileOverride:  computed.ProfileOverride,
+		Paused:           pc.state.paused[nodeName],
+	}
+	for _, c := range computed.PriorityConflicts {
+		explanation.Conflicts = append(explanation.Conflicts,
+			fmt.Sprintf("profiles %s (Tuned %s) and %s (Tuned %s) have the same priority %d", c.TunedProfileName, c.TunedName, c.ConflictTunedProfileName, c.ConflictTunedName, c.Priority))
+	}
+
+	return explanation, nil
+}
+
+// poolNames returns a sorted slice of names of MachineConfigPools 'pools'.
+func poolNames(pools []*mcfgv1.MachineConfigPool) []string {
+	names := []string{}
+	for _, pool := range pools {
+		names = append(names, pool.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewStaticProfileCalculator returns a ProfileCalculator which operates on
+// a static set of Tuned CRs, Nodes, Pods and MachineConfigPools rather than
+// on the informer caches.  Tuned CRs without a namespace are assumed to live
+// in the operator's watch namespace.
+func NewStaticProfileCalculator(tuneds []*tunedv1.Tuned, nodes []*corev1.Node, pods []*corev1.Pod, pools []*mcfgv1.MachineConfigPool) (*ProfileCalculator, error) {
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	tunedIndexer := newIndexer()
+	nodeIndexer := newIndexer()
+	podIndexer := newIndexer()
+	poolIndexer := newIndexer()
+
+	for _, tuned := range tuneds {
+		if len(tuned.Namespace) == 0 {
+			tuned = tuned.DeepCopy()
+			tuned.Namespace = ntoconfig.WatchNamespace()
+		}
+		if err := tunedIndexer.Add(tuned); err != nil {
+			return nil, fmt.Errorf("failed to add Tuned %s: %v", tuned.Name, err)
+		}
+	}
+	for _, node := range nodes {
+		if err := nodeIndexer.Add(node); err != nil {
+			return nil, fmt.Errorf("failed to add Node %s: %v", node.Name, err)
+		}
+	}
+	for _, pod := range pods {
+		if err := podIndexer.Add(pod); err != nil {
+			return nil, fmt.Errorf("failed to add Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	for _, pool := range pools {
+		if err := poolIndexer.Add(pool); err != nil {
+			return nil, fmt.Errorf("failed to add MachineConfigPool %s: %v", pool.Name, err)
+		}
+	}
+
+	listers := &ntoclient.Listers{
+		TunedResources:     ntolisters.NewTunedLister(tunedIndexer).Tuneds(ntoconfig.WatchNamespace()),
+		Nodes:              kcorelisters.NewNodeLister(nodeIndexer),
+		Pods:               kcorelisters.NewPodLister(podIndexer),
+		MachineConfigPools: mcfglisters.NewMachineConfigPoolLister(poolIndexer),
+	}
+	pc := NewProfileCalculator(listers, nil)
+
+	for _, node := range nodes {
+		if _, err := pc.nodeChangeHandler(node.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) == 0 {
+			// Pods not scheduled on any Node do not affect profile calculation.
+			continue
+		}
+		if _, _, err := pc.podChangeHandler(pod.Namespace, pod.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return pc, nil
+}
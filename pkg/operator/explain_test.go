@@ -0,0 +1,111 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestExplainProfile(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: tunedv1.TunedDefaultResourceName},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{
+					{Profile: ptr.To("openshift-control-plane"), Priority: ptr.To(uint64(30)), Match: []tunedv1.TunedMatch{{Label: ptr.To("node-role.kubernetes.io/master")}}},
+					{Profile: ptr.To("openshift-node"), Priority: ptr.To(uint64(40))},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{
+					{Profile: ptr.To("custom-mcp"), Priority: ptr.To(uint64(10)), MachineConfigLabels: map[string]string{"machineconfiguration.openshift.io/role": "custom"}},
+					{
+						Profile:  ptr.To("custom-pod"),
+						Priority: ptr.To(uint64(20)),
+						Match: []tunedv1.TunedMatch{
+							{
+								Label: ptr.To("node-role.kubernetes.io/worker"),
+								Match: []tunedv1.TunedMatch{{Label: ptr.To("app"), Value: ptr.To("db"), Type: ptr.To("pod")}},
+							},
+						},
+					},
+					{Profile: ptr.To("custom-dup"), Priority: ptr.To(uint64(20)), Match: []tunedv1.TunedMatch{{Label: ptr.To("node-role.kubernetes.io/worker")}}},
+				},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}},
+	}
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}},
+			Spec:       corev1.PodSpec{NodeName: "worker-0"},
+		},
+	}
+	pools := []*mcfgv1.MachineConfigPool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+			Spec: mcfgv1.MachineConfigPoolSpec{
+				NodeSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+				MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker"}},
+			},
+		},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, pods, pools)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	explanation, err := pc.ExplainProfile("worker-0")
+	if err != nil {
+		t.Fatalf("ExplainProfile() failed: %v", err)
+	}
+
+	if explanation.TunedProfile != "custom-pod" {
+		t.Errorf("TunedProfile = %s, want custom-pod", explanation.TunedProfile)
+	}
+
+	type result struct {
+		profile   string
+		matchedBy string
+		selected  bool
+	}
+	want := []result{
+		{profile: "custom-mcp"},
+		{profile: "custom-pod", matchedBy: MatchedByMatch, selected: true},
+		{profile: "custom-dup", matchedBy: MatchedByMatch},
+		{profile: "openshift-control-plane"},
+		{profile: "openshift-node", matchedBy: MatchedByCatchAll},
+	}
+	got := []result{}
+	for _, e := range explanation.Evaluations {
+		got = append(got, result{profile: e.Profile, matchedBy: e.MatchedBy, selected: e.Selected})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluations = %+v, want %+v", got, want)
+	}
+
+	wantDetails := []string{
+		"node label node-role.kubernetes.io/worker: matched, evaluating nested rules",
+		"  pod label app=db: matched",
+	}
+	if !reflect.DeepEqual(explanation.Evaluations[1].Details, wantDetails) {
+		t.Errorf("Details = %q, want %q", explanation.Evaluations[1].Details, wantDetails)
+	}
+
+	if len(explanation.Conflicts) != 1 {
+		t.Errorf("Conflicts = %v, want a single conflict", explanation.Conflicts)
+	}
+}
@@ -0,0 +1,224 @@
+package operator
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+)
+
+// instrumentedQueue is a rate limiting workqueue keeping track of the number of
+// keys of each kind waiting to be processed.  Keys added with a delay are only
+// counted once the delay passes and they are ready to be processed.
+type instrumentedQueue struct {
+	workqueue.TypedRateLimitingInterface[wqKey]
+	rateLimiter workqueue.TypedRateLimiter[wqKey]
+
+	mu      sync.Mutex
+	pending map[wqKey]bool
+	depth   map[string]int
+	// Key kind: ^^^^^^
+}
+
+func newInstrumentedQueue(rateLimiter workqueue.TypedRateLimiter[wqKey]) *instrumentedQueue {
+	return &instrumentedQueue{
+		TypedRateLimitingInterface: workqueue.NewTypedRateLimitingQueue(rateLimiter),
+		rateLimiter:                rateLimiter,
+		pending:                    map[wqKey]bool{},
+		depth:                      map[string]int{},
+	}
+}
+
+func (q *instrumentedQueue) Add(key wqKey) {
+	if q.ShuttingDown() {
+		return
+	}
+	q.enqueued(key)
+	q.TypedRateLimitingInterface.Add(key)
+}
+
+func (q *instrumentedQueue) AddAfter(key wqKey, duration time.Duration) {
+	if duration <= 0 {
+		q.Add(key)
+		return
+	}
+	time.AfterFunc(duration, func() {
+		q.Add(key)
+	})
+}
+
+func (q *instrumentedQueue) AddRateLimited(key wqKey) {
+	q.AddAfter(key, q.rateLimiter.When(key))
+}
+
+func (q *instrumentedQueue) Get() (wqKey, bool) {
+	key, shutdown := q.TypedRateLimitingInterface.Get()
+	if !shutdown {
+		q.dequeued(key)
+	}
+	return key, shutdown
+}
+
+// pendingKind returns the number of keys of kind 'kind' waiting to be processed.
+func (q *instrumentedQueue) pendingKind(kind string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.depth[kind]
+}
+
+func (q *instrumentedQueue) enqueued(key wqKey) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending[key] {
+		// The workqueue deduplicates the keys.
+		return
+	}
+	q.pending[key] = true
+	q.depth[key.kind]++
+	metrics.WorkqueueDepth(key.kind, q.depth[key.kind])
+}
+
+func (q *instrumentedQueue) dequeued(key wqKey) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.pending[key] {
+		return
+	}
+	delete(q.pending, key)
+	q.depth[key.kind]--
+	metrics.WorkqueueDepth(key.kind, q.depth[key.kind])
+}
+
+// tunedChange is a Tuned CR generation observed by the operator.
+type tunedChange struct {
+	generation int64
+	observed   time.Time // zero when unknown or once all selected Profiles were applied
+}
+
+// tunedChangeObserve records the time the current generation of Tuned CR
+// 'tunedName' was first observed.  Tuned CRs which existed before the
+// operator started are not timed.
+func (c *Controller) tunedChangeObserve(tunedName string) {
+	tuned, err := c.listers.TunedResources.Get(tunedName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			delete(c.tunedChanges, tunedName)
+		}
+		return
+	}
+
+	change, ok := c.tunedChanges[tunedName]
+	if ok && change.generation == tuned.Generation {
+		return
+	}
+
+	change = tunedChange{generation: tuned.Generation}
+	if ok || tuned.CreationTimestamp.Time.After(c.started) {
+		change.observed = time.Now()
+	}
+	c.tunedChanges[tunedName] = change
+}
+
+// tunedChangesApplied records the rollout duration of the observed Tuned CR
+// changes whose selected Profiles were all applied.  Only call this once the
+// Profile being synced is up-to-date, the other Profiles are not considered
+// before their pending syncs are done.
+func (c *Controller) tunedChangesApplied() {
+	if c.workqueue.pendingKind(wqKindProfile) > 0 {
+		return
+	}
+
+	for tunedName, change := range c.tunedChanges {
+		if change.observed.IsZero() {
+			continue
+		}
+
+		applied := true
+		for profileName, name := range c.rollout.selected {
+			if name != tunedName {
+				continue
+			}
+			profile, err := c.listers.TunedProfiles.Get(profileName)
+			if err != nil || !profileObserved(profile) || !profileApplied(profile) {
+				applied = false
+				break
+			}
+		}
+		if !applied {
+			continue
+		}
+
+		d := time.Since(change.observed)
+		klog.V(2).Infof("all Profiles selected by Tuned %s (generation %d) applied in %v", tunedName, change.generation, d)
+		metrics.TunedRolloutDuration(d)
+		change.observed = time.Time{}
+		c.tunedChanges[tunedName] = change
+	}
+}
+
+// profileStateCounts returns the number of Profiles 'profileList' applied, degraded
+// and deferred.  Each Profile is counted in at most one of these states.
+func profileStateCounts(profileList []*tunedv1.Profile) (applied, degraded, deferred int) {
+	for _, profile := range profileList {
+		switch {
+		case profileDeferred(profile):
+			deferred++
+		case profileDegraded(profile):
+			degraded++
+		case profileApplied(profile):
+			applied++
+		}
+	}
+
+	return applied, degraded, deferred
+}
+
+// syncProfileMetrics exposes the number of Profiles in each state and, outside
+// of HyperShift, the number of MachineConfigs managed by the operator.  In
+// HyperShift, the MachineConfigs are counted when pruned.
+func (c *Controller) syncProfileMetrics() error {
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	numApplied, numDegraded, numDeferred := profileStateCounts(profileList)
+	metrics.ProfileStates(numApplied, numDegraded, numDeferred, c.numProfilesWithBootcmdlineConflict(profileList))
+
+	if ntoconfig.InHyperShift() {
+		return nil
+	}
+	numMC, err := c.numMachineConfigsManaged()
+	if err != nil {
+		return err
+	}
+	metrics.MachineConfigsManaged(numMC)
+
+	return nil
+}
+
+// numMachineConfigsManaged returns the number of MachineConfigs created by the operator.
+func (c *Controller) numMachineConfigsManaged() (int, error) {
+	mcList, err := c.listers.MachineConfigs.List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, mc := range mcList {
+		if _, ok := mc.ObjectMeta.Annotations[GeneratedByControllerVersionAnnotationKey]; ok {
+			n++
+		}
+	}
+
+	return n, nil
+}
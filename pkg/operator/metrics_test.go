@@ -0,0 +1,51 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestInstrumentedQueue(t *testing.T) {
+	q := newInstrumentedQueue(workqueue.DefaultTypedControllerRateLimiter[wqKey]())
+	defer q.ShutDown()
+
+	profileA := wqKey{kind: wqKindProfile, name: "a"}
+	profileB := wqKey{kind: wqKindProfile, name: "b"}
+	tuned := wqKey{kind: wqKindTuned, name: "default"}
+
+	q.Add(profileA)
+	q.Add(profileA) // deduplicated
+	q.AddAfter(profileB, time.Hour)
+	q.AddRateLimited(tuned)
+	if n := q.pendingKind(wqKindProfile); n != 1 {
+		t.Errorf("want 1 pending Profile key, have %d", n)
+	}
+
+	// The rate limited key is counted once its delay passes.
+	deadline := time.Now().Add(5 * time.Second)
+	for q.pendingKind(wqKindTuned) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := q.pendingKind(wqKindTuned); n != 1 {
+		t.Errorf("want 1 pending Tuned key, have %d", n)
+	}
+
+	key, _ := q.Get()
+	if key != profileA {
+		t.Fatalf("want key %v, have %v", profileA, key)
+	}
+	q.Done(key)
+	if n := q.pendingKind(wqKindProfile); n != 0 {
+		t.Errorf("want no pending Profile keys, have %d", n)
+	}
+
+	// A delayed key already waiting to be processed is counted once.
+	q.Add(profileA)
+	q.AddAfter(profileA, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	if n := q.pendingKind(wqKindProfile); n != 1 {
+		t.Errorf("want 1 pending Profile key, have %d", n)
+	}
+}
@@ -0,0 +1,196 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+)
+
+// Name of the TuneD DaemonSet container environment variable holding the resync period in seconds.
+const resyncPeriodEnv = "RESYNC_PERIOD"
+
+// operatorConfig returns the cluster-wide OperatorConfig or nil if it does not exist.
+// The OperatorConfig is not used in HyperShift.
+func (c *Controller) operatorConfig() (*tunedv1.OperatorConfig, error) {
+	if c.listers.OperatorConfigs == nil {
+		return nil, nil
+	}
+	cfg, err := c.listers.OperatorConfigs.Get(tunedv1.OperatorConfigResourceName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get OperatorConfig %s: %v", tunedv1.OperatorConfigResourceName, err)
+	}
+	return cfg, nil
+}
+
+// initialResyncPeriod returns the resync period in effect before the informer
+// caches are populated.
+func (c *Controller) initialResyncPeriod(ctx context.Context) time.Duration {
+	if ntoconfig.InHyperShift() {
+		return ntoconfig.ResyncPeriod()
+	}
+	cfg, err := c.clients.Tuned.TunedV1().OperatorConfigs().Get(ctx, tunedv1.OperatorConfigResourceName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("failed to get OperatorConfig %s, using the default resync period: %v", tunedv1.OperatorConfigResourceName, err)
+		}
+		return ntoconfig.ResyncPeriod()
+	}
+	return operatorConfigResyncPeriod(cfg)
+}
+
+// operatorConfigResyncPeriod returns the resync period set by OperatorConfig 'cfg'
+// or the default one.  The period is truncated to whole seconds as it is passed
+// to the TuneD daemons in seconds.
+func operatorConfigResyncPeriod(cfg *tunedv1.OperatorConfig) time.Duration {
+	if cfg == nil || cfg.Spec.ResyncPeriod == nil {
+		return ntoconfig.ResyncPeriod()
+	}
+	period := cfg.Spec.ResyncPeriod.Duration.Truncate(time.Second)
+	if period <= 0 {
+		klog.Errorf("invalid resync period %v in OperatorConfig %s, using the default", cfg.Spec.ResyncPeriod.Duration, cfg.Name)
+		return ntoconfig.ResyncPeriod()
+	}
+	return period
+}
+
+// effectiveOperatorConfig returns the configuration in effect given OperatorConfig
+// 'cfg' (possibly nil) and the defaults of the TuneD DaemonSet manifest 'dsMf'.
+func effectiveOperatorConfig(cfg *tunedv1.OperatorConfig, dsMf *appsv1.DaemonSet) tunedv1.OperatorConfigStatus {
+	podSpec := &dsMf.Spec.Template.Spec
+	resources := podSpec.Containers[0].Resources.DeepCopy()
+	effective := tunedv1.OperatorConfigStatus{
+		DaemonSet: tunedv1.DaemonSetConfig{
+			Tolerations:       podSpec.Tolerations,
+			NodeSelector:      map[string]string{},
+			Resources:         resources,
+			PriorityClassName: podSpec.PriorityClassName,
+		},
+		ResyncPeriod: metav1.Duration{Duration: operatorConfigResyncPeriod(cfg)},
+	}
+
+	var spec tunedv1.OperatorConfigSpec
+	if cfg != nil {
+		spec = cfg.Spec
+		effective.ObservedGeneration = cfg.Generation
+	}
+
+	if spec.DaemonSet.Tolerations != nil {
+		effective.DaemonSet.Tolerations = spec.DaemonSet.Tolerations
+	}
+	for k, v := range spec.DaemonSet.NodeSelector {
+		effective.DaemonSet.NodeSelector[k] = v
+	}
+	// The manifest node selector cannot be overridden, the TuneD daemon only runs on Linux Nodes.
+	for k, v := range podSpec.NodeSelector {
+		effective.DaemonSet.NodeSelector[k] = v
+	}
+	if spec.DaemonSet.Resources != nil {
+		effective.DaemonSet.Resources = spec.DaemonSet.Resources
+	}
+	if spec.DaemonSet.PriorityClassName != "" {
+		effective.DaemonSet.PriorityClassName = spec.DaemonSet.PriorityClassName
+	}
+	effective.OperandVerbosity = spec.OperandVerbosity
+	effective.Metrics = spec.Metrics
+	if effective.Metrics.Verb == "" {
+		effective.Metrics.Verb = metrics.AuthDefaultVerb
+	}
+
+	return *effective.DeepCopy()
+}
+
+// applyOperatorConfig sets the TuneD DaemonSet 'ds' fields governed by the
+// configuration in effect 'effective'.
+func applyOperatorConfig(ds *appsv1.DaemonSet, effective tunedv1.OperatorConfigStatus) {
+	podSpec := &ds.Spec.Template.Spec
+	podSpec.Tolerations = effective.DaemonSet.Tolerations
+	podSpec.NodeSelector = effective.DaemonSet.NodeSelector
+	podSpec.PriorityClassName = effective.DaemonSet.PriorityClassName
+	if effective.DaemonSet.Resources != nil {
+		podSpec.Containers[0].Resources = *effective.DaemonSet.Resources
+	}
+	setContainerEnv(&podSpec.Containers[0], resyncPeriodEnv, strconv.FormatInt(int64(effective.ResyncPeriod.Duration/time.Second), 10))
+	metricsConfig, err := json.Marshal(effective.Metrics)
+	if err != nil {
+		// This should never happen.
+		klog.Errorf("failed to encode the metrics configuration: %v", err)
+		return
+	}
+	setContainerEnv(&podSpec.Containers[0], metrics.AuthConfigEnv, string(metricsConfig))
+}
+
+// daemonSetConfigEqual returns true if the TuneD DaemonSet 'ds' fields governed by
+// OperatorConfig are equal to those of DaemonSet 'dsMf'.
+func daemonSetConfigEqual(ds, dsMf *appsv1.DaemonSet) bool {
+	podSpec, podSpecMf := &ds.Spec.Template.Spec, &dsMf.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		return false
+	}
+	return equality.Semantic.DeepEqual(podSpec.Tolerations, podSpecMf.Tolerations) &&
+		equality.Semantic.DeepEqual(podSpec.NodeSelector, podSpecMf.NodeSelector) &&
+		podSpec.PriorityClassName == podSpecMf.PriorityClassName &&
+		equality.Semantic.DeepEqual(podSpec.Containers[0].Resources, podSpecMf.Containers[0].Resources) &&
+		containerEnv(&podSpec.Containers[0], resyncPeriodEnv) == containerEnv(&podSpecMf.Containers[0], resyncPeriodEnv) &&
+		containerEnv(&podSpec.Containers[0], metrics.AuthConfigEnv) == containerEnv(&podSpecMf.Containers[0], metrics.AuthConfigEnv)
+}
+
+// containerEnv returns the value of environment variable 'name' of container 'c'.
+func containerEnv(c *corev1.Container, name string) string {
+	for _, env := range c.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+// setContainerEnv sets environment variable 'name' of container 'c' to 'value'.
+func setContainerEnv(c *corev1.Container, name, value string) {
+	for i := range c.Env {
+		if c.Env[i].Name == name {
+			c.Env[i].Value = value
+			return
+		}
+	}
+	c.Env = append(c.Env, corev1.EnvVar{Name: name, Value: value})
+}
+
+// syncOperatorConfigStatus reports the configuration in effect 'effective' in
+// the status of OperatorConfig 'cfg' (possibly nil).
+func (c *Controller) syncOperatorConfigStatus(cfg *tunedv1.OperatorConfig, effective tunedv1.OperatorConfigStatus) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(cfg.Status, effective) {
+		klog.V(2).Infof("syncOperatorConfigStatus(): OperatorConfig %s status is up-to-date", cfg.Name)
+		return nil
+	}
+
+	cfg = cfg.DeepCopy() // never update the objects from cache
+	cfg.Status = effective
+
+	klog.V(2).Infof("syncOperatorConfigStatus(): updating OperatorConfig %s status", cfg.Name)
+	_, err := c.clients.Tuned.TunedV1().OperatorConfigs().UpdateStatus(context.TODO(), cfg, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update OperatorConfig %s status: %v", cfg.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	ntomf "github.com/openshift/cluster-node-tuning-operator/pkg/manifests"
+)
+
+func TestEffectiveOperatorConfig(t *testing.T) {
+	t.Setenv("RESYNC_PERIOD", "")
+
+	tolerations := []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists}}
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20m")},
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *tunedv1.OperatorConfig
+		want      func(defaults tunedv1.OperatorConfigStatus) tunedv1.OperatorConfigStatus
+		resyncEnv string
+	}{
+		{
+			name: "no OperatorConfig",
+			cfg:  nil,
+			want: func(defaults tunedv1.OperatorConfigStatus) tunedv1.OperatorConfigStatus {
+				return defaults
+			},
+			resyncEnv: "600",
+		},
+		{
+			name: "all settings",
+			cfg: &tunedv1.OperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: tunedv1.OperatorConfigResourceName, Generation: 3},
+				Spec: tunedv1.OperatorConfigSpec{
+					DaemonSet: tunedv1.DaemonSetConfig{
+						Tolerations:       tolerations,
+						NodeSelector:      map[string]string{"node-role.kubernetes.io/worker": "", "kubernetes.io/os": "windows"},
+						Resources:         resources,
+						PriorityClassName: "system-cluster-critical",
+					},
+					OperandVerbosity: 2,
+					ResyncPeriod:     &metav1.Duration{Duration: 5*time.Minute + 500*time.Millisecond},
+					Metrics: tunedv1.MetricsConfig{
+						Resource:                   &tunedv1.MetricsResourceAttributes{Resource: "services", Name: "node-tuning-operator"},
+						ClientCertificateAllowlist: []string{"system:serviceaccount:openshift-monitoring:prometheus-k8s"},
+					},
+				},
+			},
+			want: func(defaults tunedv1.OperatorConfigStatus) tunedv1.OperatorConfigStatus {
+				return tunedv1.OperatorConfigStatus{
+					ObservedGeneration: 3,
+					DaemonSet: tunedv1.DaemonSetConfig{
+						Tolerations:       tolerations,
+						NodeSelector:      map[string]string{"node-role.kubernetes.io/worker": "", "kubernetes.io/os": "linux"},
+						Resources:         resources,
+						PriorityClassName: "system-cluster-critical",
+					},
+					OperandVerbosity: 2,
+					ResyncPeriod:     metav1.Duration{Duration: 5 * time.Minute},
+					Metrics: tunedv1.MetricsConfig{
+						Verb:                       "get",
+						Resource:                   &tunedv1.MetricsResourceAttributes{Resource: "services", Name: "node-tuning-operator"},
+						ClientCertificateAllowlist: []string{"system:serviceaccount:openshift-monitoring:prometheus-k8s"},
+					},
+				}
+			},
+			resyncEnv: "300",
+		},
+		{
+			name: "invalid resync period",
+			cfg: &tunedv1.OperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: tunedv1.OperatorConfigResourceName, Generation: 1},
+				Spec: tunedv1.OperatorConfigSpec{
+					ResyncPeriod: &metav1.Duration{Duration: 0},
+				},
+			},
+			want: func(defaults tunedv1.OperatorConfigStatus) tunedv1.OperatorConfigStatus {
+				defaults.ObservedGeneration = 1
+				return defaults
+			},
+			resyncEnv: "600",
+		},
+	}
+
+	defaults := tunedv1.OperatorConfigStatus{
+		DaemonSet: tunedv1.DaemonSetConfig{
+			Tolerations:  []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+			},
+			PriorityClassName: "system-node-critical",
+		},
+		ResyncPeriod: metav1.Duration{Duration: ntoconfig.ResyncPeriod()},
+		Metrics:      tunedv1.MetricsConfig{Verb: "get"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dsMf := ntomf.TunedDaemonSet()
+			effective := effectiveOperatorConfig(tc.cfg, dsMf)
+			if want := tc.want(*defaults.DeepCopy()); !reflect.DeepEqual(effective, want) {
+				t.Errorf("effectiveOperatorConfig(): want %+v, have %+v", want, effective)
+			}
+
+			ds := dsMf.DeepCopy()
+			applyOperatorConfig(dsMf, effective)
+			if tc.cfg == nil && !daemonSetConfigEqual(ds, dsMf) {
+				t.Errorf("the DaemonSet manifest changed without an OperatorConfig")
+			}
+			if tc.cfg != nil && tc.cfg.Spec.DaemonSet.Resources != nil && daemonSetConfigEqual(ds, dsMf) {
+				t.Errorf("the DaemonSet manifest did not change")
+			}
+			if env := containerEnv(&dsMf.Spec.Template.Spec.Containers[0], resyncPeriodEnv); env != tc.resyncEnv {
+				t.Errorf("%s: want %q, have %q", resyncPeriodEnv, tc.resyncEnv, env)
+			}
+		})
+	}
+}
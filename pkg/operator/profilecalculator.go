@@ -13,6 +13,7 @@ import (
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	tunedpkg "github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 
 	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
@@ -37,6 +38,11 @@ type tunedState struct {
 	bootcmdline map[string]string
 	// Node name:   ^^^^^^
 	// bootcmdline         ^^^^^^
+	paused map[string]bool
+	// Node name: ^^^^^^
+	profileOverride map[string]string
+	// Node name:       ^^^^^^
+	// TuneD profile           ^^^^^^
 }
 
 type ProfileCalculator struct {
@@ -54,6 +60,8 @@ func NewProfileCalculator(listers *ntoclient.Listers, clients *ntoclient.Clients
 	pc.state.podLabels = map[string]map[string]map[string]string{}
 	pc.state.providerIDs = map[string]string{}
 	pc.state.bootcmdline = map[string]string{}
+	pc.state.paused = map[string]bool{}
+	pc.state.profileOverride = map[string]string{}
 	return pc
 }
 
@@ -139,6 +147,28 @@ func (pc *ProfileCalculator) nodeChangeHandler(nodeName string) (bool, error) {
 		}
 	}
 
+	paused := node.Annotations[tunedv1.TunedPauseAnnotationKey] == "true"
+	if paused != pc.state.paused[nodeName] {
+		klog.V(3).Infof("Node's %s Profile updates paused=%v", nodeName, paused)
+		change = true
+	}
+	if paused {
+		pc.state.paused[nodeName] = true
+	} else {
+		delete(pc.state.paused, nodeName)
+	}
+
+	profileOverride := node.Annotations[tunedv1.TunedProfileOverrideAnnotationKey]
+	if profileOverride != pc.state.profileOverride[nodeName] {
+		klog.V(3).Infof("Node's %s TuneD profile override=%q", nodeName, profileOverride)
+		change = true
+	}
+	if profileOverride != "" {
+		pc.state.profileOverride[nodeName] = profileOverride
+	} else {
+		delete(pc.state.profileOverride, nodeName)
+	}
+
 	nodeLabelsNew := util.MapOfStringsCopy(node.Labels)
 
 	if !util.MapOfStringsEqual(nodeLabelsNew, pc.state.nodeLabels[nodeName]) {
@@ -151,19 +181,40 @@ func (pc *ProfileCalculator) nodeChangeHandler(nodeName string) (bool, error) {
 }
 
 type ComputedProfile struct {
-	TunedProfileName string
-	AllProfiles      []tunedv1.TunedProfile
-	Deferred         util.DeferMode
-	MCLabels         map[string]string
-	NodePoolName     string
-	Operand          tunedv1.OperandConfig
+	TunedProfileName  string
+	TunedName         string
+	AllProfiles       []tunedv1.TunedProfile
+	Deferred          util.DeferMode
+	MaintenanceWindow *tunedv1.TunedMaintenanceWindow
+	MCLabels          map[string]string
+	NodePoolName      string
+	Operand           tunedv1.OperandConfig
+	PriorityConflicts []PriorityConflict
+
+	// TuneD profiles of the matching additive recommend rules to stack on top of TunedProfileName.
+	AdditiveProfileNames []string
+	// TuneD profile forced by the Node's profile override annotation.  If set, it is also
+	// the TunedProfileName and no additive profiles or MachineConfigs are used.
+	ProfileOverride string
+}
+
+// PriorityConflict describes two different TuneD profiles recommended with
+// the same priority by Tuned CRs and matching the same Node.
+type PriorityConflict struct {
+	TunedName                string // Tuned CR recommending the selected TuneD profile
+	TunedProfileName         string // the selected TuneD profile
+	ConflictTunedName        string // Tuned CR recommending the conflicting TuneD profile
+	ConflictTunedProfileName string // the conflicting TuneD profile
+	Priority                 uint64
 }
 
 type RecommendedProfile struct {
-	TunedProfileName string
-	Deferred         util.DeferMode
-	Labels           map[string]string
-	Config           tunedv1.OperandConfig
+	TunedProfileName  string
+	TunedName         string
+	Deferred          util.DeferMode
+	MaintenanceWindow *tunedv1.TunedMaintenanceWindow
+	Labels            map[string]string
+	Config            tunedv1.OperandConfig
 }
 
 // calculateProfile calculates a tuned profile for Node nodeName.
@@ -174,7 +225,9 @@ type RecommendedProfile struct {
 // * MachineConfig labels if the profile was selected by machineConfigLabels
 // * operand configuration as defined by tunedv1.OperandConfig
 // * an error if any
-func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile, error) {
+//
+// The evaluation of the recommend rules is recorded in 'trace' if not nil.
+func (pc *ProfileCalculator) calculateProfile(nodeName string, trace *profileTrace) (ComputedProfile, error) {
 	klog.V(3).Infof("calculateProfile(%s)", nodeName)
 	tunedList, err := pc.listers.TunedResources.List(labels.Everything())
 
@@ -188,62 +241,86 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 	}
 
 	recommendAll := TunedRecommend(tunedList)
+	trace.start(recommendAll)
+	var (
+		pools []*mcfgv1.MachineConfigPool
+		node  *corev1.Node
+	)
+	// recommendMatches returns true if recommend rule 'i' matches Node 'nodeName' and
+	// true for 'byMachineConfigLabels' if the rule matched by its machineConfigLabels.
+	recommendMatches := func(nodeName string, i int) (matched, byMachineConfigLabels bool, err error) {
+		recommend := recommendAll[i]
+		// Start with node/pod label based matching to MachineConfig matching when
+		// both the match section and MachineConfigLabels are specified.
+		// Also note the catch-all functionality when "recommend.Match == nil",
+		// we do not want to call profileMatches() in that case unless machineConfigLabels
+		// is undefined.
+		if (recommend.Match != nil || recommend.MachineConfigLabels == nil) && pc.profileMatchesTrace(recommend.Match, nodeName, 0, trace.details(i)) {
+			trace.matchedByMatch(i, len(recommend.Match) == 0)
+			return true, false, nil
+		}
+
+		if recommend.MachineConfigLabels == nil {
+			// Speed things up, empty labels (used as selectors) match/select nothing.
+			return false, false, nil
+		}
+
+		if node == nil {
+			// We did not retrieve the node object from cache yet -- get it and also the pools
+			// for this node.  Fetching the node/pools is often unneeded and would likely have
+			// a performance impact, do it only once needed.
+			node, err = pc.listers.Nodes.Get(nodeName)
+			if err != nil {
+				return false, false, err
+			}
+
+			pools, err = pc.getPoolsForNode(node)
+			if err != nil {
+				node = nil
+				return false, false, err
+			}
+		}
+
+		// MachineConfigLabels based matching
+		matched = pc.machineConfigLabelsMatch(recommend.MachineConfigLabels, pools)
+		trace.machineConfigLabels(i, recommend.MachineConfigLabels, pools, matched)
+		return matched, matched, nil
+	}
 	recommendProfile := func(nodeName string, iStart int) (int, RecommendedProfile, error) {
 		var i int
 		for i = iStart; i < len(recommendAll); i++ {
-			var (
-				pools []*mcfgv1.MachineConfigPool
-				node  *corev1.Node
-			)
 			recommend := recommendAll[i]
 
-			// Start with node/pod label based matching to MachineConfig matching when
-			// both the match section and MachineConfigLabels are specified.
-			// Also note the catch-all functionality when "recommend.Match == nil",
-			// we do not want to call profileMatches() in that case unless machineConfigLabels
-			// is undefined.
-			if (recommend.Match != nil || recommend.MachineConfigLabels == nil) && pc.profileMatches(recommend.Match, nodeName) {
-				return i, RecommendedProfile{
-					TunedProfileName: *recommend.Profile,
-					Config:           recommend.Operand,
-					Deferred:         recommend.Deferred,
-				}, nil
+			if recommend.Additive {
+				// Additive profiles are stacked on top of the selected profile, see additiveProfiles().
+				continue
 			}
 
-			if recommend.MachineConfigLabels == nil {
-				// Speed things up, empty labels (used as selectors) match/select nothing.
+			matched, byMachineConfigLabels, err := recommendMatches(nodeName, i)
+			if err != nil {
+				return i, RecommendedProfile{}, err
+			}
+			if !matched {
 				continue
 			}
 
-			if node == nil {
-				// We did not retrieve the node object from cache yet -- get it and also the pools
-				// for this node.  Do not move this code outside the for loop, fetching the node/pools
-				// is often unneeded and would likely have a performance impact.
-				node, err = pc.listers.Nodes.Get(nodeName)
-				if err != nil {
-					return i, RecommendedProfile{}, err
-				}
-
-				pools, err = pc.getPoolsForNode(node)
-				if err != nil {
-					return i, RecommendedProfile{}, err
-				}
+			recommendedProfile := RecommendedProfile{
+				TunedProfileName:  *recommend.Profile,
+				TunedName:         recommend.TunedName,
+				Config:            recommend.Operand,
+				Deferred:          recommend.Deferred,
+				MaintenanceWindow: recommend.MaintenanceWindow,
 			}
-
-			// MachineConfigLabels based matching
-			if pc.machineConfigLabelsMatch(recommend.MachineConfigLabels, pools) {
-				return i, RecommendedProfile{
-					TunedProfileName: *recommend.Profile,
-					Labels:           recommend.MachineConfigLabels,
-					Config:           recommend.Operand,
-					Deferred:         recommend.Deferred,
-				}, nil
+			if byMachineConfigLabels {
+				recommendedProfile.Labels = recommend.MachineConfigLabels
 			}
+			return i, recommendedProfile, nil
 		}
 		// No profile matches.  This is not necessarily a problem, e.g. when we check for matching profiles with the same priority.
 		return i, RecommendedProfile{TunedProfileName: defaultProfile}, nil
 	}
 	iStop, recommendedProfile, err := recommendProfile(nodeName, 0)
+	trace.selected(iStop)
 
 	if iStop == len(recommendAll) {
 		// This should never happen; the default Tuned CR should always be accessible and with a catch-all rule
@@ -263,49 +340,46 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 	}
 
 	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a warning.
-	for i := iStop + 1; i < len(recommendAll); i++ {
-		j, recommendedProfileDup, err := recommendProfile(nodeName, i)
-		if err != nil {
-			// Duplicate matching profile priority detection failed, likely due to a failure to retrieve a k8s object.
-			// This is not fatal, do not spam the logs, as we will retry later during a periodic resync.
-			continue
-		}
-		if j == len(recommendAll) {
-			// No other profile matched.
-			break
-		}
-		i = j // This will also ensure we do not go through the same recommend rules when calling r() again.
-
-		if recommendAll[iStop].Priority == nil || recommendAll[i].Priority == nil {
-			// This should never happen as Priority is a required field, but just in case -- we don't want to crash below.
-			klog.Warningf("one or both of profiles %s/%s have undefined priority", *recommendAll[iStop].Profile, *recommendAll[i].Profile)
-			continue
-		}
-		// Warn if two profiles have the same priority, and different names.
-		// If they have the same name and different contents a separate warning
-		// will be issued by manifests.tunedRenderedProfiles()
-		if *recommendAll[iStop].Priority == *recommendAll[i].Priority {
-			if recommendedProfile.TunedProfileName != recommendedProfileDup.TunedProfileName {
-				klog.Warningf("profiles %s/%s have the same priority %d and match %s; please use a different priority for your custom profiles!",
-					recommendedProfile.TunedProfileName, recommendedProfileDup.TunedProfileName, *recommendAll[i].Priority, nodeName)
-			}
-		} else {
-			// We no longer have recommend rules with the same priority -- do not go through the entire (priority-ordered) list.
-			break
-		}
+	// When tracing, all the recommend rules are evaluated to explain the profile selection.
+	priorityConflicts := recommendPriorityConflicts(recommendAll, iStop, nodeName, func(iStart int) (int, error) {
+		i, _, err := recommendProfile(nodeName, iStart)
+		return i, err
+	}, trace != nil)
+
+	var additive []string
+	if err == nil {
+		additive, err = additiveProfiles(recommendAll, recommendedProfile.TunedProfileName, func(i int) (bool, error) {
+			matched, _, err := recommendMatches(nodeName, i)
+			return matched, err
+		}, trace)
 	}
+	trace.recommended(recommendedProfile.TunedProfileName, additive)
+
+	computed := ComputedProfile{
+		TunedProfileName:     recommendedProfile.TunedProfileName,
+		TunedName:            recommendedProfile.TunedName,
+		AdditiveProfileNames: additive,
+		AllProfiles:          tunedProfilesForNode(profilesAll, util.StackedTunedProfiles(recommendedProfile.TunedProfileName, additive), pc.providerName(nodeName)),
+		Deferred:             recommendedProfile.Deferred,
+		MaintenanceWindow:    recommendedProfile.MaintenanceWindow,
+		MCLabels:             recommendedProfile.Labels,
+		Operand:              recommendedProfile.Config,
+		PriorityConflicts:    priorityConflicts,
+	}
+	pc.profileOverride(nodeName, profilesAll, &computed)
+
+	return computed, err
+}
 
-	return ComputedProfile{
-		TunedProfileName: recommendedProfile.TunedProfileName,
-		AllProfiles:      profilesAll,
-		Deferred:         recommendedProfile.Deferred,
-		MCLabels:         recommendedProfile.Labels,
-		Operand:          recommendedProfile.Config,
-	}, err
+// CalculateProfile calculates a tuned profile for Node nodeName.
+// See calculateProfile for details.
+func (pc *ProfileCalculator) CalculateProfile(nodeName string) (ComputedProfile, error) {
+	return pc.calculateProfile(nodeName, nil)
 }
 
 type HypershiftRecommendedProfile struct {
 	TunedProfileName string
+	TunedName        string
 	Deferred         util.DeferMode
 	NodePoolName     string
 	Config           tunedv1.OperandConfig
@@ -360,11 +434,17 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 		for i = iStart; i < len(recommendAll); i++ {
 			recommend := recommendAll[i]
 
+			if recommend.Additive {
+				// Additive profiles are stacked on top of the selected profile, see additiveProfiles().
+				continue
+			}
+
 			// Start with node/pod label based matching
 			if recommend.Match != nil && pc.profileMatches(recommend.Match, nodeName) {
 				klog.V(3).Infof("calculateProfileHyperShift: node / pod label matching used for node: %s, tunedProfileName: %s, nodePoolName: %s, operand: %v", nodeName, *recommend.Profile, "", recommend.Operand)
 				return i, HypershiftRecommendedProfile{
 					TunedProfileName: *recommend.Profile,
+					TunedName:        recommend.TunedName,
 					Config:           recommend.Operand,
 				}, nil
 			}
@@ -375,12 +455,14 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 					// Don't set nodepool for default profile, no MachineConfigs should be generated.
 					return i, HypershiftRecommendedProfile{
 						TunedProfileName: *recommend.Profile,
+						TunedName:        recommend.TunedName,
 						Config:           recommend.Operand,
 					}, nil
 				}
 				klog.V(3).Infof("calculateProfileHyperShift: NodePool based matching used for node: %s, tunedProfileName: %s, nodePoolName: %s", nodeName, *recommend.Profile, nodePoolName)
 				return i, HypershiftRecommendedProfile{
 					TunedProfileName: *recommend.Profile,
+					TunedName:        recommend.TunedName,
 					NodePoolName:     nodePoolName,
 					Config:           recommend.Operand,
 				}, nil
@@ -400,8 +482,68 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 	}
 
 	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a warning.
+	priorityConflicts := recommendPriorityConflicts(recommendAll, iStop, nodeName, func(iStart int) (int, error) {
+		i, _, err := recommendProfile(nodeName, iStart)
+		return i, err
+	}, false)
+
+	var additive []string
+	if err == nil {
+		// If recommend.Match is empty, NodePool based matching is assumed.
+		additive, err = additiveProfiles(recommendAll, recommendedProfile.TunedProfileName, func(i int) (bool, error) {
+			return recommendAll[i].Match == nil || pc.profileMatches(recommendAll[i].Match, nodeName), nil
+		}, nil)
+	}
+
+	computed := ComputedProfile{
+		TunedProfileName:     recommendedProfile.TunedProfileName,
+		TunedName:            recommendedProfile.TunedName,
+		AdditiveProfileNames: additive,
+		AllProfiles:          tunedProfilesForNode(profilesAll, util.StackedTunedProfiles(recommendedProfile.TunedProfileName, additive), pc.providerName(nodeName)),
+		Deferred:             recommendedProfile.Deferred,
+		NodePoolName:         recommendedProfile.NodePoolName,
+		Operand:              recommendedProfile.Config,
+		PriorityConflicts:    priorityConflicts,
+	}
+	pc.profileOverride(nodeName, profilesAll, &computed)
+
+	return computed, err
+}
+
+// profileOverride forces the TuneD profile set by the profile override annotation
+// of Node 'nodeName' in the 'computed' profile.  The operand configuration of the
+// recommended profile is kept.  MachineConfigs are not synchronized for overridden
+// profiles, the override is meant for debugging a single Node.
+func (pc *ProfileCalculator) profileOverride(nodeName string, profilesAll []tunedv1.TunedProfile, computed *ComputedProfile) {
+	profileOverride, ok := pc.state.profileOverride[nodeName]
+	if !ok {
+		return
+	}
+	klog.V(2).Infof("overriding TuneD profile %s of Node %s by %s", computed.TunedProfileName, nodeName, profileOverride)
+
+	computed.TunedProfileName = profileOverride
+	computed.AdditiveProfileNames = nil
+	computed.AllProfiles = tunedProfilesForNode(profilesAll, profileOverride, pc.providerName(nodeName))
+	computed.MCLabels = nil
+	computed.NodePoolName = ""
+	computed.ProfileOverride = profileOverride
+}
+
+// providerName returns the cloud provider name of Node 'nodeName' known to the
+// ProfileCalculator internal data structures.
+func (pc *ProfileCalculator) providerName(nodeName string) string {
+	return util.GetProviderName(pc.state.providerIDs[nodeName])
+}
+
+// recommendPriorityConflicts returns the recommend rules among 'recommendAll'
+// matching Node 'nodeName' with the same priority as the selected rule 'iStop'
+// and a different TuneD profile.  'next' returns the index of the first matching
+// rule starting from the given index.  Unless 'all' is set, the rules are only
+// evaluated while they have the same priority as the selected rule.
+func recommendPriorityConflicts(recommendAll []TunedRecommendInfo, iStop int, nodeName string, next func(int) (int, error), all bool) []PriorityConflict {
+	var priorityConflicts []PriorityConflict
 	for i := iStop + 1; i < len(recommendAll); i++ {
-		j, recommendedProfileDup, err := recommendProfile(nodeName, i)
+		j, err := next(i)
 		if err != nil {
 			// Duplicate matching profile priority detection failed, likely due to a failure to retrieve a k8s object.
 			// This is not fatal, do not spam the logs, as we will retry later during a periodic resync.
@@ -411,7 +553,7 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 			// No other profile matched.
 			break
 		}
-		i = j // This will also ensure we do not go through the same recommend rules when calling r() again.
+		i = j // This will also ensure we do not go through the same recommend rules when calling next() again.
 
 		if recommendAll[iStop].Priority == nil || recommendAll[i].Priority == nil {
 			// This should never happen as Priority is a required field, but just in case -- we don't want to crash below.
@@ -422,29 +564,75 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 		// If they have the same name and different contents a separate warning
 		// will be issued by manifests.tunedRenderedProfiles()
 		if *recommendAll[iStop].Priority == *recommendAll[i].Priority {
-			if recommendedProfile.TunedProfileName != recommendedProfileDup.TunedProfileName {
+			if *recommendAll[iStop].Profile != *recommendAll[i].Profile {
 				klog.Warningf("profiles %s/%s have the same priority %d and match %s; please use a different priority for your custom profiles!",
-					recommendedProfile.TunedProfileName, recommendedProfileDup.TunedProfileName, *recommendAll[i].Priority, nodeName)
+					*recommendAll[iStop].Profile, *recommendAll[i].Profile, *recommendAll[i].Priority, nodeName)
+				priorityConflicts = append(priorityConflicts, PriorityConflict{
+					TunedName:                recommendAll[iStop].TunedName,
+					TunedProfileName:         *recommendAll[iStop].Profile,
+					ConflictTunedName:        recommendAll[i].TunedName,
+					ConflictTunedProfileName: *recommendAll[i].Profile,
+					Priority:                 *recommendAll[i].Priority,
+				})
 			}
-		} else {
+		} else if !all {
 			// We no longer have recommend rules with the same priority -- do not go through the entire (priority-ordered) list.
 			break
 		}
 	}
 
-	return ComputedProfile{
-		TunedProfileName: recommendedProfile.TunedProfileName,
-		AllProfiles:      profilesAll,
-		Deferred:         recommendedProfile.Deferred,
-		NodePoolName:     recommendedProfile.NodePoolName,
-		Operand:          recommendedProfile.Config,
-	}, err
+	return priorityConflicts
+}
+
+// additiveProfiles returns the TuneD profiles of the additive rules among
+// 'recommendAll' for which 'matches' returns true given the index of the rule.
+// The profiles are stacked on top of TuneD profile 'base' and ordered from the
+// lowest to the highest priority as the options of later TuneD profiles override
+// the earlier ones.  Each profile is listed once, at the position of its
+// highest-priority matching rule.  The selected rules are recorded in 'trace'
+// if not nil.
+func additiveProfiles(recommendAll []TunedRecommendInfo, base string, matches func(int) (bool, error), trace *profileTrace) ([]string, error) {
+	var profiles []string
+	seen := map[string]bool{base: true}
+
+	// recommendAll is sorted by priority, the highest priority first.
+	for i, recommend := range recommendAll {
+		if !recommend.Additive || recommend.Profile == nil {
+			continue
+		}
+		if seen[*recommend.Profile] {
+			trace.detail(i, "TuneD profile %s is already selected", *recommend.Profile)
+			continue
+		}
+		matched, err := matches(i)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			seen[*recommend.Profile] = true
+			trace.selected(i)
+			profiles = append(profiles, *recommend.Profile)
+		}
+	}
+
+	// Apply the highest priority profile last.
+	for i, j := 0, len(profiles)-1; i < j; i, j = i+1, j-1 {
+		profiles[i], profiles[j] = profiles[j], profiles[i]
+	}
+	return profiles, nil
 }
 
 // profileMatches returns true, if Node 'nodeName' fulfills all the necessary
 // requirements of TunedMatch's tree-like definition of profile matching
 // rules 'match'.
 func (pc *ProfileCalculator) profileMatches(match []tunedv1.TunedMatch, nodeName string) bool {
+	return pc.profileMatchesTrace(match, nodeName, 0, nil)
+}
+
+// profileMatchesTrace is profileMatches which also records the result of
+// evaluating the individual TunedMatch rules in 'trace' if not nil.  'depth'
+// is the depth of the 'match' subtree used for indenting the records.
+func (pc *ProfileCalculator) profileMatchesTrace(match []tunedv1.TunedMatch, nodeName string, depth int, trace *[]string) bool {
 	if len(match) == 0 {
 		// Empty catch-all profile with no Node/Pod labels
 		return true
@@ -453,34 +641,87 @@ func (pc *ProfileCalculator) profileMatches(match []tunedv1.TunedMatch, nodeName
 	for _, m := range match {
 		var labelMatches bool
 
+		selector, err := tunedMatchSelector(m.LabelSelector)
+		if err != nil {
+			// Invalid label selector, do not propagate this user error to the event loop, only log this
+			klog.Errorf("invalid label selector %s: %v", metav1.FormatLabelSelector(m.LabelSelector), err)
+			tunedMatchTrace(trace, depth, m, fmt.Sprintf("invalid label selector: %v", err))
+			continue
+		}
+
 		if m.Type != nil && *m.Type == "pod" { // note the (lower-)case from the API
-			labelMatches = pc.podLabelMatches(m.Label, m.Value, nodeName)
+			labelMatches = pc.podLabelMatches(m.Label, m.Value, selector, nodeName)
 		} else {
 			// Assume "node" type match; no types other than "node"/"pod" are allowed.
 			// Unspecified m.Type means "node" type match.
-			labelMatches = pc.nodeLabelMatches(m.Label, m.Value, nodeName)
+			labelMatches = pc.nodeLabelMatches(m.Label, m.Value, selector, nodeName)
 		}
-		if labelMatches {
-			// AND condition, check if subtree matches too
-			if pc.profileMatches(m.Match, nodeName) {
-				return true
-			}
+		if !labelMatches {
+			tunedMatchTrace(trace, depth, m, "no match")
+			continue
+		}
+		if len(m.Match) == 0 {
+			tunedMatchTrace(trace, depth, m, "matched")
+			return true
+		}
+		tunedMatchTrace(trace, depth, m, "matched, evaluating nested rules")
+		// AND condition, check if subtree matches too
+		if pc.profileMatchesTrace(m.Match, nodeName, depth+1, trace) {
+			return true
 		}
 	}
 
 	return false
 }
 
+// tunedMatchTrace appends a human-readable record of evaluating TunedMatch
+// rule 'm' with the 'result' to 'trace' if not nil.
+func tunedMatchTrace(trace *[]string, depth int, m tunedv1.TunedMatch, result string) {
+	if trace == nil {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("  ", depth))
+	if m.Type != nil && *m.Type == "pod" {
+		sb.WriteString("pod")
+	} else {
+		sb.WriteString("node")
+	}
+	if m.Label != nil {
+		sb.WriteString(" label ")
+		sb.WriteString(*m.Label)
+		if m.Value != nil {
+			sb.WriteString("=")
+			sb.WriteString(*m.Value)
+		}
+	}
+	if m.LabelSelector != nil {
+		sb.WriteString(" labelSelector ")
+		sb.WriteString(metav1.FormatLabelSelector(m.LabelSelector))
+	}
+	sb.WriteString(": ")
+	sb.WriteString(result)
+
+	*trace = append(*trace, sb.String())
+}
+
 // nodeLabelMatches returns true if Node label's 'mNodeLabel' value 'mNodeLabelValue'
 // matches any of the Node labels in the ProfileCalculator internal data structures
-// for Node of the name 'mNodeName'.
-func (pc *ProfileCalculator) nodeLabelMatches(mNodeLabel *string, mNodeLabelValue *string, mNodeName string) bool {
+// for Node of the name 'mNodeName'.  If 'mNodeSelector' is not nil, the Node labels
+// also need to satisfy the selector.
+func (pc *ProfileCalculator) nodeLabelMatches(mNodeLabel *string, mNodeLabelValue *string, mNodeSelector labels.Selector, mNodeName string) bool {
+	nodeLabels := pc.state.nodeLabels[mNodeName]
+
+	if mNodeSelector != nil && !mNodeSelector.Matches(labels.Set(nodeLabels)) {
+		return false
+	}
+
 	if mNodeLabel == nil {
 		// Undefined node label matches
 		return true
 	}
 
-	nodeLabels := pc.state.nodeLabels[mNodeName]
 	for nodeLabel, nodeLabelValue := range nodeLabels {
 		if nodeLabel == *mNodeLabel {
 			if mNodeLabelValue != nil {
@@ -496,9 +737,10 @@ func (pc *ProfileCalculator) nodeLabelMatches(mNodeLabel *string, mNodeLabelValu
 
 // podLabelMatches returns true if Pod label's 'mPodLabel' value 'mPodLabelValue'
 // matches any of the Pod labels in the ProfileCalculator internal data structures
-// for any Pod associated with Node of the name 'mNodeName'.
-func (pc *ProfileCalculator) podLabelMatches(mPodLabel *string, mPodLabelValue *string, mNodeName string) bool {
-	if mPodLabel == nil {
+// for any Pod associated with Node of the name 'mNodeName'.  If 'mPodSelector'
+// is not nil, the labels of the same Pod also need to satisfy the selector.
+func (pc *ProfileCalculator) podLabelMatches(mPodLabel *string, mPodLabelValue *string, mPodSelector labels.Selector, mNodeName string) bool {
+	if mPodLabel == nil && mPodSelector == nil {
 		// Undefined Pod label matches
 		return true
 	}
@@ -506,6 +748,13 @@ func (pc *ProfileCalculator) podLabelMatches(mPodLabel *string, mPodLabelValue *
 	podsPerNode := pc.state.podLabels[mNodeName]
 
 	for _, podLabels := range podsPerNode {
+		if mPodSelector != nil && !mPodSelector.Matches(labels.Set(podLabels)) {
+			// Pod label selector did not match, check the remaining pods on mNodeName
+			continue
+		}
+		if mPodLabel == nil {
+			return true
+		}
 		for podLabel, podLabelValue := range podLabels {
 			if podLabel == *mPodLabel {
 				if mPodLabelValue == nil || (podLabelValue == *mPodLabelValue) {
@@ -520,6 +769,16 @@ func (pc *ProfileCalculator) podLabelMatches(mPodLabel *string, mPodLabelValue *
 	return false
 }
 
+// tunedMatchSelector converts TunedMatch's label selector 'labelSelector' into
+// a labels.Selector.  Returns a nil selector if 'labelSelector' is nil.
+func tunedMatchSelector(labelSelector *metav1.LabelSelector) (labels.Selector, error) {
+	if labelSelector == nil {
+		return nil, nil
+	}
+
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
+
 // machineConfigLabelsMatch returns true if any of the MachineConfigPools 'pools' select 'machineConfigLabels' labels.
 func (pc *ProfileCalculator) machineConfigLabelsMatch(machineConfigLabels map[string]string, pools []*mcfgv1.MachineConfigPool) bool {
 	if machineConfigLabels == nil || pools == nil {
@@ -592,6 +851,9 @@ func (pc *ProfileCalculator) nodeRemove(nodeName string) {
 
 	// Delete all data structures related to nodeName in podLabels
 	delete(pc.state.podLabels, nodeName)
+
+	delete(pc.state.paused, nodeName)
+	delete(pc.state.profileOverride, nodeName)
 }
 
 // podRemove removes the reference of a Pod identified by namespace/name
@@ -769,9 +1031,28 @@ func tunedProfiles(tunedSlice []*tunedv1.Tuned) ([]tunedv1.TunedProfile, error)
 	return tunedProfiles, err
 }
 
+// tunedProfilesForNode returns the TuneD profiles out of 'profilesAll' the
+// recommended TuneD profile(s) 'recommended' depend on.  'providerName' is the
+// cloud provider name of the node the profile(s) are recommended for.  Sending a
+// node only the profiles it needs avoids updating the Profiles of all nodes on
+// any Tuned CR change.
+func tunedProfilesForNode(profilesAll []tunedv1.TunedProfile, recommended string, providerName string) []tunedv1.TunedProfile {
+	deps := tunedpkg.ProfileDependencies(profilesAll, recommended, providerName)
+
+	profiles := []tunedv1.TunedProfile{}
+	for _, profile := range profilesAll {
+		if profile.Name != nil && deps[*profile.Name] {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
 type TunedRecommendInfo struct {
 	tunedv1.TunedRecommend
-	Deferred util.DeferMode
+	Deferred          util.DeferMode
+	MaintenanceWindow *tunedv1.TunedMaintenanceWindow
+	TunedName         string
 }
 
 // TunedRecommend returns a priority-sorted TunedRecommend slice out of
@@ -788,10 +1069,17 @@ func TunedRecommend(tunedSlice []*tunedv1.Tuned) []TunedRecommendInfo {
 	})
 
 	for _, tuned := range tunedSlice {
+		deferred := tunedDeferredMode(tuned)
+		var window *tunedv1.TunedMaintenanceWindow
+		if deferred == util.DeferWindow {
+			window = tuned.Spec.MaintenanceWindow
+		}
 		for _, recommend := range tuned.Spec.Recommend {
 			recommendAll = append(recommendAll, TunedRecommendInfo{
-				TunedRecommend: recommend,
-				Deferred:       util.GetDeferredUpdateAnnotation(tuned.Annotations),
+				TunedRecommend:    recommend,
+				Deferred:          deferred,
+				MaintenanceWindow: window,
+				TunedName:         tuned.Name,
 			})
 		}
 	}
@@ -806,6 +1094,44 @@ func TunedRecommend(tunedSlice []*tunedv1.Tuned) []TunedRecommendInfo {
 	return recommendAll
 }
 
+// TunedRecommendStatic returns the TuneD profile of the highest-priority
+// non-additive rule among priority-sorted 'recommendAll' with the TuneD profiles
+// of all the additive rules stacked on top of it.  The match rules are not
+// evaluated, this is meant for rendering without a cluster.  Returns an empty
+// string if there is no non-additive rule.
+func TunedRecommendStatic(recommendAll []TunedRecommendInfo) string {
+	var base string
+	for _, recommend := range recommendAll {
+		if !recommend.Additive && recommend.Profile != nil {
+			base = *recommend.Profile
+			break
+		}
+	}
+	if len(base) == 0 {
+		return ""
+	}
+
+	additive, _ := additiveProfiles(recommendAll, base, func(int) (bool, error) {
+		return true, nil
+	}, nil)
+	return util.StackedTunedProfiles(base, additive)
+}
+
+// tunedDeferredMode returns the deferred update mode requested by Tuned CR 'tuned'.
+// The "window" mode needs a valid maintenance window, fall back to waiting for
+// the next node restart otherwise.
+func tunedDeferredMode(tuned *tunedv1.Tuned) util.DeferMode {
+	mode := util.GetDeferredUpdateAnnotation(tuned.Annotations)
+	if mode != util.DeferWindow {
+		return mode
+	}
+	if err := validateMaintenanceWindow(tuned.Spec.MaintenanceWindow); err != nil {
+		klog.Warningf("tuned/%s: %v; deferring updates until the next node restart", tuned.Name, err)
+		return util.DeferAlways
+	}
+	return mode
+}
+
 // podLabelsUnique goes through Pod labels of all the Pods on a Node-wide
 // 'podLabelsNodeWide' map and returns a subset of 'podLabels' unique to 'podNsName'
 // Pod; i.e. the retuned labels (key & value) will not exist on any other Pod
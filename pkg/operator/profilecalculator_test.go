@@ -5,13 +5,19 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntomf "github.com/openshift/cluster-node-tuning-operator/pkg/manifests"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 )
 
 func tunedProfileToString(tunedProfile tunedv1.TunedProfile) string {
@@ -135,3 +141,551 @@ func TestTunedProfiles(t *testing.T) {
 		}
 	}
 }
+
+func TestProfileMatches(t *testing.T) {
+	const nodeName = "node1"
+
+	pc := &ProfileCalculator{}
+	pc.state.nodeLabels = map[string]map[string]string{
+		nodeName: {
+			"node-role.kubernetes.io/worker": "",
+			"topology.kubernetes.io/zone":    "zone-b",
+		},
+	}
+	pc.state.podLabels = map[string]map[string]map[string]string{
+		nodeName: {
+			"ns/pod-a": {"app": "web", "tier": "frontend"},
+			"ns/pod-b": {"app": "db"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		match []tunedv1.TunedMatch
+		want  bool
+	}{
+		{
+			name: "node label present",
+			match: []tunedv1.TunedMatch{
+				{Label: ptr.To("node-role.kubernetes.io/worker")},
+			},
+			want: true,
+		},
+		{
+			name: "node selector In",
+			match: []tunedv1.TunedMatch{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   []string{"zone-a", "zone-b"},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node label present but selector DoesNotExist fails",
+			match: []tunedv1.TunedMatch{
+				{
+					Label: ptr.To("node-role.kubernetes.io/worker"),
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: metav1.LabelSelectorOpDoesNotExist,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "node selector NotIn",
+			match: []tunedv1.TunedMatch{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"node-role.kubernetes.io/worker": ""},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: metav1.LabelSelectorOpNotIn,
+								Values:   []string{"zone-a"},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "pod label and selector must match the same pod",
+			match: []tunedv1.TunedMatch{
+				{
+					Label: ptr.To("tier"),
+					Type:  ptr.To("pod"),
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "db"},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "pod selector Exists nested in node match",
+			match: []tunedv1.TunedMatch{
+				{
+					Label: ptr.To("node-role.kubernetes.io/worker"),
+					Match: []tunedv1.TunedMatch{
+						{
+							Type: ptr.To("pod"),
+							LabelSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      "tier",
+										Operator: metav1.LabelSelectorOpExists,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "invalid selector does not match",
+			match: []tunedv1.TunedMatch{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pc.profileMatches(tc.match, nodeName); got != tc.want {
+				t.Errorf("profileMatches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalculateProfilePriorityConflicts(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: tunedv1.TunedDefaultResourceName},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("openshift-node"), Priority: ptr.To(uint64(40))}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuned-a"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("profile-a"), Priority: ptr.To(uint64(20)), Match: []tunedv1.TunedMatch{{Label: ptr.To("node-role.kubernetes.io/worker")}}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuned-b"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("profile-b"), Priority: ptr.To(uint64(20)), Match: []tunedv1.TunedMatch{{Label: ptr.To("node-role.kubernetes.io/worker")}}}},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "master-0", Labels: map[string]string{"node-role.kubernetes.io/master": ""}}},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	computed, err := pc.CalculateProfile("worker-0")
+	if err != nil {
+		t.Fatalf("CalculateProfile() failed: %v", err)
+	}
+	want := []PriorityConflict{
+		{
+			TunedName:                "tuned-a",
+			TunedProfileName:         "profile-a",
+			ConflictTunedName:        "tuned-b",
+			ConflictTunedProfileName: "profile-b",
+			Priority:                 20,
+		},
+	}
+	if !reflect.DeepEqual(computed.PriorityConflicts, want) {
+		t.Errorf("PriorityConflicts = %+v, want %+v", computed.PriorityConflicts, want)
+	}
+
+	computed, err = pc.CalculateProfile("master-0")
+	if err != nil {
+		t.Fatalf("CalculateProfile() failed: %v", err)
+	}
+	if len(computed.PriorityConflicts) != 0 {
+		t.Errorf("PriorityConflicts = %+v, want none", computed.PriorityConflicts)
+	}
+}
+
+func TestCalculateProfileAdditive(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: tunedv1.TunedDefaultResourceName},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("openshift-node"), Priority: ptr.To(uint64(40))}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "network"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("network"), Priority: ptr.To(uint64(10)), Additive: true}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{
+					{Profile: ptr.To("storage"), Priority: ptr.To(uint64(30)), Additive: true, Match: []tunedv1.TunedMatch{{Label: ptr.To("storage")}}},
+					{Profile: ptr.To("storage"), Priority: ptr.To(uint64(5)), Additive: true, Match: []tunedv1.TunedMatch{{Label: ptr.To("storage-fast")}}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "power"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("power"), Priority: ptr.To(uint64(20)), Additive: true, Match: []tunedv1.TunedMatch{{Label: ptr.To("power")}}}},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"storage": "", "power": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"storage": "", "storage-fast": "", "power": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	tests := []struct {
+		nodeName string
+		additive []string
+	}{
+		{
+			nodeName: "worker-0",
+			additive: []string{"storage", "power", "network"},
+		},
+		{
+			// The highest-priority matching rule of a profile determines its position.
+			nodeName: "worker-1",
+			additive: []string{"power", "network", "storage"},
+		},
+		{
+			nodeName: "worker-2",
+			additive: []string{"network"},
+		},
+	}
+	for _, tc := range tests {
+		computed, err := pc.CalculateProfile(tc.nodeName)
+		if err != nil {
+			t.Fatalf("CalculateProfile(%s) failed: %v", tc.nodeName, err)
+		}
+		if computed.TunedProfileName != "openshift-node" || computed.TunedName != tunedv1.TunedDefaultResourceName {
+			t.Errorf("%s: selected profile %s (Tuned %s), want openshift-node (Tuned %s)", tc.nodeName, computed.TunedProfileName, computed.TunedName, tunedv1.TunedDefaultResourceName)
+		}
+		if !reflect.DeepEqual(computed.AdditiveProfileNames, tc.additive) {
+			t.Errorf("%s: AdditiveProfileNames = %v, want %v", tc.nodeName, computed.AdditiveProfileNames, tc.additive)
+		}
+		if len(computed.PriorityConflicts) != 0 {
+			t.Errorf("%s: PriorityConflicts = %+v, want none", tc.nodeName, computed.PriorityConflicts)
+		}
+
+		explanation, err := pc.ExplainProfile(tc.nodeName)
+		if err != nil {
+			t.Fatalf("ExplainProfile(%s) failed: %v", tc.nodeName, err)
+		}
+		if !reflect.DeepEqual(explanation.AdditiveProfiles, tc.additive) {
+			t.Errorf("%s: explained AdditiveProfiles = %v, want %v", tc.nodeName, explanation.AdditiveProfiles, tc.additive)
+		}
+	}
+
+	if static, want := TunedRecommendStatic(TunedRecommend(tuneds)), "openshift-node power network storage"; static != want {
+		t.Errorf("TunedRecommendStatic() = %q, want %q", static, want)
+	}
+}
+
+func TestCalculateProfileDependencies(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: tunedv1.TunedDefaultResourceName},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("openshift"), Data: ptr.To("[main]\nsummary=Base\n")},
+					{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\nsummary=Node\ninclude=openshift\n")},
+				},
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("openshift-node"), Priority: ptr.To(uint64(40))}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("custom"), Data: ptr.To("[main]\nsummary=Custom\ninclude=openshift-node\n")},
+				},
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("custom"), Priority: ptr.To(uint64(20)), Match: []tunedv1.TunedMatch{{Label: ptr.To("custom")}}}},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"custom": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	tests := []struct {
+		nodeName string
+		profiles []string
+	}{
+		{
+			nodeName: "worker-0",
+			profiles: []string{"custom", "openshift", "openshift-node"},
+		},
+		{
+			// Only the profiles the selected profile depends on.
+			nodeName: "worker-1",
+			profiles: []string{"openshift", "openshift-node"},
+		},
+	}
+	for _, tc := range tests {
+		computed, err := pc.CalculateProfile(tc.nodeName)
+		if err != nil {
+			t.Fatalf("CalculateProfile(%s) failed: %v", tc.nodeName, err)
+		}
+		var profiles []string
+		for _, profile := range computed.AllProfiles {
+			profiles = append(profiles, *profile.Name)
+		}
+		if !reflect.DeepEqual(profiles, tc.profiles) {
+			t.Errorf("%s: AllProfiles = %v, want %v", tc.nodeName, profiles, tc.profiles)
+		}
+	}
+}
+
+func TestCalculateProfileDependenciesDefault(t *testing.T) {
+	// The default "openshift" profile includes the cloud provider specific profile by a TuneD built-in function.
+	tuneds := []*tunedv1.Tuned{
+		ntomf.TunedCustomResource(),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "provider"},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("provider-aws"), Data: ptr.To("[main]\nsummary=AWS\n")},
+					{Name: ptr.To("provider-gce"), Data: ptr.To("[main]\nsummary=GCE\n")},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("custom"), Data: ptr.To("[main]\nsummary=Custom\ninclude=openshift\n")},
+				},
+				Recommend: []tunedv1.TunedRecommend{{Profile: ptr.To("custom"), Priority: ptr.To(uint64(20)), Match: []tunedv1.TunedMatch{{Label: ptr.To("custom")}}}},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{"custom": ""}},
+			Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123456789abcdef0"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"custom": ""}},
+			Spec:       corev1.NodeSpec{ProviderID: "gce://project/us-central1-a/worker-1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-2", Labels: map[string]string{"custom": ""}},
+		},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	tests := []struct {
+		nodeName string
+		profiles []string
+	}{
+		{
+			nodeName: "worker-0",
+			profiles: []string{"custom", "openshift", "provider-aws"},
+		},
+		{
+			nodeName: "worker-1",
+			profiles: []string{"custom", "openshift", "provider-gce"},
+		},
+		{
+			// No cloud provider.
+			nodeName: "worker-2",
+			profiles: []string{"custom", "openshift"},
+		},
+	}
+	for _, tc := range tests {
+		computed, err := pc.CalculateProfile(tc.nodeName)
+		if err != nil {
+			t.Fatalf("CalculateProfile(%s) failed: %v", tc.nodeName, err)
+		}
+		var profiles []string
+		for _, profile := range computed.AllProfiles {
+			profiles = append(profiles, *profile.Name)
+		}
+		if !reflect.DeepEqual(profiles, tc.profiles) {
+			t.Errorf("%s: AllProfiles = %v, want %v", tc.nodeName, profiles, tc.profiles)
+		}
+	}
+}
+
+func TestCalculateProfileOverride(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: tunedv1.TunedDefaultResourceName},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\nsummary=Node\n")},
+					{Name: ptr.To("debug"), Data: ptr.To("[main]\nsummary=Debug\n")},
+				},
+				Recommend: []tunedv1.TunedRecommend{
+					{Profile: ptr.To("openshift-node"), Priority: ptr.To(uint64(40)), Operand: tunedv1.OperandConfig{Verbosity: ptr.To(2)}},
+					{Profile: ptr.To("network"), Priority: ptr.To(uint64(20)), Additive: true},
+				},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Annotations: map[string]string{tunedv1.TunedProfileOverrideAnnotationKey: "debug"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Annotations: map[string]string{tunedv1.TunedPauseAnnotationKey: "true"}}},
+	}
+
+	pc, err := NewStaticProfileCalculator(tuneds, nodes, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create ProfileCalculator: %v", err)
+	}
+
+	computed, err := pc.CalculateProfile("worker-0")
+	if err != nil {
+		t.Fatalf("CalculateProfile(worker-0) failed: %v", err)
+	}
+	if computed.TunedProfileName != "debug" || computed.ProfileOverride != "debug" || computed.AdditiveProfileNames != nil {
+		t.Errorf("worker-0: unexpected override %q/%q, additive %v", computed.TunedProfileName, computed.ProfileOverride, computed.AdditiveProfileNames)
+	}
+	if len(computed.AllProfiles) != 1 || *computed.AllProfiles[0].Name != "debug" {
+		t.Errorf("worker-0: unexpected AllProfiles %v", computed.AllProfiles)
+	}
+	if ptr.Deref(computed.Operand.Verbosity, 0) != 2 {
+		t.Errorf("worker-0: the operand configuration of the recommended profile was not kept: %+v", computed.Operand)
+	}
+
+	// Pausing does not change the calculation.
+	computed, err = pc.CalculateProfile("worker-1")
+	if err != nil {
+		t.Fatalf("CalculateProfile(worker-1) failed: %v", err)
+	}
+	if computed.TunedProfileName != "openshift-node" || computed.ProfileOverride != "" || !pc.state.paused["worker-1"] {
+		t.Errorf("worker-1: unexpected profile %q, override %q, paused %v", computed.TunedProfileName, computed.ProfileOverride, pc.state.paused["worker-1"])
+	}
+
+	// Removing the annotations is a Node change.
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := nodeIndexer.Add(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	pc.listers.Nodes = kcorelisters.NewNodeLister(nodeIndexer)
+	change, err := pc.nodeChangeHandler("worker-1")
+	if err != nil {
+		t.Fatalf("nodeChangeHandler(worker-1) failed: %v", err)
+	}
+	if !change || pc.state.paused["worker-1"] {
+		t.Errorf("worker-1: want change and no pause, have change=%v paused=%v", change, pc.state.paused["worker-1"])
+	}
+}
+
+func TestTunedDeferredMode(t *testing.T) {
+	window := &tunedv1.TunedMaintenanceWindow{
+		Schedule: "0 2 * * *",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+
+	tests := []struct {
+		name     string
+		deferred string
+		window   *tunedv1.TunedMaintenanceWindow
+		expected util.DeferMode
+	}{
+		{
+			name:     "not deferred",
+			expected: util.DeferNever,
+		},
+		{
+			name:     "always",
+			deferred: "always",
+			window:   window,
+			expected: util.DeferAlways,
+		},
+		{
+			name:     "window",
+			deferred: "window",
+			window:   window,
+			expected: util.DeferWindow,
+		},
+		{
+			name:     "window undefined",
+			deferred: "window",
+			expected: util.DeferAlways,
+		},
+		{
+			name:     "window with invalid schedule",
+			deferred: "window",
+			window: &tunedv1.TunedMaintenanceWindow{
+				Schedule: "daily",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+			},
+			expected: util.DeferAlways,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tuned := &tunedv1.Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       tunedv1.TunedSpec{MaintenanceWindow: tc.window},
+			}
+			if tc.deferred != "" {
+				tuned.Annotations = map[string]string{tunedv1.TunedDeferredUpdate: tc.deferred}
+			}
+			if actual := tunedDeferredMode(tuned); actual != tc.expected {
+				t.Errorf("want: %q, have: %q", tc.expected, actual)
+			}
+		})
+	}
+}
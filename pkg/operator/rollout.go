@@ -0,0 +1,271 @@
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+const (
+	// How long to wait before re-checking whether a Profile update postponed
+	// due to a Tuned CR rolloutStrategy can proceed.
+	rolloutRequeuePeriod = 10 * time.Second
+	// Maximum number of degraded Profile names listed in the RolloutHalted condition.
+	rolloutMaxReportedProfiles = 10
+)
+
+// rolloutPending is a Profile update written by the operator as part of a rollout.
+type rolloutPending struct {
+	tunedName  string // Tuned CR whose rolloutStrategy governed the update
+	generation int64  // Profile generation after the update
+}
+
+// rolloutState is the internal operator's cache of progressive Profile rollouts.
+type rolloutState struct {
+	selected map[string]string
+	// Profile name: ^^^^^^
+	// Tuned CR name selecting the Profile: ^^^^^^
+	pending map[string]rolloutPending
+	// Profile name: ^^^^^^
+}
+
+// rolloutProgress summarizes the progress of a rollout for a single Tuned CR.
+type rolloutProgress struct {
+	selected   int             // number of Profiles selected by the Tuned CR
+	inProgress map[string]bool // Profiles updated but not yet applied
+	degraded   []string        // Profiles updated during the rollout and reported as Degraded
+}
+
+func newRolloutState() rolloutState {
+	return rolloutState{
+		selected: map[string]string{},
+		pending:  map[string]rolloutPending{},
+	}
+}
+
+// profileRemove removes all references to Profile 'profileName' from the rollout state.
+func (r *rolloutState) profileRemove(profileName string) {
+	delete(r.selected, profileName)
+	delete(r.pending, profileName)
+}
+
+// progress computes the rollout progress for Tuned CR 'tunedName' based on
+// the Profiles returned by 'getProfile'.  Profile updates which have been
+// applied without errors are no longer tracked as pending.
+func (r *rolloutState) progress(tunedName string, getProfile func(string) (*tunedv1.Profile, error)) rolloutProgress {
+	rp := rolloutProgress{
+		inProgress: map[string]bool{},
+	}
+
+	for profileName, name := range r.selected {
+		if name != tunedName {
+			continue
+		}
+		rp.selected++
+
+		if _, ok := r.pending[profileName]; ok {
+			// Evaluated below.
+			continue
+		}
+
+		profile, err := getProfile(profileName)
+		if err != nil {
+			continue
+		}
+		if !profileObserved(profile) {
+			// Possibly updated by a previous operator instance.
+			rp.inProgress[profileName] = true
+		}
+	}
+
+	for profileName, pending := range r.pending {
+		if pending.tunedName != tunedName {
+			continue
+		}
+
+		profile, err := getProfile(profileName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				delete(r.pending, profileName)
+			}
+			continue
+		}
+
+		if profile.Generation < pending.generation || !profileObserved(profile) {
+			// The operand did not report the status for the updated Profile yet.
+			rp.inProgress[profileName] = true
+			continue
+		}
+
+		if profileDegraded(profile) {
+			rp.degraded = append(rp.degraded, profileName)
+			continue
+		}
+
+		if profileApplied(profile) {
+			delete(r.pending, profileName)
+			continue
+		}
+
+		rp.inProgress[profileName] = true
+	}
+	sort.Strings(rp.degraded)
+
+	return rp
+}
+
+// profileObserved returns true if the operand reported status for the
+// current generation of Profile 'profile'.
+func profileObserved(profile *tunedv1.Profile) bool {
+	return profile.Status.ObservedGeneration >= profile.Generation
+}
+
+// rolloutMaxUnavailable returns the maximum number of Profiles which can be updated
+// and not yet applied at the same time based on 'strategy' and the total number
+// of 'selected' Profiles.
+func rolloutMaxUnavailable(strategy *tunedv1.TunedRolloutStrategy, selected int) int {
+	if strategy == nil || strategy.MaxUnavailable == nil {
+		return 1
+	}
+
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, selected, true)
+	if err != nil {
+		klog.Errorf("invalid rolloutStrategy maxUnavailable %q: %v", strategy.MaxUnavailable.String(), err)
+		return 1
+	}
+	if maxUnavailable < 1 {
+		return 1
+	}
+
+	return maxUnavailable
+}
+
+// rolloutPauseOnDegraded returns true if the rollout should be halted on degraded Profiles.
+func rolloutPauseOnDegraded(strategy *tunedv1.TunedRolloutStrategy) bool {
+	return strategy == nil || strategy.PauseOnDegraded == nil || *strategy.PauseOnDegraded
+}
+
+// rolloutAllowed returns true if Profile 'profile' can be updated with the
+// 'computed' TuneD profile based on the rolloutStrategy of the Tuned CR which
+// selected the Profile.
+func (c *Controller) rolloutAllowed(computed ComputedProfile, profile *tunedv1.Profile) bool {
+	if util.IsDeferredUpdate(computed.Deferred) {
+		// Deferred updates wait for a node restart or the next update, do not gate them.
+		return true
+	}
+
+	tuned, err := c.listers.TunedResources.Get(computed.TunedName)
+	if err != nil || tuned.Spec.RolloutStrategy == nil {
+		return true
+	}
+
+	if _, ok := c.rollout.pending[profile.Name]; ok {
+		// The Profile is already part of the rollout, e.g. a degraded Profile receiving a fix.
+		return true
+	}
+
+	rp := c.rollout.progress(tuned.Name, c.listers.TunedProfiles.Get)
+	if len(rp.degraded) > 0 && rolloutPauseOnDegraded(tuned.Spec.RolloutStrategy) {
+		klog.V(2).Infof("rollout of Tuned %s halted due to degraded Profile(s) %v", tuned.Name, rp.degraded)
+		return false
+	}
+
+	maxUnavailable := rolloutMaxUnavailable(tuned.Spec.RolloutStrategy, rp.selected)
+	if len(rp.inProgress)+len(rp.degraded) >= maxUnavailable {
+		klog.V(2).Infof("rollout of Tuned %s: %d Profile(s) unavailable, maxUnavailable=%d", tuned.Name, len(rp.inProgress)+len(rp.degraded), maxUnavailable)
+		return false
+	}
+
+	return true
+}
+
+// rolloutUsed returns true if Tuned CR 'tunedName' uses a rolloutStrategy.
+func (c *Controller) rolloutUsed(tunedName string) bool {
+	tuned, err := c.listers.TunedResources.Get(tunedName)
+	return err == nil && tuned.Spec.RolloutStrategy != nil
+}
+
+// rolloutTrack starts tracking the update of Profile 'profile' for Tuned CR 'tunedName'
+// if the Tuned CR uses a rolloutStrategy.
+func (c *Controller) rolloutTrack(tunedName string, profile *tunedv1.Profile) {
+	if !c.rolloutUsed(tunedName) {
+		return
+	}
+
+	c.rollout.pending[profile.Name] = rolloutPending{
+		tunedName:  tunedName,
+		generation: profile.Generation,
+	}
+}
+
+// updateRolloutAnnotation returns a copy of Profile annotations 'anns' with the
+// rollout annotation set to Tuned CR 'tunedName' if the Tuned CR uses a
+// rolloutStrategy and removed otherwise.
+func (c *Controller) updateRolloutAnnotation(anns map[string]string, tunedName string) map[string]string {
+	ret := map[string]string{}
+	for k, v := range anns {
+		ret[k] = v
+	}
+	delete(ret, tunedv1.TunedRolloutAnnotationKey)
+	if c.rolloutUsed(tunedName) {
+		ret[tunedv1.TunedRolloutAnnotationKey] = tunedName
+	}
+	return ret
+}
+
+// restore reconstructs the rollout state from the rollout annotations of
+// Profiles 'profileList' after the operator (re)starts.  The last update of an
+// annotated Profile is tracked until the Profile is applied, a degraded Profile
+// keeps halting the rollout of its Tuned CR as it did before the restart.
+func (r *rolloutState) restore(profileList []*tunedv1.Profile) {
+	for _, profile := range profileList {
+		tunedName, ok := profile.Annotations[tunedv1.TunedRolloutAnnotationKey]
+		if !ok {
+			continue
+		}
+		if _, ok := r.selected[profile.Name]; !ok {
+			r.selected[profile.Name] = tunedName
+		}
+		r.pending[profile.Name] = rolloutPending{
+			tunedName:  tunedName,
+			generation: profile.Generation,
+		}
+	}
+}
+
+// computeRolloutCondition returns the RolloutHalted condition based on the rollout progress 'rp'.
+func computeRolloutCondition(halted bool, rp rolloutProgress) *tunedv1.StatusCondition {
+	condition := &tunedv1.StatusCondition{
+		Type: tunedv1.TunedRolloutHalted,
+	}
+
+	if halted {
+		degraded := rp.degraded
+		if len(degraded) > rolloutMaxReportedProfiles {
+			degraded = append(degraded[:rolloutMaxReportedProfiles:rolloutMaxReportedProfiles], "...")
+		}
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "ProfileDegraded"
+		condition.Message = fmt.Sprintf("Rollout halted, %d/%d Profiles degraded: %s", len(rp.degraded), rp.selected, strings.Join(degraded, ", "))
+		return condition
+	}
+
+	condition.Status = corev1.ConditionFalse
+	if len(rp.inProgress) > 0 {
+		condition.Reason = "RollingOut"
+		condition.Message = fmt.Sprintf("Waiting for %d/%d Profiles to be applied", len(rp.inProgress), rp.selected)
+	} else {
+		condition.Reason = "AsExpected"
+	}
+
+	return condition
+}
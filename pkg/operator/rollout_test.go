@@ -0,0 +1,180 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func newRolloutTestProfile(name string, generation, observedGeneration int64, applied, degraded corev1.ConditionStatus) *tunedv1.Profile {
+	return &tunedv1.Profile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: generation,
+		},
+		Spec: tunedv1.ProfileSpec{
+			Config: tunedv1.ProfileConfig{
+				TunedProfile: "openshift-node",
+			},
+		},
+		Status: tunedv1.ProfileStatus{
+			TunedProfile:       "openshift-node",
+			ObservedGeneration: observedGeneration,
+			Conditions: []tunedv1.StatusCondition{
+				{Type: tunedv1.TunedProfileApplied, Status: applied},
+				{Type: tunedv1.TunedDegraded, Status: degraded},
+			},
+		},
+	}
+}
+
+func TestRolloutMaxUnavailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy *tunedv1.TunedRolloutStrategy
+		selected int
+		want     int
+	}{
+		{
+			name:     "default",
+			strategy: &tunedv1.TunedRolloutStrategy{},
+			selected: 10,
+			want:     1,
+		},
+		{
+			name:     "absolute",
+			strategy: &tunedv1.TunedRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(3))},
+			selected: 10,
+			want:     3,
+		},
+		{
+			name:     "percentage rounds up",
+			strategy: &tunedv1.TunedRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromString("25%"))},
+			selected: 10,
+			want:     3,
+		},
+		{
+			name:     "zero is at least one",
+			strategy: &tunedv1.TunedRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+			selected: 10,
+			want:     1,
+		},
+		{
+			name:     "invalid percentage",
+			strategy: &tunedv1.TunedRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromString("x%"))},
+			selected: 10,
+			want:     1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rolloutMaxUnavailable(tc.strategy, tc.selected); got != tc.want {
+				t.Errorf("rolloutMaxUnavailable() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRolloutProgress(t *testing.T) {
+	profiles := map[string]*tunedv1.Profile{
+		"applied":     newRolloutTestProfile("applied", 2, 2, corev1.ConditionTrue, corev1.ConditionFalse),
+		"degraded":    newRolloutTestProfile("degraded", 2, 2, corev1.ConditionFalse, corev1.ConditionTrue),
+		"unobserved":  newRolloutTestProfile("unobserved", 3, 2, corev1.ConditionTrue, corev1.ConditionFalse),
+		"stale-cache": newRolloutTestProfile("stale-cache", 1, 1, corev1.ConditionTrue, corev1.ConditionFalse),
+		"untouched":   newRolloutTestProfile("untouched", 1, 1, corev1.ConditionTrue, corev1.ConditionFalse),
+		"other":       newRolloutTestProfile("other", 5, 4, corev1.ConditionTrue, corev1.ConditionFalse),
+	}
+	getProfile := func(name string) (*tunedv1.Profile, error) {
+		if p, ok := profiles[name]; ok {
+			return p, nil
+		}
+		return nil, errors.NewNotFound(tunedv1.Resource("profile"), name)
+	}
+
+	r := newRolloutState()
+	for name := range profiles {
+		r.selected[name] = "tuned-a"
+	}
+	r.selected["other"] = "tuned-b"
+	r.pending["applied"] = rolloutPending{tunedName: "tuned-a", generation: 2}
+	r.pending["degraded"] = rolloutPending{tunedName: "tuned-a", generation: 2}
+	r.pending["stale-cache"] = rolloutPending{tunedName: "tuned-a", generation: 2}
+	r.pending["removed"] = rolloutPending{tunedName: "tuned-a", generation: 1}
+
+	rp := r.progress("tuned-a", getProfile)
+
+	if rp.selected != 5 {
+		t.Errorf("selected = %d, want 5", rp.selected)
+	}
+	wantInProgress := map[string]bool{"unobserved": true, "stale-cache": true}
+	if !reflect.DeepEqual(rp.inProgress, wantInProgress) {
+		t.Errorf("inProgress = %v, want %v", rp.inProgress, wantInProgress)
+	}
+	if !reflect.DeepEqual(rp.degraded, []string{"degraded"}) {
+		t.Errorf("degraded = %v, want [degraded]", rp.degraded)
+	}
+	if _, ok := r.pending["applied"]; ok {
+		t.Errorf("applied Profile still tracked as pending")
+	}
+	if _, ok := r.pending["removed"]; ok {
+		t.Errorf("removed Profile still tracked as pending")
+	}
+	if _, ok := r.pending["degraded"]; !ok {
+		t.Errorf("degraded Profile no longer tracked as pending")
+	}
+
+	condition := computeRolloutCondition(true, rp)
+	if condition.Status != corev1.ConditionTrue || condition.Reason != "ProfileDegraded" {
+		t.Errorf("unexpected RolloutHalted condition: %+v", condition)
+	}
+}
+
+func TestRolloutRestore(t *testing.T) {
+	profiles := map[string]*tunedv1.Profile{
+		"applied":    newRolloutTestProfile("applied", 2, 2, corev1.ConditionTrue, corev1.ConditionFalse),
+		"degraded":   newRolloutTestProfile("degraded", 2, 2, corev1.ConditionFalse, corev1.ConditionTrue),
+		"unobserved": newRolloutTestProfile("unobserved", 3, 2, corev1.ConditionTrue, corev1.ConditionFalse),
+		"other":      newRolloutTestProfile("other", 1, 1, corev1.ConditionTrue, corev1.ConditionFalse),
+	}
+	for name, profile := range profiles {
+		if name != "other" {
+			profile.Annotations = map[string]string{tunedv1.TunedRolloutAnnotationKey: "tuned-a"}
+		}
+	}
+	getProfile := func(name string) (*tunedv1.Profile, error) {
+		if p, ok := profiles[name]; ok {
+			return p, nil
+		}
+		return nil, errors.NewNotFound(tunedv1.Resource("profile"), name)
+	}
+
+	r := newRolloutState()
+	r.restore([]*tunedv1.Profile{profiles["applied"], profiles["degraded"], profiles["unobserved"], profiles["other"]})
+
+	if _, ok := r.selected["other"]; ok {
+		t.Errorf("Profile without the rollout annotation restored")
+	}
+
+	rp := r.progress("tuned-a", getProfile)
+
+	if rp.selected != 3 {
+		t.Errorf("selected = %d, want 3", rp.selected)
+	}
+	if wantInProgress := map[string]bool{"unobserved": true}; !reflect.DeepEqual(rp.inProgress, wantInProgress) {
+		t.Errorf("inProgress = %v, want %v", rp.inProgress, wantInProgress)
+	}
+	if !reflect.DeepEqual(rp.degraded, []string{"degraded"}) {
+		t.Errorf("degraded = %v, want [degraded]", rp.degraded)
+	}
+	if _, ok := r.pending["applied"]; ok {
+		t.Errorf("applied Profile still tracked as pending")
+	}
+}
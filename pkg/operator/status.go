@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -20,11 +23,18 @@ import (
 	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	ntomf "github.com/openshift/cluster-node-tuning-operator/pkg/manifests"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
-	"github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
+	tunedpkg "github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 )
 
 const (
 	errGenerationMismatch = "generation mismatch"
+	// Maximum number of failing Node names listed per TuneD profile in Tuned CR status.
+	tunedStatusMaxFailingNodes = 10
+	// Maximum number of Node names listed per conflict in the PriorityConflict condition.
+	tunedStatusMaxConflictNodes = 10
+	// Maximum number of paused or overridden Node names listed in ClusterOperator status.
+	tunedStatusMaxOverrideNodes = 10
 )
 
 // syncOperatorStatus computes the operator's current status and therefrom
@@ -99,7 +109,7 @@ func (c *Controller) getOrCreateOperatorStatus() (*configv1.ClusterOperator, err
 
 // profileApplied returns true if Tuned Profile 'profile' has been applied.
 func profileApplied(profile *tunedv1.Profile) bool {
-	if profile == nil || profile.Spec.Config.TunedProfile != profile.Status.TunedProfile {
+	if profile == nil || util.StackedTunedProfiles(profile.Spec.Config.TunedProfile, profile.Spec.Config.AdditiveTunedProfiles) != profile.Status.TunedProfile {
 		return false
 	}
 
@@ -129,6 +139,117 @@ func profileDegraded(profile *tunedv1.Profile) bool {
 	return false
 }
 
+// profileDeferred returns true if Profile 'profile' waits for the next node
+// restart to be applied.
+func profileDeferred(profile *tunedv1.Profile) bool {
+	if profile == nil {
+		return false
+	}
+
+	for _, sc := range profile.Status.Conditions {
+		if sc.Type == tunedv1.TunedProfileApplied && sc.Status == corev1.ConditionFalse && sc.Reason == "Deferred" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// profileConditionTrue returns true if Profile 'profile' has condition 'conditionType' set to true.
+func profileConditionTrue(profile *tunedv1.Profile, conditionType tunedv1.ConditionType) bool {
+	for _, sc := range profile.Status.Conditions {
+		if sc.Type == conditionType && sc.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// profileOverrideConditions returns 'conditions' with the Paused and
+// ProfileOverridden conditions set based on the Node annotations: 'paused'
+// and the TuneD profile 'profileOverride'.  The conditions are only reported
+// when the annotations are set.
+func profileOverrideConditions(conditions []tunedv1.StatusCondition, paused bool, profileOverride string) []tunedv1.StatusCondition {
+	if paused {
+		conditions = tunedpkg.SetStatusCondition(conditions, &tunedv1.StatusCondition{
+			Type:    tunedv1.TunedPaused,
+			Status:  corev1.ConditionTrue,
+			Reason:  "NodeAnnotation",
+			Message: fmt.Sprintf("Profile updates paused by Node annotation %s.", tunedv1.TunedPauseAnnotationKey),
+		})
+	} else {
+		conditions = tunedpkg.RemoveStatusCondition(conditions, tunedv1.TunedPaused)
+	}
+
+	if profileOverride != "" {
+		conditions = tunedpkg.SetStatusCondition(conditions, &tunedv1.StatusCondition{
+			Type:    tunedv1.TunedProfileOverridden,
+			Status:  corev1.ConditionTrue,
+			Reason:  "NodeAnnotation",
+			Message: fmt.Sprintf("TuneD profile %s forced by Node annotation %s.", profileOverride, tunedv1.TunedProfileOverrideAnnotationKey),
+		})
+	} else {
+		conditions = tunedpkg.RemoveStatusCondition(conditions, tunedv1.TunedProfileOverridden)
+	}
+
+	return conditions
+}
+
+// syncProfileOverrideConditions updates the Paused and ProfileOverridden
+// conditions of Profile 'profile' and returns the updated Profile.
+func (c *Controller) syncProfileOverrideConditions(profile *tunedv1.Profile, paused bool, profileOverride string) (*tunedv1.Profile, error) {
+	conditions := profileOverrideConditions(profile.Status.Conditions, paused, profileOverride)
+	if tunedpkg.ConditionsEqual(profile.Status.Conditions, conditions) {
+		return profile, nil
+	}
+
+	profile = profile.DeepCopy() // never update the objects from cache
+	profile.Status.Conditions = conditions
+
+	klog.V(2).Infof("syncProfileOverrideConditions(): updating Profile %s status (paused=%v, override=%q)", profile.Name, paused, profileOverride)
+	profileUpdated, err := c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).UpdateStatus(context.TODO(), profile, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Profile %s status: %v", profile.Name, err)
+	}
+
+	return profileUpdated, nil
+}
+
+// profilesOverriddenMessage returns a message listing the Nodes with paused
+// Profile updates or an overridden TuneD profile in 'profileList' or an empty
+// string if there are none.
+func profilesOverriddenMessage(profileList []*tunedv1.Profile) string {
+	var (
+		messages           []string
+		paused, overridden []string
+	)
+	for _, profile := range profileList {
+		if profileConditionTrue(profile, tunedv1.TunedPaused) {
+			paused = append(paused, profile.Name)
+		}
+		if profileConditionTrue(profile, tunedv1.TunedProfileOverridden) {
+			overridden = append(overridden, profile.Name)
+		}
+	}
+
+	nodes := func(nodeNames []string) string {
+		sort.Strings(nodeNames)
+		if len(nodeNames) > tunedStatusMaxOverrideNodes {
+			return strings.Join(nodeNames[:tunedStatusMaxOverrideNodes], ", ") + ", ..."
+		}
+		return strings.Join(nodeNames, ", ")
+	}
+	if len(paused) > 0 {
+		messages = append(messages, fmt.Sprintf("Profile updates paused on %d Node(s): %s", len(paused), nodes(paused)))
+	}
+	if len(overridden) > 0 {
+		messages = append(messages, fmt.Sprintf("TuneD profile overridden on %d Node(s): %s", len(overridden), nodes(overridden)))
+	}
+
+	return strings.Join(messages, "; ")
+}
+
 // numProfilesProgressingDegraded returns two ints which count
 // the number of Profiles in the slice 'profileList' which are
 // waiting to be applied and in a degraded state, respectively.
@@ -310,6 +431,15 @@ func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.C
 			availableCondition.Message = fmt.Sprintf("%v/%v Profiles failed to be applied", numDegradedProfiles, len(profileList))
 		}
 
+		if msg := profilesOverriddenMessage(profileList); msg != "" {
+			// Make sure the Node annotation overrides are not forgotten.
+			klog.V(2).Info(msg)
+			if availableCondition.Message != "" {
+				availableCondition.Message += ". "
+			}
+			availableCondition.Message += msg
+		}
+
 		numConflict := c.numProfilesWithBootcmdlineConflict(profileList)
 		if numConflict > 0 {
 			klog.Infof("%v/%v Profiles with bootcmdline conflict", numConflict, len(profileList))
@@ -417,7 +547,232 @@ func computeStatusConditions(tunedValid bool, message string, conditions []tuned
 	}
 	tunedValidCondition.Message = message
 
-	conditions = tuned.SetStatusCondition(conditions, &tunedValidCondition)
+	conditions = tunedpkg.SetStatusCondition(conditions, &tunedValidCondition)
 
 	return conditions
 }
+
+// enqueueTunedStatuses enqueues the status updates of Tuned CRs 'tunedNames'.
+// The updates are batched by the workqueue, which holds a single key per Tuned
+// CR no matter how many Profile changes requested its status update.
+func (c *Controller) enqueueTunedStatuses(tunedNames map[string]bool) {
+	for tunedName := range tunedNames {
+		if len(tunedName) == 0 {
+			// No Tuned CR selected the Profile.
+			continue
+		}
+		c.workqueue.Add(wqKey{kind: wqKindTunedStatus, namespace: ntoconfig.WatchNamespace(), name: tunedName})
+	}
+}
+
+// syncTunedStatus updates the status of Tuned CR 'tunedName' based on the
+// Profiles of the Nodes selected by the Tuned CR.  This includes the summary
+// of the Profiles per TuneD profile, the PriorityConflict condition and the
+// RolloutHalted condition, which is removed when the Tuned CR does not use
+// a rolloutStrategy.
+func (c *Controller) syncTunedStatus(tunedName string) error {
+	tunedCR, err := c.listers.TunedResources.Get(tunedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Tuned %s: %v", tunedName, err)
+	}
+
+	conditions := []tunedv1.StatusCondition{}
+	for _, condition := range tunedCR.Status.Conditions {
+		if condition.Type != tunedv1.TunedRolloutHalted {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if tunedCR.Spec.RolloutStrategy != nil {
+		rp := c.rollout.progress(tunedCR.Name, c.listers.TunedProfiles.Get)
+		halted := len(rp.degraded) > 0 && rolloutPauseOnDegraded(tunedCR.Spec.RolloutStrategy)
+		conditions = tunedpkg.SetStatusCondition(conditions, computeRolloutCondition(halted, rp))
+	}
+	conditions = tunedpkg.SetStatusCondition(conditions, computePriorityConflictCondition(tunedCR.Name, c.priorityConflicts))
+
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list Profiles: %v", err)
+	}
+	profileStatuses := computeTunedProfileStatuses(tunedProfileUses(tunedCR, c.rollout.selected, profileList))
+
+	if tunedpkg.ConditionsEqual(tunedCR.Status.Conditions, conditions) &&
+		reflect.DeepEqual(tunedCR.Status.Profiles, profileStatuses) {
+		return nil
+	}
+
+	tunedCR = tunedCR.DeepCopy() // never update the objects from cache
+	tunedCR.Status.Conditions = conditions
+	tunedCR.Status.Profiles = profileStatuses
+
+	_, err = c.clients.Tuned.TunedV1().Tuneds(ntoconfig.WatchNamespace()).UpdateStatus(context.TODO(), tunedCR, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update Tuned %s status: %v", tunedCR.Name, err)
+	}
+
+	return nil
+}
+
+// additiveTunedNames adds the Tuned CRs recommending any of the additive TuneD
+// profiles 'profileNames' to 'tunedNames'.
+func (c *Controller) additiveTunedNames(tunedNames map[string]bool, profileNames []string) {
+	if len(profileNames) == 0 {
+		return
+	}
+	tunedList, err := c.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list Tuneds: %v", err)
+		return
+	}
+	for _, tuned := range tunedList {
+		additive := tunedAdditiveProfiles(tuned)
+		for _, name := range profileNames {
+			if additive[name] {
+				tunedNames[tuned.Name] = true
+				break
+			}
+		}
+	}
+}
+
+// tunedAdditiveProfiles returns the additive TuneD profiles recommended by Tuned CR 'tuned'.
+func tunedAdditiveProfiles(tuned *tunedv1.Tuned) map[string]bool {
+	additive := map[string]bool{}
+	for _, recommend := range tuned.Spec.Recommend {
+		if recommend.Additive && recommend.Profile != nil {
+			additive[*recommend.Profile] = true
+		}
+	}
+	return additive
+}
+
+// tunedProfileUses returns the Profiles among 'profileList' using the TuneD
+// profiles of Tuned CR 'tuned' keyed by the TuneD profile name.  These are the
+// Profiles for which 'selected' lists 'tuned' and the Profiles stacking any of
+// the additive TuneD profiles recommended by 'tuned'.
+func tunedProfileUses(tuned *tunedv1.Tuned, selected map[string]string, profileList []*tunedv1.Profile) map[string][]*tunedv1.Profile {
+	additive := tunedAdditiveProfiles(tuned)
+	uses := map[string][]*tunedv1.Profile{}
+	for _, profile := range profileList {
+		if selected[profile.Name] == tuned.Name {
+			uses[profile.Spec.Config.TunedProfile] = append(uses[profile.Spec.Config.TunedProfile], profile)
+		}
+		for _, name := range profile.Spec.Config.AdditiveTunedProfiles {
+			if additive[name] {
+				uses[name] = append(uses[name], profile)
+			}
+		}
+	}
+	return uses
+}
+
+// computeTunedProfileStatuses returns a summary of the Profiles using each
+// TuneD profile in 'uses' sorted by the TuneD profile name.
+func computeTunedProfileStatuses(uses map[string][]*tunedv1.Profile) []tunedv1.TunedProfileStatus {
+	statuses := map[string]*tunedv1.TunedProfileStatus{}
+
+	for name, profileList := range uses {
+		status := &tunedv1.TunedProfileStatus{Name: name}
+		statuses[name] = status
+
+		for _, profile := range profileList {
+			status.Nodes++
+			switch {
+			case profileDeferred(profile):
+				status.Deferred++
+			case profileDegraded(profile):
+				status.Degraded++
+				status.FailingNodes = append(status.FailingNodes, profile.Name)
+			case profileApplied(profile):
+				status.Applied++
+			}
+		}
+	}
+
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	profileStatuses := []tunedv1.TunedProfileStatus{}
+	for _, status := range statuses {
+		sort.Strings(status.FailingNodes)
+		if len(status.FailingNodes) > tunedStatusMaxFailingNodes {
+			status.FailingNodes = status.FailingNodes[:tunedStatusMaxFailingNodes]
+		}
+		profileStatuses = append(profileStatuses, *status)
+	}
+	sort.Slice(profileStatuses, func(i, j int) bool {
+		return profileStatuses[i].Name < profileStatuses[j].Name
+	})
+
+	return profileStatuses
+}
+
+// priorityConflictsSet records 'conflicts' for Node 'nodeName' in the internal
+// operator's cache and updates the related metric.
+func (c *Controller) priorityConflictsSet(nodeName string, conflicts []PriorityConflict) {
+	if len(conflicts) == 0 {
+		delete(c.priorityConflicts, nodeName)
+	} else {
+		c.priorityConflicts[nodeName] = conflicts
+	}
+	metrics.PriorityConflictExist(len(c.priorityConflicts) > 0)
+}
+
+// priorityConflictsTunedNames returns the names of Tuned CRs involved in
+// priority conflicts on Node 'nodeName'.
+func (c *Controller) priorityConflictsTunedNames(nodeName string) map[string]bool {
+	tunedNames := map[string]bool{}
+	for _, conflict := range c.priorityConflicts[nodeName] {
+		tunedNames[conflict.TunedName] = true
+		tunedNames[conflict.ConflictTunedName] = true
+	}
+
+	return tunedNames
+}
+
+// computePriorityConflictCondition returns the PriorityConflict condition for
+// Tuned CR 'tunedName' based on the 'priorityConflicts' per Node.
+func computePriorityConflictCondition(tunedName string, priorityConflicts map[string][]PriorityConflict) *tunedv1.StatusCondition {
+	condition := &tunedv1.StatusCondition{
+		Type: tunedv1.TunedPriorityConflict,
+	}
+
+	conflictNodes := map[PriorityConflict][]string{}
+	for nodeName, conflicts := range priorityConflicts {
+		for _, conflict := range conflicts {
+			if conflict.TunedName != tunedName && conflict.ConflictTunedName != tunedName {
+				continue
+			}
+			conflictNodes[conflict] = append(conflictNodes[conflict], nodeName)
+		}
+	}
+
+	if len(conflictNodes) == 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "AsExpected"
+		return condition
+	}
+
+	messages := []string{}
+	for conflict, nodeNames := range conflictNodes {
+		sort.Strings(nodeNames)
+		nodes := strings.Join(nodeNames, ", ")
+		if len(nodeNames) > tunedStatusMaxConflictNodes {
+			nodes = strings.Join(nodeNames[:tunedStatusMaxConflictNodes], ", ") + ", ..."
+		}
+		messages = append(messages, fmt.Sprintf("profile %s (Tuned %s) and profile %s (Tuned %s) with priority %d match Node(s) %s",
+			conflict.TunedProfileName, conflict.TunedName, conflict.ConflictTunedProfileName, conflict.ConflictTunedName, conflict.Priority, nodes))
+	}
+	sort.Strings(messages)
+
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = "EqualPriority"
+	condition.Message = "Different TuneD profiles with the same priority match the same Node(s): " + strings.Join(messages, "; ") +
+		". Please use a different priority for your custom profiles."
+
+	return condition
+}
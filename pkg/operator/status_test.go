@@ -0,0 +1,186 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func newStatusTestProfile(name, tunedProfile string, applied, degraded tunedv1.StatusCondition) *tunedv1.Profile {
+	applied.Type = tunedv1.TunedProfileApplied
+	degraded.Type = tunedv1.TunedDegraded
+
+	return &tunedv1.Profile{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: tunedv1.ProfileSpec{
+			Config: tunedv1.ProfileConfig{TunedProfile: tunedProfile},
+		},
+		Status: tunedv1.ProfileStatus{
+			TunedProfile: tunedProfile,
+			Conditions:   []tunedv1.StatusCondition{applied, degraded},
+		},
+	}
+}
+
+func TestComputeTunedProfileStatuses(t *testing.T) {
+	var (
+		appliedTrue     = tunedv1.StatusCondition{Status: corev1.ConditionTrue, Reason: "AsExpected"}
+		appliedFalse    = tunedv1.StatusCondition{Status: corev1.ConditionFalse, Reason: "Failed"}
+		appliedDeferred = tunedv1.StatusCondition{Status: corev1.ConditionFalse, Reason: "Deferred"}
+		appliedUnknown  = tunedv1.StatusCondition{Status: corev1.ConditionUnknown}
+		degradedFalse   = tunedv1.StatusCondition{Status: corev1.ConditionFalse, Reason: "AsExpected"}
+		degradedTrue    = tunedv1.StatusCondition{Status: corev1.ConditionTrue, Reason: "TunedError"}
+		degradedDefer   = tunedv1.StatusCondition{Status: corev1.ConditionTrue, Reason: "TunedDeferredUpdate"}
+	)
+
+	profileList := []*tunedv1.Profile{
+		newStatusTestProfile("node-a", "profile-x", appliedTrue, degradedFalse),
+		newStatusTestProfile("node-b", "profile-x", appliedFalse, degradedTrue),
+		newStatusTestProfile("node-c", "profile-x", appliedDeferred, degradedDefer),
+		newStatusTestProfile("node-d", "profile-x", appliedUnknown, degradedFalse),
+		newStatusTestProfile("node-e", "profile-a", appliedTrue, degradedFalse),
+	}
+	for i := 0; i < tunedStatusMaxFailingNodes+2; i++ {
+		profileList = append(profileList, newStatusTestProfile(fmt.Sprintf("failing-%02d", i), "profile-f", appliedTrue, degradedTrue))
+	}
+
+	uses := map[string][]*tunedv1.Profile{}
+	for _, profile := range profileList {
+		uses[profile.Spec.Config.TunedProfile] = append(uses[profile.Spec.Config.TunedProfile], profile)
+	}
+	got := computeTunedProfileStatuses(uses)
+
+	failingNodes := []string{}
+	for i := 0; i < tunedStatusMaxFailingNodes; i++ {
+		failingNodes = append(failingNodes, fmt.Sprintf("failing-%02d", i))
+	}
+	want := []tunedv1.TunedProfileStatus{
+		{Name: "profile-a", Nodes: 1, Applied: 1},
+		{Name: "profile-f", Nodes: tunedStatusMaxFailingNodes + 2, Degraded: tunedStatusMaxFailingNodes + 2, FailingNodes: failingNodes},
+		{Name: "profile-x", Nodes: 4, Applied: 1, Degraded: 1, Deferred: 1, FailingNodes: []string{"node-b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeTunedProfileStatuses() = %+v, want %+v", got, want)
+	}
+
+	if got := computeTunedProfileStatuses(nil); got != nil {
+		t.Errorf("computeTunedProfileStatuses(nil) = %+v, want nil", got)
+	}
+}
+
+func TestTunedProfileUses(t *testing.T) {
+	applied := tunedv1.StatusCondition{Status: corev1.ConditionTrue, Reason: "AsExpected"}
+	degraded := tunedv1.StatusCondition{Status: corev1.ConditionFalse, Reason: "AsExpected"}
+
+	tuned := &tunedv1.Tuned{
+		ObjectMeta: metav1.ObjectMeta{Name: "extra"},
+		Spec: tunedv1.TunedSpec{
+			Recommend: []tunedv1.TunedRecommend{
+				{Profile: ptr.To("profile-extra")},
+				{Profile: ptr.To("profile-rt"), Additive: true},
+			},
+		},
+	}
+	nodeA := newStatusTestProfile("node-a", "profile-extra", applied, degraded)
+	nodeB := newStatusTestProfile("node-b", "openshift-node", applied, degraded)
+	nodeB.Spec.Config.AdditiveTunedProfiles = []string{"profile-rt", "profile-other"}
+	nodeB.Status.TunedProfile = "openshift-node profile-rt profile-other"
+	nodeC := newStatusTestProfile("node-c", "openshift-node", applied, degraded)
+	nodeC.Spec.Config.AdditiveTunedProfiles = []string{"profile-other"}
+	selected := map[string]string{
+		"node-a": "extra",
+		"node-b": "default",
+		"node-c": "default",
+	}
+
+	got := tunedProfileUses(tuned, selected, []*tunedv1.Profile{nodeA, nodeB, nodeC})
+	want := map[string][]*tunedv1.Profile{
+		"profile-extra": {nodeA},
+		"profile-rt":    {nodeB},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tunedProfileUses() = %v, want %v", got, want)
+	}
+
+	statuses := computeTunedProfileStatuses(got)
+	wantStatuses := []tunedv1.TunedProfileStatus{
+		{Name: "profile-extra", Nodes: 1, Applied: 1},
+		{Name: "profile-rt", Nodes: 1, Applied: 1},
+	}
+	if !reflect.DeepEqual(statuses, wantStatuses) {
+		t.Errorf("computeTunedProfileStatuses() = %+v, want %+v", statuses, wantStatuses)
+	}
+}
+
+func TestComputePriorityConflictCondition(t *testing.T) {
+	conflict := PriorityConflict{
+		TunedName:                "tuned-a",
+		TunedProfileName:         "profile-a",
+		ConflictTunedName:        "tuned-b",
+		ConflictTunedProfileName: "profile-b",
+		Priority:                 20,
+	}
+	priorityConflicts := map[string][]PriorityConflict{
+		"node-1": {conflict},
+		"node-0": {conflict},
+	}
+
+	tests := []struct {
+		tunedName string
+		status    corev1.ConditionStatus
+		message   string
+	}{
+		{
+			tunedName: "tuned-a",
+			status:    corev1.ConditionTrue,
+			message:   "Different TuneD profiles with the same priority match the same Node(s): profile profile-a (Tuned tuned-a) and profile profile-b (Tuned tuned-b) with priority 20 match Node(s) node-0, node-1. Please use a different priority for your custom profiles.",
+		},
+		{
+			tunedName: "tuned-b",
+			status:    corev1.ConditionTrue,
+			message:   "Different TuneD profiles with the same priority match the same Node(s): profile profile-a (Tuned tuned-a) and profile profile-b (Tuned tuned-b) with priority 20 match Node(s) node-0, node-1. Please use a different priority for your custom profiles.",
+		},
+		{
+			tunedName: "tuned-c",
+			status:    corev1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tunedName, func(t *testing.T) {
+			condition := computePriorityConflictCondition(tc.tunedName, priorityConflicts)
+			if condition.Type != tunedv1.TunedPriorityConflict || condition.Status != tc.status || condition.Message != tc.message {
+				t.Errorf("computePriorityConflictCondition() = %+v, want status %s and message %q", condition, tc.status, tc.message)
+			}
+		})
+	}
+}
+
+func TestProfileOverrides(t *testing.T) {
+	conditions := profileOverrideConditions(nil, true, "debug")
+	profile := &tunedv1.Profile{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Status: tunedv1.ProfileStatus{Conditions: conditions}}
+	if !profileConditionTrue(profile, tunedv1.TunedPaused) || !profileConditionTrue(profile, tunedv1.TunedProfileOverridden) {
+		t.Errorf("profileOverrideConditions() = %+v, want Paused and ProfileOverridden", conditions)
+	}
+
+	paused := &tunedv1.Profile{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	paused.Status.Conditions = profileOverrideConditions(nil, true, "")
+	want := "Profile updates paused on 2 Node(s): node-a, node-b; TuneD profile overridden on 1 Node(s): node-b"
+	if got := profilesOverriddenMessage([]*tunedv1.Profile{profile, paused, {}}); got != want {
+		t.Errorf("profilesOverriddenMessage() = %q, want %q", got, want)
+	}
+
+	// The conditions are removed once the annotations are removed.
+	if conditions = profileOverrideConditions(conditions, false, ""); len(conditions) != 0 {
+		t.Errorf("profileOverrideConditions() = %+v, want no conditions", conditions)
+	}
+	if got := profilesOverriddenMessage(nil); got != "" {
+		t.Errorf("profilesOverriddenMessage(nil) = %q, want an empty string", got)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+	tunedpkg "github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 )
 
 type DuplicateProfileError struct {
@@ -52,12 +55,35 @@ func (c *Controller) validateTunedCRs() error {
 			}
 		}
 
+		if err := validateTunedMatchSelectors(tuned.Spec.Recommend); err != nil {
+			tunedValid = false
+			allTunedValid = false
+			klog.Errorf("invalid label selector detected in tuned/%s: %v", tuned.Name, err)
+			if len(message) > 0 {
+				message += " "
+			}
+			message += fmt.Sprintf("Invalid label selector detected: %v.", err)
+		}
+
+		if tuned.Spec.MaintenanceWindow != nil || util.GetDeferredUpdateAnnotation(tuned.Annotations) == util.DeferWindow {
+			if err := validateMaintenanceWindow(tuned.Spec.MaintenanceWindow); err != nil {
+				tunedValid = false
+				allTunedValid = false
+				klog.Errorf("invalid maintenance window detected in tuned/%s: %v", tuned.Name, err)
+				if len(message) > 0 {
+					message += " "
+				}
+				message += fmt.Sprintf("Invalid maintenance window detected: %v.", err)
+			}
+		}
+
 		tuned = tuned.DeepCopy() // Make sure we do not modify objects in cache
 
 		if len(tuned.Status.Conditions) == 0 {
 			tuned.Status.Conditions = initializeTunedStatusConditions()
 		}
 		tuned.Status.Conditions = computeStatusConditions(tunedValid, message, tuned.Status.Conditions)
+		tuned.Status.Conditions = tunedpkg.SetStatusCondition(tuned.Status.Conditions, computePriorityConflictCondition(tuned.Name, c.priorityConflicts))
 
 		_, err = c.clients.Tuned.TunedV1().Tuneds(ntoconfig.WatchNamespace()).UpdateStatus(context.TODO(), tuned, metav1.UpdateOptions{})
 		if err != nil {
@@ -69,3 +95,44 @@ func (c *Controller) validateTunedCRs() error {
 
 	return nil
 }
+
+// validateTunedMatchSelectors returns an error if any of the label selectors
+// in the TunedMatch's tree-like definitions of 'recommend' rules is invalid.
+func validateTunedMatchSelectors(recommend []tunedv1.TunedRecommend) error {
+	var validate func(match []tunedv1.TunedMatch) error
+
+	validate = func(match []tunedv1.TunedMatch) error {
+		for _, m := range match {
+			if _, err := tunedMatchSelector(m.LabelSelector); err != nil {
+				return err
+			}
+			if err := validate(m.Match); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, r := range recommend {
+		if err := validate(r.Match); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMaintenanceWindow returns an error if the maintenance window 'window'
+// is undefined or invalid.
+func validateMaintenanceWindow(window *tunedv1.TunedMaintenanceWindow) error {
+	if window == nil {
+		return fmt.Errorf("maintenanceWindow is required for %s=%s", tunedv1.TunedDeferredUpdate, util.DeferWindow)
+	}
+	if _, err := util.ParseMaintenanceWindowSchedule(window.Schedule); err != nil {
+		return err
+	}
+	if window.Duration.Duration <= 0 {
+		return fmt.Errorf("invalid maintenance window duration %v", window.Duration.Duration)
+	}
+	return nil
+}
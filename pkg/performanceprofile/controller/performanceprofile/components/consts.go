@@ -37,3 +37,16 @@ const (
 	// HugepagesSize1G contains the size of 1G hugepages
 	HugepagesSize1G = "1G"
 )
+
+const (
+	// DefaultTransparentHugePages contains the default transparent huge pages mode
+	DefaultTransparentHugePages = "never"
+	// DefaultRealTimeStatInterval contains the default vm.stat_interval with the real time workload hint
+	DefaultRealTimeStatInterval = 10
+	// DefaultDirtyRatio contains the default vm.dirty_ratio
+	DefaultDirtyRatio = 10
+	// DefaultDirtyBackgroundRatio contains the default vm.dirty_background_ratio
+	DefaultDirtyBackgroundRatio = 3
+	// DefaultSwappiness contains the default vm.swappiness
+	DefaultSwappiness = 10
+)
@@ -26,6 +26,7 @@ const (
 	// 3. Topology manager policy
 	// 4. Reserved CPUs
 	// 5. Memory manager policy
+	// The settings of the typed kubelet API (spec.kubelet) take precedence over the snippet as well.
 	// Please avoid specifying them and use the relevant API to configure these parameters.
 	experimentalKubeletSnippetAnnotation         = "kubeletconfig.experimental"
 	cpuManagerPolicyStatic                       = "static"
@@ -62,6 +63,11 @@ func New(profile *performancev2.PerformanceProfile, opts *components.KubeletConf
 	kubeletConfig.CPUManagerReconcilePeriod = metav1.Duration{Duration: 5 * time.Second}
 	kubeletConfig.TopologyManagerPolicy = kubeletconfigv1beta1.BestEffortTopologyManagerPolicy
 
+	// the typed kubelet settings take precedence over the experimental annotation
+	if profile.Spec.Kubelet != nil {
+		applyKubeletSettings(kubeletConfig, profile.Spec.Kubelet)
+	}
+
 	// set the default hard eviction memory threshold
 	if kubeletConfig.EvictionHard == nil {
 		kubeletConfig.EvictionHard = map[string]string{}
@@ -111,53 +117,57 @@ func New(profile *performancev2.PerformanceProfile, opts *components.KubeletConf
 		kubeletConfig.ReservedSystemCPUs = reservedCPUs.Union(sharedCPUs).String()
 	}
 
-	if profile.Spec.NUMA != nil {
-		if profile.Spec.NUMA.TopologyPolicy != nil {
-			topologyPolicy := *profile.Spec.NUMA.TopologyPolicy
-			kubeletConfig.TopologyManagerPolicy = topologyPolicy
-
-			// set the memory manager policy to static only when the topology policy is
-			// restricted or single NUMA node
-			if topologyPolicy == kubeletconfigv1beta1.RestrictedTopologyManagerPolicy ||
-				topologyPolicy == kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy {
-				kubeletConfig.MemoryManagerPolicy = memoryManagerPolicyStatic
-
-				if kubeletConfig.ReservedMemory == nil {
-					reservedMemory := resource.NewQuantity(0, resource.DecimalSI)
-					if err := addStringToQuantity(reservedMemory, kubeletConfig.KubeReserved[string(corev1.ResourceMemory)]); err != nil {
-						return nil, err
-					}
-					if err := addStringToQuantity(reservedMemory, kubeletConfig.SystemReserved[string(corev1.ResourceMemory)]); err != nil {
-						return nil, err
-					}
-					if err := addStringToQuantity(reservedMemory, kubeletConfig.EvictionHard[evictionHardMemoryAvailable]); err != nil {
-						return nil, err
-					}
-
-					kubeletConfig.ReservedMemory = []kubeletconfigv1beta1.MemoryReservation{
-						{
-							// the NUMA node 0 is the only safe choice for non NUMA machines
-							//  in the future we can extend our API to get this information from a user
-							NumaNode: 0,
-							Limits: map[corev1.ResourceName]resource.Quantity{
-								corev1.ResourceMemory: *reservedMemory,
-							},
-						},
-					}
-				}
-
-				// require full physical CPUs only to ensure maximum isolation
-				if topologyPolicy == kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy {
-					if kubeletConfig.CPUManagerPolicyOptions == nil {
-						kubeletConfig.CPUManagerPolicyOptions = make(map[string]string)
-					}
-
-					if _, ok := kubeletConfig.CPUManagerPolicyOptions[cpuManagerPolicyOptionFullPCPUsOnly]; !ok {
-						kubeletConfig.CPUManagerPolicyOptions[cpuManagerPolicyOptionFullPCPUsOnly] = "true"
-					}
-				}
+	if profile.Spec.NUMA != nil && profile.Spec.NUMA.TopologyPolicy != nil {
+		topologyPolicy := *profile.Spec.NUMA.TopologyPolicy
+		kubeletConfig.TopologyManagerPolicy = topologyPolicy
+
+		// set the memory manager policy to static only when the topology policy is
+		// restricted or single NUMA node
+		if topologyPolicy == kubeletconfigv1beta1.RestrictedTopologyManagerPolicy ||
+			topologyPolicy == kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy {
+			kubeletConfig.MemoryManagerPolicy = memoryManagerPolicyStatic
+		}
+
+		// require full physical CPUs only to ensure maximum isolation
+		if topologyPolicy == kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy {
+			if kubeletConfig.CPUManagerPolicyOptions == nil {
+				kubeletConfig.CPUManagerPolicyOptions = make(map[string]string)
 			}
+
+			if _, ok := kubeletConfig.CPUManagerPolicyOptions[cpuManagerPolicyOptionFullPCPUsOnly]; !ok {
+				kubeletConfig.CPUManagerPolicyOptions[cpuManagerPolicyOptionFullPCPUsOnly] = "true"
+			}
+		}
+	}
+
+	if profile.Spec.Kubelet != nil && profile.Spec.Kubelet.MemoryManagerPolicy != nil {
+		kubeletConfig.MemoryManagerPolicy = *profile.Spec.Kubelet.MemoryManagerPolicy
+	}
+
+	// the static memory manager policy requires the reserved memory
+	var numaReservedMemory *performancev2.NUMAReservedMemory
+	if profile.Spec.NUMA != nil {
+		numaReservedMemory = profile.Spec.NUMA.ReservedMemory
+	}
+	if kubeletConfig.MemoryManagerPolicy == memoryManagerPolicyStatic &&
+		(kubeletConfig.ReservedMemory == nil || numaReservedMemory != nil) {
+		reservedMemory := resource.NewQuantity(0, resource.DecimalSI)
+		if err := addStringToQuantity(reservedMemory, kubeletConfig.KubeReserved[string(corev1.ResourceMemory)]); err != nil {
+			return nil, err
+		}
+		if err := addStringToQuantity(reservedMemory, kubeletConfig.SystemReserved[string(corev1.ResourceMemory)]); err != nil {
+			return nil, err
+		}
+		if err := addStringToQuantity(reservedMemory, kubeletConfig.EvictionHard[evictionHardMemoryAvailable]); err != nil {
+			return nil, err
+		}
+
+		reservations, err := getMemoryReservations(*reservedMemory, numaReservedMemory)
+		if err != nil {
+			return nil, err
 		}
+		kubeletConfig.ReservedMemory = reservations
+		reserveHugePages(kubeletConfig)
 	}
 
 	raw, err := json.Marshal(kubeletConfig)
@@ -184,6 +194,68 @@ func New(profile *performancev2.PerformanceProfile, opts *components.KubeletConf
 	}, nil
 }
 
+// applyKubeletSettings overrides the settings of 'kubeletConfig' by the typed kubelet settings 'kubelet'.
+// The memory manager policy is applied separately, as it overrides the topology policy based default.
+func applyKubeletSettings(kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, kubelet *performancev2.Kubelet) {
+	kubeletConfig.KubeReserved = applyResourceReservation(kubeletConfig.KubeReserved, kubelet.KubeReserved)
+	kubeletConfig.SystemReserved = applyResourceReservation(kubeletConfig.SystemReserved, kubelet.SystemReserved)
+
+	if thresholds := kubelet.EvictionHard; thresholds != nil {
+		if kubeletConfig.EvictionHard == nil {
+			kubeletConfig.EvictionHard = map[string]string{}
+		}
+		for signal, threshold := range map[string]*string{
+			evictionHardMemoryAvailable:  thresholds.MemoryAvailable,
+			evictionHardNodefsAvaialble:  thresholds.NodefsAvailable,
+			evictionHardNodefsInodesFree: thresholds.NodefsInodesFree,
+			evictionHardImagefsAvailable: thresholds.ImagefsAvailable,
+		} {
+			if threshold != nil {
+				kubeletConfig.EvictionHard[signal] = *threshold
+			}
+		}
+	}
+
+	if len(kubelet.CPUManagerPolicyOptions) > 0 {
+		if kubeletConfig.CPUManagerPolicyOptions == nil {
+			kubeletConfig.CPUManagerPolicyOptions = make(map[string]string)
+		}
+		for k, v := range kubelet.CPUManagerPolicyOptions {
+			kubeletConfig.CPUManagerPolicyOptions[k] = v
+		}
+	}
+
+	if len(kubelet.TopologyManagerPolicyOptions) > 0 {
+		if kubeletConfig.TopologyManagerPolicyOptions == nil {
+			kubeletConfig.TopologyManagerPolicyOptions = make(map[string]string)
+		}
+		for k, v := range kubelet.TopologyManagerPolicyOptions {
+			kubeletConfig.TopologyManagerPolicyOptions[k] = v
+		}
+	}
+
+	if kubelet.TopologyManagerScope != nil {
+		kubeletConfig.TopologyManagerScope = *kubelet.TopologyManagerScope
+	}
+}
+
+func applyResourceReservation(reserved map[string]string, reservation *performancev2.ResourceReservation) map[string]string {
+	if reservation == nil {
+		return reserved
+	}
+	if reserved == nil {
+		reserved = map[string]string{}
+	}
+	if reservation.Memory != nil {
+		reserved[string(corev1.ResourceMemory)] = reservation.Memory.String()
+	}
+	if reservation.EphemeralStorage != nil {
+		reserved[string(corev1.ResourceEphemeralStorage)] = reservation.EphemeralStorage.String()
+	}
+
+	return reserved
+}
+
 func addStringToQuantity(q *resource.Quantity, value string) error {
 	v, err := resource.ParseQuantity(value)
 	if err != nil {
@@ -1,15 +1,19 @@
 package kubeletconfig
 
 import (
+	"encoding/json"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"k8s.io/kubernetes/pkg/kubelet/eviction"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
+	performancev2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components"
 	testutils "github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/utils/testing"
 )
@@ -197,4 +201,137 @@ var _ = Describe("Kubelet Config", func() {
 		})
 
 	})
+
+	Context("with typed kubelet settings", func() {
+		It("should override the defaults and the kubelet config snippet", func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Annotations = map[string]string{
+				experimentalKubeletSnippetAnnotation: `{"allowedUnsafeSysctls": ["net.core.somaxconn"], "kubeReserved": {"memory": "2Gi", "cpu": "500m"},
+				"evictionHard": {"memory.available": "200Mi"}, "cpuManagerPolicyOptions": {"full-pcpus-only": "false"}}`,
+			}
+			profile.Spec.Kubelet = &performancev2.Kubelet{
+				KubeReserved: &performancev2.ResourceReservation{
+					Memory:           ptr.To(resource.MustParse("1Gi")),
+					EphemeralStorage: ptr.To(resource.MustParse("5Gi")),
+				},
+				SystemReserved: &performancev2.ResourceReservation{
+					Memory: ptr.To(resource.MustParse("600Mi")),
+				},
+				EvictionHard: &performancev2.EvictionThresholds{
+					MemoryAvailable: ptr.To("300Mi"),
+				},
+				CPUManagerPolicyOptions: map[string]string{
+					"full-pcpus-only":                  "true",
+					"prefer-align-cpus-by-uncorecache": "true",
+				},
+				TopologyManagerPolicyOptions: map[string]string{"prefer-closest-numa-nodes": "true"},
+				TopologyManagerScope:         ptr.To(kubeletconfigv1beta1.PodTopologyManagerScope),
+			}
+			selectorKey, selectorValue := components.GetFirstKeyAndValue(profile.Spec.MachineConfigPoolSelector)
+			kc, err := New(profile, &components.KubeletConfigOptions{MachineConfigPoolSelector: map[string]string{selectorKey: selectorValue}})
+			Expect(err).ToNot(HaveOccurred())
+
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+			Expect(json.Unmarshal(kc.Spec.KubeletConfig.Raw, kubeletConfig)).To(Succeed())
+			Expect(kubeletConfig.AllowedUnsafeSysctls).To(ConsistOf("net.core.somaxconn"))
+			Expect(kubeletConfig.KubeReserved).To(Equal(map[string]string{"memory": "1Gi", "cpu": "500m", "ephemeral-storage": "5Gi"}))
+			Expect(kubeletConfig.SystemReserved).To(Equal(map[string]string{"memory": "600Mi"}))
+			Expect(kubeletConfig.EvictionHard).To(HaveKeyWithValue(evictionHardMemoryAvailable, "300Mi"))
+			Expect(kubeletConfig.EvictionHard).To(HaveKeyWithValue(evictionHardNodefsAvaialble, defaultHardEvictionThresholdNodefs))
+			Expect(kubeletConfig.CPUManagerPolicyOptions).To(Equal(map[string]string{
+				"full-pcpus-only":                  "true",
+				"prefer-align-cpus-by-uncorecache": "true",
+			}))
+			Expect(kubeletConfig.TopologyManagerPolicyOptions).To(Equal(map[string]string{"prefer-closest-numa-nodes": "true"}))
+			Expect(kubeletConfig.TopologyManagerScope).To(Equal(kubeletconfigv1beta1.PodTopologyManagerScope))
+
+			// 1Gi kube-reserved + 600Mi system-reserved + 300Mi hard eviction threshold
+			Expect(kubeletConfig.MemoryManagerPolicy).To(Equal(memoryManagerPolicyStatic))
+			Expect(kubeletConfig.ReservedMemory).To(HaveLen(1))
+			reserved := kubeletConfig.ReservedMemory[0].Limits[corev1.ResourceMemory]
+			Expect(reserved.Cmp(resource.MustParse("1924Mi"))).To(Equal(0))
+		})
+
+		It("should set the memory manager policy", func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Spec.NUMA.TopologyPolicy = ptr.To(kubeletconfigv1beta1.BestEffortTopologyManagerPolicy)
+			profile.Spec.Kubelet = &performancev2.Kubelet{
+				MemoryManagerPolicy: ptr.To(kubeletconfigv1beta1.StaticMemoryManagerPolicy),
+			}
+			selectorKey, selectorValue := components.GetFirstKeyAndValue(profile.Spec.MachineConfigPoolSelector)
+			kc, err := New(profile, &components.KubeletConfigOptions{MachineConfigPoolSelector: map[string]string{selectorKey: selectorValue}})
+			Expect(err).ToNot(HaveOccurred())
+
+			y, err := yaml.Marshal(kc)
+			Expect(err).ToNot(HaveOccurred())
+
+			manifest := string(y)
+			Expect(manifest).To(ContainSubstring("memoryManagerPolicy: Static"))
+			Expect(manifest).To(ContainSubstring(testReservedMemory))
+		})
+	})
+
+	Context("with the NUMA reserved memory", func() {
+		It("should split the reserved memory and hugepages evenly", func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Spec.NUMA.ReservedMemory = &performancev2.NUMAReservedMemory{
+				Mode:      performancev2.ReservedMemoryModeEvenSplit,
+				NUMANodes: ptr.To[int32](2),
+				HugePages: []performancev2.HugePagesReservation{{Size: "1G", Count: 3}},
+			}
+			selectorKey, selectorValue := components.GetFirstKeyAndValue(profile.Spec.MachineConfigPoolSelector)
+			kc, err := New(profile, &components.KubeletConfigOptions{MachineConfigPoolSelector: map[string]string{selectorKey: selectorValue}})
+			Expect(err).ToNot(HaveOccurred())
+
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+			Expect(json.Unmarshal(kc.Spec.KubeletConfig.Raw, kubeletConfig)).To(Succeed())
+			Expect(kubeletConfig.ReservedMemory).To(HaveLen(2))
+
+			// 500Mi kube-reserved + 500Mi system-reserved + 100Mi hard eviction threshold
+			for i, want := range []struct{ memory, hugepages string }{{"550Mi", "2Gi"}, {"550Mi", "1Gi"}} {
+				reservation := kubeletConfig.ReservedMemory[i]
+				Expect(reservation.NumaNode).To(Equal(int32(i)))
+				memory := reservation.Limits[corev1.ResourceMemory]
+				Expect(memory.Cmp(resource.MustParse(want.memory))).To(Equal(0))
+				hugepages := reservation.Limits["hugepages-1Gi"]
+				Expect(hugepages.Cmp(resource.MustParse(want.hugepages))).To(Equal(0))
+			}
+			Expect(kubeletConfig.SystemReserved).To(HaveKeyWithValue("hugepages-1Gi", "3Gi"))
+		})
+
+		It("should reserve the explicit memory of the NUMA nodes", func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Spec.NUMA.ReservedMemory = &performancev2.NUMAReservedMemory{
+				Mode: performancev2.ReservedMemoryModeExplicit,
+				Nodes: []performancev2.NUMANodeReservedMemory{
+					{Node: 0, Memory: ptr.To(resource.MustParse("1000Mi"))},
+					{Node: 1, Memory: ptr.To(resource.MustParse("100Mi"))},
+				},
+			}
+			selectorKey, selectorValue := components.GetFirstKeyAndValue(profile.Spec.MachineConfigPoolSelector)
+			kc, err := New(profile, &components.KubeletConfigOptions{MachineConfigPoolSelector: map[string]string{selectorKey: selectorValue}})
+			Expect(err).ToNot(HaveOccurred())
+
+			y, err := yaml.Marshal(kc)
+			Expect(err).ToNot(HaveOccurred())
+
+			manifest := string(y)
+			Expect(manifest).To(ContainSubstring("memory: 1000Mi\n      numaNode: 0"))
+			Expect(manifest).To(ContainSubstring("memory: 100Mi\n      numaNode: 1"))
+		})
+
+		It("should fail when the explicit memory of the NUMA nodes does not add up", func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Spec.NUMA.ReservedMemory = &performancev2.NUMAReservedMemory{
+				Mode: performancev2.ReservedMemoryModeExplicit,
+				Nodes: []performancev2.NUMANodeReservedMemory{
+					{Node: 0, Memory: ptr.To(resource.MustParse("500Mi"))},
+					{Node: 1, Memory: ptr.To(resource.MustParse("500Mi"))},
+				},
+			}
+			selectorKey, selectorValue := components.GetFirstKeyAndValue(profile.Spec.MachineConfigPoolSelector)
+			_, err := New(profile, &components.KubeletConfigOptions{MachineConfigPoolSelector: map[string]string{selectorKey: selectorValue}})
+			Expect(err).To(MatchError(ContainSubstring("does not add up")))
+		})
+	})
 })
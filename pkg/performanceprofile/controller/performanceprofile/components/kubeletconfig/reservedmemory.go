@@ -0,0 +1,153 @@
+package kubeletconfig
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	performancev2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
+)
+
+// getMemoryReservations returns the memory reservations of the NUMA nodes for the static memory manager policy.
+// The 'reservedMemory' is the sum of the kube-reserved, system-reserved and hard eviction memory.
+// All the memory is reserved on NUMA node 0 unless distributed across the NUMA nodes by 'numaReserved'.
+func getMemoryReservations(reservedMemory resource.Quantity, numaReserved *performancev2.NUMAReservedMemory) ([]kubeletconfigv1beta1.MemoryReservation, error) {
+	if numaReserved == nil {
+		return []kubeletconfigv1beta1.MemoryReservation{
+			{
+				// the NUMA node 0 is the only safe choice for non NUMA machines
+				NumaNode: 0,
+				Limits: map[corev1.ResourceName]resource.Quantity{
+					corev1.ResourceMemory: reservedMemory,
+				},
+			},
+		}, nil
+	}
+
+	switch numaReserved.Mode {
+	case performancev2.ReservedMemoryModeEvenSplit:
+		return getEvenSplitMemoryReservations(reservedMemory, numaReserved)
+	case performancev2.ReservedMemoryModeExplicit:
+		return getExplicitMemoryReservations(reservedMemory, numaReserved)
+	}
+
+	return nil, fmt.Errorf("unknown reserved memory mode %q", numaReserved.Mode)
+}
+
+func getEvenSplitMemoryReservations(reservedMemory resource.Quantity, numaReserved *performancev2.NUMAReservedMemory) ([]kubeletconfigv1beta1.MemoryReservation, error) {
+	if numaReserved.NUMANodes == nil || *numaReserved.NUMANodes <= 0 {
+		return nil, fmt.Errorf("the number of NUMA nodes is required to split the reserved memory evenly")
+	}
+	numaNodes := int64(*numaReserved.NUMANodes)
+
+	reservations := make([]kubeletconfigv1beta1.MemoryReservation, numaNodes)
+	total := reservedMemory.Value()
+	for i := range reservations {
+		// the remainder goes to the NUMA node 0
+		memory := total / numaNodes
+		if i == 0 {
+			memory += total % numaNodes
+		}
+		reservations[i] = kubeletconfigv1beta1.MemoryReservation{
+			NumaNode: int32(i),
+			Limits: map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceMemory: *resource.NewQuantity(memory, resource.BinarySI),
+			},
+		}
+	}
+
+	for _, hugepages := range numaReserved.HugePages {
+		name, size, err := getHugePagesResource(hugepages.Size)
+		if err != nil {
+			return nil, err
+		}
+		for i := range reservations {
+			// the remainder goes to the lowest NUMA nodes
+			count := int64(hugepages.Count) / numaNodes
+			if int64(i) < int64(hugepages.Count)%numaNodes {
+				count++
+			}
+			if count > 0 {
+				reservations[i].Limits[name] = *resource.NewQuantity(count*size, resource.BinarySI)
+			}
+		}
+	}
+
+	return reservations, nil
+}
+
+func getExplicitMemoryReservations(reservedMemory resource.Quantity, numaReserved *performancev2.NUMAReservedMemory) ([]kubeletconfigv1beta1.MemoryReservation, error) {
+	if len(numaReserved.Nodes) == 0 {
+		return nil, fmt.Errorf("the reserved memory of the NUMA nodes is required")
+	}
+
+	var reservations []kubeletconfigv1beta1.MemoryReservation
+	total := resource.NewQuantity(0, resource.BinarySI)
+	for _, node := range numaReserved.Nodes {
+		reservation := kubeletconfigv1beta1.MemoryReservation{
+			NumaNode: node.Node,
+			Limits:   map[corev1.ResourceName]resource.Quantity{},
+		}
+		if node.Memory != nil {
+			reservation.Limits[corev1.ResourceMemory] = node.Memory.DeepCopy()
+			total.Add(*node.Memory)
+		}
+		for _, hugepages := range node.HugePages {
+			name, size, err := getHugePagesResource(hugepages.Size)
+			if err != nil {
+				return nil, err
+			}
+			reservation.Limits[name] = *resource.NewQuantity(int64(hugepages.Count)*size, resource.BinarySI)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	// the kubelet refuses to start otherwise
+	if total.Cmp(reservedMemory) != 0 {
+		return nil, fmt.Errorf("the reserved memory of the NUMA nodes %s does not add up to the kube-reserved, system-reserved and hard eviction memory %s",
+			total.String(), reservedMemory.String())
+	}
+
+	return reservations, nil
+}
+
+// reserveHugePages adds the hugepages reserved on the NUMA nodes to the system-reserved resources
+// unless already reserved, the kubelet requires them to add up.
+func reserveHugePages(kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) {
+	totals := map[corev1.ResourceName]*resource.Quantity{}
+	for _, reservation := range kubeletConfig.ReservedMemory {
+		for name, quantity := range reservation.Limits {
+			if name == corev1.ResourceMemory {
+				continue
+			}
+			if _, ok := totals[name]; !ok {
+				totals[name] = resource.NewQuantity(0, resource.BinarySI)
+			}
+			totals[name].Add(quantity)
+		}
+	}
+
+	for name, total := range totals {
+		if _, ok := kubeletConfig.KubeReserved[string(name)]; ok {
+			continue
+		}
+		if _, ok := kubeletConfig.SystemReserved[string(name)]; ok {
+			continue
+		}
+		kubeletConfig.SystemReserved[string(name)] = total.String()
+	}
+}
+
+// getHugePagesResource returns the resource name and the size in bytes of the huge pages of size 'size'.
+func getHugePagesResource(size performancev2.HugePageSize) (corev1.ResourceName, int64, error) {
+	bytes, err := units.RAMInBytes(string(size))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to parse hugepages size: %w", err)
+	}
+
+	name := corev1.ResourceName(corev1.ResourceHugePagesPrefix + resource.NewQuantity(bytes, resource.BinarySI).String())
+	return name, bytes, nil
+}
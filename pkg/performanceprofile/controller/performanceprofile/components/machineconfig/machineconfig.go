@@ -9,6 +9,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"text/template"
 
 	assets "github.com/openshift/cluster-node-tuning-operator/assets/performanceprofile"
@@ -63,12 +64,14 @@ const (
 	// TBD
 	defaultContainersLimit = "256"
 
-	udevRulesDir         = "/etc/udev/rules.d"
-	udevPhysicalRpsRules = "99-netdev-physical-rps.rules"
+	udevRulesDir          = "/etc/udev/rules.d"
+	udevPhysicalRpsRules  = "99-netdev-physical-rps.rules"
+	udevNetDevTuningRules = "99-netdev-tuning.rules"
 	// scripts
 	hugepagesAllocation       = "hugepages-allocation"
 	setCPUsOffline            = "set-cpus-offline"
 	setRPSMask                = "set-rps-mask"
+	setNetDevTuning           = "set-netdev-tuning"
 	clearIRQBalanceBannedCPUs = "clear-irqbalance-banned-cpus"
 
 	ovsSliceName                     = "ovs.slice"
@@ -107,6 +110,9 @@ const (
 	environmentHugepagesCount = "HUGEPAGES_COUNT"
 	environmentNUMANode       = "NUMA_NODE"
 	environmentOfflineCpus    = "OFFLINE_CPUS"
+	environmentIRQAffinity    = "IRQ_AFFINITY"
+	environmentRPSMask        = "RPS_MASK"
+	environmentXPSMask        = "XPS_MASK"
 )
 
 const (
@@ -188,6 +194,13 @@ func getIgnitionConfig(profile *performancev2.PerformanceProfile, opts *componen
 		// realtime is explicitly disabled by workload hint
 		scripts = []string{hugepagesAllocation, setCPUsOffline, clearIRQBalanceBannedCPUs}
 	}
+	netDevTuningRules, netDevTuningUnits, err := getNetDevTuning(profile)
+	if err != nil {
+		return nil, err
+	}
+	if len(netDevTuningUnits) > 0 {
+		scripts = append(scripts, setNetDevTuning)
+	}
 	mode := 0700
 	for _, script := range scripts {
 		dst := getBashScriptPath(script)
@@ -198,6 +211,14 @@ func getIgnitionConfig(profile *performancev2.PerformanceProfile, opts *componen
 		addContent(ignitionConfig, content, dst, &mode)
 	}
 
+	// add the IRQ affinity and RPS/XPS settings of the specific network devices
+	if len(netDevTuningUnits) > 0 {
+		netDevTuningRulesMode := 0644
+		netDevTuningRulesDst := filepath.Join(udevRulesDir, udevNetDevTuningRules)
+		addContent(ignitionConfig, netDevTuningRules, netDevTuningRulesDst, &netDevTuningRulesMode)
+		ignitionConfig.Systemd.Units = append(ignitionConfig.Systemd.Units, netDevTuningUnits...)
+	}
+
 	// add crio config snippet under the node /etc/crio/crio.conf.d/ directory
 	crioConfdRuntimesMode := 0644
 	crioConfigSnippetContent, err := renderCrioConfigSnippet(profile, filepath.Join("configs", crioRuntimesConfig), opts)
@@ -249,6 +270,9 @@ func getIgnitionConfig(profile *performancev2.PerformanceProfile, opts *componen
 			if page.Node == nil {
 				continue
 			}
+			// the NUMA specific hugepages allocated at boot time still get the service, it does
+			// nothing when the kernel allocated the pages and falls back to the runtime allocation
+			// when it did not, e.g. the kernel does not support the per-node kernel arguments
 
 			hugepagesSize, err := GetHugepagesSizeKilobytes(page.Size)
 			if err != nil {
@@ -562,6 +586,120 @@ func getRPSUnitOptions(rpsMask string) []*unit.UnitOption {
 	}
 }
 
+// getNetDevTuning returns the udev rules and the systemd units setting the IRQ affinity and
+// the RPS/XPS masks of the network devices 'profile' specifies them for.  The units are started
+// when the physical network devices or their queues are added.
+func getNetDevTuning(profile *performancev2.PerformanceProfile) ([]byte, []igntypes.Unit, error) {
+	if profile.Spec.Net == nil {
+		return nil, nil, nil
+	}
+
+	var rules []string
+	var units []igntypes.Unit
+	for i, device := range profile.Spec.Net.Devices {
+		if device.IRQAffinity == nil && device.RPSCPUs == nil && device.XPSCPUs == nil {
+			continue
+		}
+
+		options, err := getNetDevTuningUnitOptions(device)
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := getSystemdContent(options)
+		if err != nil {
+			return nil, nil, err
+		}
+		service := getSystemdService(fmt.Sprintf("%s-%d@", setNetDevTuning, i))
+
+		rules = append(rules, getNetDevTuningUdevRule(device, service))
+		units = append(units, igntypes.Unit{
+			Contents: &content,
+			Name:     service,
+		})
+	}
+	if len(units) == 0 {
+		return nil, nil, nil
+	}
+
+	// the queues do not carry the properties of their network devices the rules match
+	header := []string{
+		`SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="INTERFACE"`,
+		`SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="ID_VENDOR_ID"`,
+		`SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="ID_MODEL_ID"`,
+	}
+	return []byte(strings.Join(append(header, rules...), "\n") + "\n"), units, nil
+}
+
+// getNetDevTuningUdevRule returns the udev rule starting the template 'service' for the physical
+// network devices matching 'device' and their queues.
+func getNetDevTuningUdevRule(device performancev2.Device, service string) string {
+	keys := []string{`SUBSYSTEM=="net|queues"`, `ACTION=="add|move"`, `ENV{DEVPATH}!="/devices/virtual/*"`}
+	if device.InterfaceName != nil {
+		if name, ok := strings.CutPrefix(*device.InterfaceName, "!"); ok {
+			keys = append(keys, fmt.Sprintf(`ENV{INTERFACE}!="%s"`, name))
+		} else {
+			keys = append(keys, fmt.Sprintf(`ENV{INTERFACE}=="%s"`, *device.InterfaceName))
+		}
+	}
+	if device.VendorID != nil {
+		keys = append(keys, fmt.Sprintf(`ENV{ID_VENDOR_ID}=="%s"`, *device.VendorID))
+	}
+	if device.DeviceID != nil {
+		keys = append(keys, fmt.Sprintf(`ENV{ID_MODEL_ID}=="%s"`, *device.DeviceID))
+	}
+	keys = append(keys,
+		`TAG+="systemd"`,
+		fmt.Sprintf(`PROGRAM="/bin/systemd-escape --path --template=%s $env{DEVPATH}"`, service),
+		`ENV{SYSTEMD_WANTS}+="%c"`,
+	)
+	return strings.Join(keys, ", ")
+}
+
+func getNetDevTuningUnitOptions(device performancev2.Device) ([]*unit.UnitOption, error) {
+	options := []*unit.UnitOption{
+		// [Unit]
+		// Description
+		unit.NewUnitOption(systemdSectionUnit, systemdDescription, "Sets network device IRQ affinity and RPS/XPS masks"),
+		// After, override the default RPS mask set on the same device or queue
+		unit.NewUnitOption(systemdSectionUnit, systemdAfter, "update-rps@%i.service"),
+		// [Service]
+		// Type
+		unit.NewUnitOption(systemdSectionService, systemdType, systemdServiceTypeOneshot),
+	}
+
+	// Environment
+	if device.IRQAffinity != nil {
+		cpus, err := cpuset.Parse(string(*device.IRQAffinity))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the network device IRQ affinity: %w", err)
+		}
+		options = append(options, unit.NewUnitOption(systemdSectionService, systemdEnvironment, getSystemdEnvironment(environmentIRQAffinity, cpus.String())))
+	}
+	for _, mask := range []struct {
+		env  string
+		cpus *performancev2.CPUSet
+	}{
+		{env: environmentRPSMask, cpus: device.RPSCPUs},
+		{env: environmentXPSMask, cpus: device.XPSCPUs},
+	} {
+		if mask.cpus == nil {
+			continue
+		}
+		if _, err := cpuset.Parse(string(*mask.cpus)); err != nil {
+			return nil, fmt.Errorf("failed to parse the network device CPUs: %w", err)
+		}
+		cpuMask, err := components.CPUListToMaskList(string(*mask.cpus))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, unit.NewUnitOption(systemdSectionService, systemdEnvironment, getSystemdEnvironment(mask.env, cpuMask)))
+	}
+
+	// ExecStart
+	options = append(options, unit.NewUnitOption(systemdSectionService, systemdExecStart, fmt.Sprintf("%s %%I", getBashScriptPath(setNetDevTuning))))
+	return options, nil
+}
+
 func addContent(ignitionConfig *igntypes.Config, content []byte, dst string, mode *int) {
 	contentBase64 := base64.StdEncoding.EncodeToString(content)
 	ignitionConfig.Storage.Files = append(ignitionConfig.Storage.Files, igntypes.File{
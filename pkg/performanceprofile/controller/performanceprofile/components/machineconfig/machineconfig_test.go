@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/utils/ptr"
 
@@ -82,6 +83,27 @@ const clearBannedCPUs = `
 var CPUs = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
 var CPUstring = "1,2,3,4,5,6,7,8,9"
 
+const netDevTuningService = `
+      - contents: |
+          [Unit]
+          Description=Sets network device IRQ affinity and RPS/XPS masks
+          After=update-rps@%i.service
+
+          [Service]
+          Type=oneshot
+          Environment=IRQ_AFFINITY=0-1
+          Environment=RPS_MASK=00000003
+          Environment=XPS_MASK=00000000
+          ExecStart=/usr/local/bin/set-netdev-tuning.sh %I
+        name: set-netdev-tuning-1@.service
+`
+
+const netDevTuningRules = `SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="INTERFACE"
+SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="ID_VENDOR_ID"
+SUBSYSTEM=="queues", ACTION=="add", IMPORT{parent}="ID_MODEL_ID"
+SUBSYSTEM=="net|queues", ACTION=="add|move", ENV{DEVPATH}!="/devices/virtual/*", ENV{INTERFACE}!="eno*", ENV{ID_VENDOR_ID}=="0x8086", TAG+="systemd", PROGRAM="/bin/systemd-escape --path --template=set-netdev-tuning-1@.service $env{DEVPATH}", ENV{SYSTEMD_WANTS}+="%c"
+`
+
 var _ = Describe("Machine Config", func() {
 
 	Context("machine config creation ", func() {
@@ -156,6 +178,61 @@ var _ = Describe("Machine Config", func() {
 		})
 	})
 
+	Context("with network devices IRQ affinity and RPS/XPS CPUs", func() {
+		var result igntypes.Config
+
+		BeforeEach(func() {
+			profile := testutils.NewPerformanceProfile("test")
+			profile.Spec.Net = &performancev2.Net{
+				Devices: []performancev2.Device{
+					{
+						InterfaceName: ptr.To("ens1f0"),
+						Queues:        ptr.To(int32(2)),
+					},
+					{
+						InterfaceName: ptr.To("!eno*"),
+						VendorID:      ptr.To("0x8086"),
+						IRQAffinity:   ptr.To(performancev2.CPUSet("0,1")),
+						RPSCPUs:       ptr.To(performancev2.CPUSet("0-1")),
+						XPSCPUs:       ptr.To(performancev2.CPUSet("")),
+					},
+				},
+			}
+
+			mc, err := New(profile, &components.MachineConfigOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Unmarshal(mc.Spec.Config.Raw, &result)).To(Succeed())
+
+			y, err := yaml.Marshal(mc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(y)).To(ContainSubstring(netDevTuningService))
+		})
+
+		It("should add the udev rules starting the systemd units of the devices", func() {
+			var rules *igntypes.File
+			for i := range result.Storage.Files {
+				if result.Storage.Files[i].Node.Path == "/etc/udev/rules.d/99-netdev-tuning.rules" {
+					rules = &result.Storage.Files[i]
+				}
+			}
+			Expect(rules).ToNot(BeNil())
+
+			content, err := base64.StdEncoding.DecodeString(regexp.MustCompile(`^data:[^,]*,`).ReplaceAllString(*rules.Contents.Source, ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal(netDevTuningRules))
+		})
+
+		It("should only add the systemd units of the devices with the IRQ affinity or RPS/XPS CPUs", func() {
+			var units []string
+			for _, u := range result.Systemd.Units {
+				if strings.HasPrefix(u.Name, setNetDevTuning) {
+					units = append(units, u.Name)
+				}
+			}
+			Expect(units).To(Equal([]string{"set-netdev-tuning-1@.service"}))
+		})
+	})
+
 	Context("check listToString ", func() {
 		It("should create string from CPUSet", func() {
 			res := components.ListToString(CPUs)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -38,6 +39,13 @@ const (
 	templateIsolatedCpuList                 = "IsolatedCpuList"
 	templateReservedCpuList                 = "ReservedCpuList"
 	templatePerformanceProfileName          = "PerformanceProfileName"
+	templateTransparentHugePages            = "TransparentHugePages"
+	templateNUMABalancing                   = "NUMABalancing"
+	templateKSM                             = "KSM"
+	templateStatInterval                    = "StatInterval"
+	templateDirtyRatio                      = "DirtyRatio"
+	templateDirtyBackgroundRatio            = "DirtyBackgroundRatio"
+	templateSwappiness                      = "Swappiness"
 )
 
 func new(name string, profiles []tunedv1.TunedProfile, recommends []tunedv1.TunedRecommend) *tunedv1.Tuned {
@@ -97,11 +105,15 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 			templateArgs[templateDefaultHugepagesSize] = string(defaultHugepageSize)
 		}
 
+		bootNUMAAllocation := profile.Spec.HugePages.NUMAAllocation != nil &&
+			*profile.Spec.HugePages.NUMAAllocation == performancev2.HugePagesNUMAAllocationBoot
+
 		var is2MHugepagesRequested *bool
 		var hugepages []string
 		for _, page := range profile.Spec.HugePages.Pages {
-			// we can not allocate huge pages on the specific NUMA node via kernel boot arguments
-			if page.Node != nil {
+			// huge pages on the specific NUMA node are allocated via the kernel boot arguments
+			// only when requested, the systemd service allocates them otherwise
+			if page.Node != nil && !bootNUMAAllocation {
 				// a user requested to allocate 2M huge pages on the specific NUMA node,
 				// append dummy kernel arguments
 				if page.Size == components.HugepagesSize2M && is2MHugepagesRequested == nil {
@@ -117,10 +129,18 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 				is2MHugepagesRequested = ptr.To(false)
 			}
 
+			if page.Node != nil {
+				continue
+			}
+
 			hugepages = append(hugepages, fmt.Sprintf("hugepagesz=%s", string(page.Size)))
 			hugepages = append(hugepages, fmt.Sprintf("hugepages=%d", page.Count))
 		}
 
+		if bootNUMAAllocation {
+			hugepages = append(hugepages, getNUMAHugepagesArgs(profile.Spec.HugePages.Pages)...)
+		}
+
 		// append dummy 2M huge pages kernel arguments to guarantee that the kernel will create 2M related files
 		// and directories under the filesystem
 		if is2MHugepagesRequested != nil && *is2MHugepagesRequested {
@@ -144,13 +164,19 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 
 	//set default [net] field first, override if needed.
 	templateArgs[templateNetDevices] = fmt.Sprintf("[net]\n%s", nfConntrackHashsize)
-	if profile.Spec.Net != nil && profile.Spec.Net.UserLevelNetworking != nil &&
-		*profile.Spec.Net.UserLevelNetworking && profile.Spec.CPU.Reserved != nil {
-		reservedSet, err := cpuset.Parse(string(*profile.Spec.CPU.Reserved))
-		if err != nil {
-			return nil, err
+	if profile.Spec.Net != nil {
+		userLevelNetworking := profile.Spec.Net.UserLevelNetworking != nil &&
+			*profile.Spec.Net.UserLevelNetworking && profile.Spec.CPU.Reserved != nil
+		// the channels are only set on the devices with the queues specified when the
+		// user level networking is disabled
+		reserveCPUcount := 0
+		if userLevelNetworking {
+			reservedSet, err := cpuset.Parse(string(*profile.Spec.CPU.Reserved))
+			if err != nil {
+				return nil, err
+			}
+			reserveCPUcount = reservedSet.Size()
 		}
-		reserveCPUcount := reservedSet.Size()
 
 		var devices []string
 		var tunedNetDevicesOutput []string
@@ -158,6 +184,14 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 		netPluginString := ""
 
 		for _, device := range profile.Spec.Net.Devices {
+			channels := reserveCPUcount
+			if device.Queues != nil {
+				channels = int(*device.Queues)
+			}
+			if channels == 0 {
+				continue
+			}
+
 			devices = make([]string, 0)
 			if device.DeviceID != nil {
 				devices = append(devices, "^ID_MODEL_ID="+*device.DeviceID)
@@ -186,14 +220,14 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 			if netPluginSequence > 0 {
 				netPluginString = "_" + strconv.Itoa(netPluginSequence)
 			}
-			tunedNetDevicesOutput = append(tunedNetDevicesOutput, fmt.Sprintf("\n[net%s]\ntype=net\ndevices_udev_regex=%s\nchannels=combined %d\n%s", netPluginString, devicesUdevRegex, reserveCPUcount, nfConntrackHashsize))
+			tunedNetDevicesOutput = append(tunedNetDevicesOutput, fmt.Sprintf("\n[net%s]\ntype=net\ndevices_udev_regex=%s\nchannels=combined %d\n%s", netPluginString, devicesUdevRegex, channels, nfConntrackHashsize))
 			netPluginSequence++
 		}
 		//nfConntrackHashsize
-		if len(tunedNetDevicesOutput) == 0 {
-			templateArgs[templateNetDevices] = fmt.Sprintf("[net]\nchannels=combined %d\n%s", reserveCPUcount, nfConntrackHashsize)
-		} else {
+		if len(tunedNetDevicesOutput) > 0 {
 			templateArgs[templateNetDevices] = strings.Join(tunedNetDevicesOutput, "")
+		} else if userLevelNetworking {
+			templateArgs[templateNetDevices] = fmt.Sprintf("[net]\nchannels=combined %d\n%s", reserveCPUcount, nfConntrackHashsize)
 		}
 	}
 
@@ -201,6 +235,8 @@ func NewNodePerformance(profile *performancev2.PerformanceProfile) (*tunedv1.Tun
 		templateArgs[templateRealTimeHint] = "true"
 	}
 
+	addMemoryTemplateArgs(templateArgs, profile)
+
 	if IsHighPowerConsumptionHintEnabled(profile) && IsPerPodPowerManagementEnabled(profile) {
 		err := fmt.Errorf("Invalid WorkloadHints configuration: HighPowerConsumption is %t and PerPodPowerManagement is %t", *profile.Spec.WorkloadHints.HighPowerConsumption, *profile.Spec.WorkloadHints.PerPodPowerManagement)
 		return nil, err
@@ -287,6 +323,80 @@ func getProfileData(tunedTemplate string, data interface{}) (string, error) {
 	return profile.String(), nil
 }
 
+// getNUMAHugepagesArgs returns the kernel arguments allocating the huge pages 'pages' of the specific
+// NUMA nodes at boot time, e.g. "hugepagesz=1G hugepages=0:4,1:2".  Kernels not supporting the per-node
+// syntax allocate no pages, the systemd services allocate them at runtime then.
+func getNUMAHugepagesArgs(pages []performancev2.HugePage) []string {
+	var sizes []performancev2.HugePageSize
+	nodePages := map[performancev2.HugePageSize][]performancev2.HugePage{}
+	for _, page := range pages {
+		if page.Node == nil {
+			continue
+		}
+		if _, ok := nodePages[page.Size]; !ok {
+			sizes = append(sizes, page.Size)
+		}
+		nodePages[page.Size] = append(nodePages[page.Size], page)
+	}
+
+	var args []string
+	for _, size := range sizes {
+		sizePages := nodePages[size]
+		sort.Slice(sizePages, func(i, j int) bool {
+			return *sizePages[i].Node < *sizePages[j].Node
+		})
+
+		counts := make([]string, 0, len(sizePages))
+		for _, page := range sizePages {
+			counts = append(counts, fmt.Sprintf("%d:%d", *page.Node, page.Count))
+		}
+		args = append(args, fmt.Sprintf("hugepagesz=%s", string(size)))
+		args = append(args, fmt.Sprintf("hugepages=%s", strings.Join(counts, ",")))
+	}
+
+	return args
+}
+
+// addMemoryTemplateArgs adds the memory subsystem settings of 'profile' to 'templateArgs'.
+// The NUMA balancing and KSM settings are only added when specified, the parent profiles
+// and the system settings apply otherwise.
+func addMemoryTemplateArgs(templateArgs map[string]interface{}, profile *performancev2.PerformanceProfile) {
+	memory := profile.Spec.Memory
+	if memory == nil {
+		memory = &performancev2.Memory{}
+	}
+
+	templateArgs[templateTransparentHugePages] = components.DefaultTransparentHugePages
+	if memory.TransparentHugePages != nil {
+		templateArgs[templateTransparentHugePages] = string(*memory.TransparentHugePages)
+	}
+
+	if memory.NUMABalancing != nil {
+		templateArgs[templateNUMABalancing] = boolToSysctl(*memory.NUMABalancing)
+	}
+
+	if memory.KSM != nil {
+		templateArgs[templateKSM] = boolToSysctl(*memory.KSM)
+	}
+
+	if memory.StatInterval != nil {
+		templateArgs[templateStatInterval] = strconv.Itoa(int(*memory.StatInterval))
+	} else if IsRealTimeHintEnabled(profile) {
+		templateArgs[templateStatInterval] = strconv.Itoa(components.DefaultRealTimeStatInterval)
+	}
+
+	templateArgs[templateDirtyRatio] = strconv.Itoa(int(ptr.Deref(memory.DirtyRatio, components.DefaultDirtyRatio)))
+	templateArgs[templateDirtyBackgroundRatio] = strconv.Itoa(int(ptr.Deref(memory.DirtyBackgroundRatio, components.DefaultDirtyBackgroundRatio)))
+	templateArgs[templateSwappiness] = strconv.Itoa(int(ptr.Deref(memory.Swappiness, components.DefaultSwappiness)))
+}
+
+func boolToSysctl(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
 func IsIRQBalancingGloballyDisabled(profile *performancev2.PerformanceProfile) bool {
 	return profile.Spec.GloballyDisableIrqLoadBalancing != nil && *profile.Spec.GloballyDisableIrqLoadBalancing
 }
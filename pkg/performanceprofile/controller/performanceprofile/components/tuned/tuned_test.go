@@ -35,6 +35,7 @@ var (
 	cmdlineIntelPstateAutomatic      = "intel_pstate=${f:intel_recommended_pstate}"
 	cmdlineIntelPstatePassive        = "intel_pstate=passive"
 	cmdlineMultipleHugePages         = "+ default_hugepagesz=1G   hugepagesz=1G hugepages=4 hugepagesz=2M hugepages=128"
+	cmdlineNUMAHugePages             = "+ default_hugepagesz=1G   hugepagesz=1G hugepages=0:4,1:2 hugepagesz=2M hugepages=0:128"
 	cmdlineRealtimeNoHZFull          = "+nohz_full=${isolated_cores}"
 	cmdlineRealtimeNosoftlookup      = "+nosoftlockup"
 	cmdlineRealtimeCommon            = "+skew_tick=1 rcutree.kthread_prio=11"
@@ -338,6 +339,20 @@ var _ = Describe("Tuned", func() {
 			Expect(strings.Count(manifest, "hugepages=")).To(BeNumerically("==", 0))
 		})
 
+		It("should allocate hugepages on the specific NUMA node via kernel arguments when requested", func() {
+			profile.Spec.HugePages.NUMAAllocation = ptr.To(performancev2.HugePagesNUMAAllocationBoot)
+			profile.Spec.HugePages.Pages = []performancev2.HugePage{
+				{Size: components.HugepagesSize1G, Count: 2, Node: ptr.To(int32(1))},
+				{Size: components.HugepagesSize1G, Count: 4, Node: ptr.To(int32(0))},
+				{Size: components.HugepagesSize2M, Count: 128, Node: ptr.To(int32(0))},
+			}
+
+			tunedData := getTunedStructuredData(profile, components.ProfileNamePerformance)
+			bootLoader, err := tunedData.GetSection("bootloader")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bootLoader.Key("cmdline_hugepages").String()).To(Equal(cmdlineNUMAHugePages))
+		})
+
 		Context("with 1G default huge pages", func() {
 			Context("with requested 2M huge pages allocation on the specified node", func() {
 				It("should append the dummy 2M huge pages kernel arguments", func() {
@@ -570,6 +585,105 @@ var _ = Describe("Tuned", func() {
 					Expect(len(manifest)).ToNot(Equal(0))
 				})
 			})
+
+			Context("with the net device queues specified", func() {
+				It("should override the reserved CPUs count", func() {
+					netDeviceName := "eth0"
+					devicesUdevRegex := "\\^INTERFACE=" + netDeviceName
+
+					profile.Spec.Net = &performancev2.Net{
+						UserLevelNetworking: ptr.To(true),
+						Devices: []performancev2.Device{
+							{
+								InterfaceName: &netDeviceName,
+								Queues:        ptr.To(int32(8)),
+							},
+						}}
+					manifest := getTunedManifest(profile)
+					channelsRegex := regexp.MustCompile(`\s*\[net\]\\ntype=net\\ndevices_udev_regex=` + devicesUdevRegex + `\\nchannels=combined\s*8\s*`)
+					Expect(channelsRegex.MatchString(manifest)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("with user level networking disabled", func() {
+			It("should only set the net device queues of the devices with the queues specified", func() {
+				netDeviceName := "eth0"
+				devicesUdevRegex := "\\^INTERFACE=" + netDeviceName
+
+				profile.Spec.Net = &performancev2.Net{
+					Devices: []performancev2.Device{
+						{
+							InterfaceName: ptr.To("eth1"),
+						},
+						{
+							InterfaceName: &netDeviceName,
+							Queues:        ptr.To(int32(2)),
+						},
+					}}
+				manifest := getTunedManifest(profile)
+				channelsRegex := regexp.MustCompile(`\s*\[net\]\\ntype=net\\ndevices_udev_regex=` + devicesUdevRegex + `\\nchannels=combined\s*2\s*`)
+				Expect(channelsRegex.MatchString(manifest)).To(BeTrue())
+				Expect(manifest).ToNot(ContainSubstring("INTERFACE=eth1"))
+				Expect(strings.Count(manifest, "channels=combined")).To(Equal(1))
+			})
+		})
+
+		Context("with memory settings", func() {
+			It("should keep the default memory settings", func() {
+				tunedData := getTunedStructuredData(profile, components.ProfileNamePerformance)
+				vm, err := tunedData.GetSection("vm")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Key("transparent_hugepages").String()).To(Equal("never"))
+
+				sysctl, err := tunedData.GetSection("sysctl")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sysctl.HasKey("kernel.numa_balancing")).To(BeFalse())
+				Expect(sysctl.Key("vm.stat_interval").String()).To(Equal("10"))
+				Expect(sysctl.Key("vm.dirty_ratio").String()).To(Equal("10"))
+				Expect(sysctl.Key("vm.dirty_background_ratio").String()).To(Equal("3"))
+				Expect(sysctl.Key("vm.swappiness").String()).To(Equal("10"))
+
+				Expect(tunedData.HasSection("sysfs_ksm")).To(BeFalse())
+			})
+
+			It("should not set the stat interval without the realtime hint", func() {
+				profile.Spec.WorkloadHints = &performancev2.WorkloadHints{RealTime: ptr.To(false)}
+				tunedData := getTunedStructuredData(profile, components.ProfileNamePerformance)
+				sysctl, err := tunedData.GetSection("sysctl")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sysctl.HasKey("vm.stat_interval")).To(BeFalse())
+			})
+
+			It("should render the specified memory settings", func() {
+				profile.Spec.WorkloadHints = &performancev2.WorkloadHints{RealTime: ptr.To(false)}
+				profile.Spec.Memory = &performancev2.Memory{
+					TransparentHugePages: ptr.To(performancev2.TransparentHugePagesMadvise),
+					NUMABalancing:        ptr.To(true),
+					KSM:                  ptr.To(false),
+					StatInterval:         ptr.To[int32](5),
+					DirtyRatio:           ptr.To[int32](20),
+					DirtyBackgroundRatio: ptr.To[int32](5),
+					Swappiness:           ptr.To[int32](0),
+				}
+				tunedData := getTunedStructuredData(profile, components.ProfileNamePerformance)
+				vm, err := tunedData.GetSection("vm")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.Key("transparent_hugepages").String()).To(Equal("madvise"))
+
+				sysctl, err := tunedData.GetSection("sysctl")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sysctl.Key("kernel.numa_balancing").String()).To(Equal("1"))
+				Expect(sysctl.Key("vm.stat_interval").String()).To(Equal("5"))
+				Expect(sysctl.Key("vm.dirty_ratio").String()).To(Equal("20"))
+				Expect(sysctl.Key("vm.dirty_background_ratio").String()).To(Equal("5"))
+				Expect(sysctl.Key("vm.swappiness").String()).To(Equal("0"))
+
+				ksm, err := tunedData.GetSection("sysfs_ksm")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ksm.Key("type").String()).To(Equal("sysfs"))
+				Expect(ksm.Key("/sys/kernel/mm/ksm/run").String()).To(Equal("0"))
+			})
 		})
 	})
 
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/docker/go-units"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -180,22 +182,11 @@ func GetKubeletConditionsByProfile(ctx context.Context, client client.Client, pr
 }
 
 func GetTunedConditionsByProfile(ctx context.Context, cli client.Client, profile *performancev2.PerformanceProfile) ([]conditionsv1.Condition, error) {
-	tunedProfileList := &tunedv1.ProfileList{}
-	if err := cli.List(ctx, tunedProfileList); err != nil {
-		klog.Errorf("Cannot list Tuned Profiles to match with profile %q: %v", profile.Name, err)
-		return nil, err
-	}
-
-	selector := labels.SelectorFromSet(profile.Spec.NodeSelector)
-	nodes := &corev1.NodeList{}
-	if err := cli.List(ctx, nodes, &client.ListOptions{LabelSelector: selector}); err != nil {
+	filtered, err := getTunedProfilesByProfile(ctx, cli, profile)
+	if err != nil {
 		return nil, err
 	}
 
-	// remove Tuned profiles that are not associate with this performance profile
-	// Tuned profile's name and node's name should be equal
-	filtered := removeUnMatchedTunedProfiles(nodes.Items, tunedProfileList.Items)
-
 	messageString := GetTunedProfilesMessage(filtered)
 	if len(messageString) == 0 {
 		return nil, nil
@@ -204,6 +195,69 @@ func GetTunedConditionsByProfile(ctx context.Context, cli client.Client, profile
 	return GetDegradedConditions(ConditionReasonTunedDegraded, messageString), nil
 }
 
+// GetHugePagesShortfallsByProfile returns the huge pages of the specific NUMA nodes the nodes
+// of the performance profile failed to allocate.
+func GetHugePagesShortfallsByProfile(ctx context.Context, cli client.Client, profile *performancev2.PerformanceProfile) ([]performancev2.HugePagesShortfall, error) {
+	if profile.Spec.HugePages == nil {
+		return nil, nil
+	}
+
+	filtered, err := getTunedProfilesByProfile(ctx, cli, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetHugePagesShortfalls(profile.Spec.HugePages.Pages, filtered)
+}
+
+// GetHugePagesShortfalls returns the huge pages 'pages' of the specific NUMA nodes which
+// are not allocated according to the Tuned 'profiles'.  Profiles not reporting the NUMA
+// nodes of their node are skipped.
+func GetHugePagesShortfalls(pages []performancev2.HugePage, profiles []tunedv1.Profile) ([]performancev2.HugePagesShortfall, error) {
+	var shortfalls []performancev2.HugePagesShortfall
+	for _, tunedProfile := range profiles {
+		if tunedProfile.Status.NUMANodes == 0 {
+			continue
+		}
+
+		for _, page := range pages {
+			if page.Node == nil {
+				continue
+			}
+
+			size, err := units.RAMInBytes(string(page.Size))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse hugepages size: %w", err)
+			}
+
+			var allocated int32
+			for _, hp := range tunedProfile.Status.HugePages {
+				if hp.Node == *page.Node && hp.SizeKB*1024 == size {
+					allocated = hp.Count
+					break
+				}
+			}
+			if allocated >= page.Count {
+				continue
+			}
+
+			shortfalls = append(shortfalls, performancev2.HugePagesShortfall{
+				NodeName:  tunedProfile.Name,
+				NUMANode:  *page.Node,
+				Size:      page.Size,
+				Requested: page.Count,
+				Allocated: allocated,
+			})
+		}
+	}
+
+	sort.SliceStable(shortfalls, func(i, j int) bool {
+		return shortfalls[i].NodeName < shortfalls[j].NodeName
+	})
+
+	return shortfalls, nil
+}
+
 func GetTunedProfilesMessage(profiles []tunedv1.Profile) string {
 	message := bytes.Buffer{}
 	for _, tunedProfile := range profiles {
@@ -228,14 +282,35 @@ func GetTunedProfilesMessage(profiles []tunedv1.Profile) string {
 			if len(tunedDegradedCondition.Reason) > 0 {
 				message.WriteString("Tuned " + tunedProfile.GetName() + " Degraded Reason: " + tunedDegradedCondition.Reason + ".\n")
 			}
-			if len(tunedDegradedCondition.Message) > 0 {
+			// The Degraded condition message only embeds the last TuneD error, prefer the
+			// structured list of all the errors if available.
+			tunedErrors := tunedErrorDiagnostics(tunedProfile.Status.Diagnostics)
+			if len(tunedErrors) == 0 && len(tunedDegradedCondition.Message) > 0 {
 				message.WriteString("Tuned " + tunedProfile.GetName() + " Degraded Message: " + tunedDegradedCondition.Message + ".\n")
 			}
+			for _, d := range tunedErrors {
+				if len(d.Plugin) > 0 {
+					message.WriteString("Tuned " + tunedProfile.GetName() + " Degraded Error (" + d.Plugin + "): " + d.Message + ".\n")
+				} else {
+					message.WriteString("Tuned " + tunedProfile.GetName() + " Degraded Error: " + d.Message + ".\n")
+				}
+			}
 		}
 	}
 	return message.String()
 }
 
+// tunedErrorDiagnostics returns the errors among the TuneD 'diagnostics'.
+func tunedErrorDiagnostics(diagnostics []tunedv1.TunedDiagnostic) []tunedv1.TunedDiagnostic {
+	var tunedErrors []tunedv1.TunedDiagnostic
+	for _, d := range diagnostics {
+		if d.Severity == tunedv1.DiagnosticSeverityError {
+			tunedErrors = append(tunedErrors, d)
+		}
+	}
+	return tunedErrors
+}
+
 func getLatestKubeletConfigCondition(conditions []mcov1.KubeletConfigCondition) *mcov1.KubeletConfigCondition {
 	var latestCondition *mcov1.KubeletConfigCondition
 	for i := 0; i < len(conditions); i++ {
@@ -246,6 +321,25 @@ func getLatestKubeletConfigCondition(conditions []mcov1.KubeletConfigCondition)
 	return latestCondition
 }
 
+// getTunedProfilesByProfile returns the Tuned profiles of the nodes the performance profile applies to.
+func getTunedProfilesByProfile(ctx context.Context, cli client.Client, profile *performancev2.PerformanceProfile) ([]tunedv1.Profile, error) {
+	tunedProfileList := &tunedv1.ProfileList{}
+	if err := cli.List(ctx, tunedProfileList); err != nil {
+		klog.Errorf("Cannot list Tuned Profiles to match with profile %q: %v", profile.Name, err)
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(profile.Spec.NodeSelector)
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes, &client.ListOptions{LabelSelector: selector}); err != nil {
+		return nil, err
+	}
+
+	// remove Tuned profiles that are not associate with this performance profile
+	// Tuned profile's name and node's name should be equal
+	return removeUnMatchedTunedProfiles(nodes.Items, tunedProfileList.Items), nil
+}
+
 func removeUnMatchedTunedProfiles(nodes []corev1.Node, profiles []tunedv1.Profile) []tunedv1.Profile {
 	filteredProfiles := make([]tunedv1.Profile, 0)
 	for _, profile := range profiles {
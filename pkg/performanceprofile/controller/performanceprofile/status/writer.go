@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -67,7 +68,17 @@ func (w *writer) UpdateOwnedConditions(ctx context.Context, object client.Object
 	if conditions == nil {
 		conditions = GetAvailableConditions("")
 	}
-	return w.Update(ctx, profile, conditions)
+
+	shortfalls, err := GetHugePagesShortfallsByProfile(ctx, w.Client, profile)
+	if err != nil {
+		return w.updateDegradedCondition(profile, ConditionFailedGettingTunedProfileStatus, err)
+	}
+	for _, sf := range shortfalls {
+		klog.Warningf("performance profile %q: node %q allocated %d of %d %s huge pages on NUMA node %d",
+			profile.Name, sf.NodeName, sf.Allocated, sf.Requested, sf.Size, sf.NUMANode)
+	}
+
+	return w.updateStatus(ctx, profile, conditions, shortfalls)
 }
 
 func (w *writer) updateDegradedCondition(instance client.Object, conditionState string, conditionError error) error {
@@ -80,7 +91,17 @@ func (w *writer) updateDegradedCondition(instance client.Object, conditionState
 }
 
 func (w *writer) update(ctx context.Context, profile *performancev2.PerformanceProfile, conditions []conditionsv1.Condition) error {
+	return w.updateStatus(ctx, profile, conditions, profile.Status.HugePagesShortfalls)
+}
+
+func (w *writer) updateStatus(ctx context.Context, profile *performancev2.PerformanceProfile, conditions []conditionsv1.Condition, shortfalls []performancev2.HugePagesShortfall) error {
 	updatedStatus := CalculateUpdated(&profile.Status, profile.Name, "", conditions)
+	if !equality.Semantic.DeepEqual(profile.Status.HugePagesShortfalls, shortfalls) {
+		if updatedStatus == nil {
+			updatedStatus = profile.Status.DeepCopy()
+		}
+		updatedStatus.HugePagesShortfalls = shortfalls
+	}
 	if updatedStatus == nil {
 		return nil
 	}
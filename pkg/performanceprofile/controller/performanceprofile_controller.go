@@ -126,7 +126,9 @@ func (r *PerformanceProfileReconciler) SetupWithManager(mgr ctrl.Manager) error
 			tunedProfileOld := e.ObjectOld.(*tunedv1.Profile)
 			tunedProfileNew := e.ObjectNew.(*tunedv1.Profile)
 
-			return !reflect.DeepEqual(tunedProfileOld.Status.Conditions, tunedProfileNew.Status.Conditions)
+			return !reflect.DeepEqual(tunedProfileOld.Status.Conditions, tunedProfileNew.Status.Conditions) ||
+				!reflect.DeepEqual(tunedProfileOld.Status.HugePages, tunedProfileNew.Status.HugePages) ||
+				tunedProfileOld.Status.NUMANodes != tunedProfileNew.Status.NUMANodes
 		},
 	}
 
@@ -771,6 +771,132 @@ var _ = Describe("Controller", func() {
 					Expect(degradedCondition.Reason).To(Equal(status.ConditionReasonTunedDegraded))
 					Expect(degradedCondition.Message).To(ContainSubstring(tunedMessage))
 				})
+
+				It("should report all TuneD errors when TunedProfile is degraded", func() {
+					tunedReason := "tunedReason"
+					tunedMessage := "Tuned message"
+
+					tuned := &tunedv1.Profile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "tuned-profile-test",
+						},
+						Status: tunedv1.ProfileStatus{
+							Conditions: []tunedv1.StatusCondition{
+								{
+									Type:    tunedv1.TunedDegraded,
+									Status:  corev1.ConditionTrue,
+									Reason:  tunedReason,
+									Message: tunedMessage,
+								},
+								{
+									Type:    tunedv1.TunedProfileApplied,
+									Status:  corev1.ConditionFalse,
+									Reason:  tunedReason,
+									Message: tunedMessage,
+								},
+							},
+							Diagnostics: []tunedv1.TunedDiagnostic{
+								{
+									Severity: tunedv1.DiagnosticSeverityError,
+									Plugin:   "sysctl",
+									Message:  "Failed to set sysctl parameter 'kernel.foo'",
+								},
+								{
+									Severity: tunedv1.DiagnosticSeverityWarning,
+									Plugin:   "cpu",
+									Message:  "Latency settings from non-first CPU plugin instance are ignored",
+								},
+								{
+									Severity: tunedv1.DiagnosticSeverityError,
+									Plugin:   "bootloader",
+									Message:  "Cannot find grub.cfg to patch",
+								},
+							},
+						},
+					}
+
+					nodes := &corev1.NodeList{
+						Items: []corev1.Node{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "tuned-profile-test",
+									Labels: map[string]string{
+										"nodekey": "nodeValue",
+									},
+								},
+							},
+						},
+					}
+
+					r := newFakeReconciler(profile, mc, kc, tunedPerformance, tuned, nodes, profileMCP, infra, clusterOperator)
+
+					Expect(reconcileTimes(r, request, 1)).To(Equal(reconcile.Result{}))
+
+					updatedProfile := &performancev2.PerformanceProfile{}
+					key := types.NamespacedName{
+						Name:      profile.Name,
+						Namespace: metav1.NamespaceNone,
+					}
+					Expect(r.Get(context.TODO(), key, updatedProfile)).ToNot(HaveOccurred())
+
+					degradedCondition := conditionsv1.FindStatusCondition(updatedProfile.Status.Conditions, conditionsv1.ConditionDegraded)
+					Expect(degradedCondition).ToNot(BeNil())
+					Expect(degradedCondition.Status).To(Equal(corev1.ConditionTrue))
+					Expect(degradedCondition.Reason).To(Equal(status.ConditionReasonTunedDegraded))
+					Expect(degradedCondition.Message).To(ContainSubstring("Degraded Error (sysctl): Failed to set sysctl parameter 'kernel.foo'"))
+					Expect(degradedCondition.Message).To(ContainSubstring("Degraded Error (bootloader): Cannot find grub.cfg to patch"))
+					Expect(degradedCondition.Message).ToNot(ContainSubstring("Latency settings"))
+					Expect(degradedCondition.Message).ToNot(ContainSubstring(tunedMessage))
+				})
+
+				It("should report the huge pages the nodes failed to allocate on the NUMA nodes", func() {
+					profile.Spec.HugePages.NUMAAllocation = ptr.To(performancev2.HugePagesNUMAAllocationBoot)
+					profile.Spec.HugePages.Pages = []performancev2.HugePage{
+						{Size: "1G", Count: 4, Node: ptr.To(int32(0))},
+						{Size: "1G", Count: 4, Node: ptr.To(int32(1))},
+					}
+
+					tuned := &tunedv1.Profile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "tuned-profile-test",
+						},
+						Status: tunedv1.ProfileStatus{
+							NUMANodes: 2,
+							HugePages: []tunedv1.NUMAHugePages{
+								{Node: 0, SizeKB: 1048576, Count: 4},
+								{Node: 1, SizeKB: 1048576, Count: 1},
+							},
+						},
+					}
+
+					nodes := &corev1.NodeList{
+						Items: []corev1.Node{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "tuned-profile-test",
+									Labels: map[string]string{
+										"nodekey": "nodeValue",
+									},
+								},
+							},
+						},
+					}
+
+					r := newFakeReconciler(profile, mc, kc, tunedPerformance, tuned, nodes, profileMCP, infra, clusterOperator)
+
+					Expect(reconcileTimes(r, request, 1)).To(Equal(reconcile.Result{}))
+
+					updatedProfile := &performancev2.PerformanceProfile{}
+					key := types.NamespacedName{
+						Name:      profile.Name,
+						Namespace: metav1.NamespaceNone,
+					}
+					Expect(r.Get(context.TODO(), key, updatedProfile)).ToNot(HaveOccurred())
+
+					Expect(updatedProfile.Status.HugePagesShortfalls).To(Equal([]performancev2.HugePagesShortfall{
+						{NodeName: "tuned-profile-test", NUMANode: 1, Size: "1G", Requested: 4, Allocated: 1},
+					}))
+				})
 			})
 
 			When("the provided machine config labels are different from one specified under the machine config pool", func() {
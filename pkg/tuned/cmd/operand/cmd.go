@@ -32,6 +32,7 @@ import (
 type tunedOpts struct {
 	inCluster bool
 	oneShot   bool
+	dbus      bool
 }
 
 func NewTunedCommand() *cobra.Command {
@@ -59,6 +60,7 @@ func NewTunedCommand() *cobra.Command {
 func (t *tunedOpts) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&t.inCluster, "in-cluster", true, "In-cluster operand run.")
 	fs.BoolVar(&t.oneShot, "one-shot", false, "Run TuneD in one-shot mode.")
+	fs.BoolVar(&t.dbus, "dbus", false, "Track the TuneD daemon state via the TuneD D-Bus API, falls back to TuneD log parsing.")
 }
 
 func addKlogFlags(cmd *cobra.Command) {
@@ -72,15 +74,15 @@ func (t *tunedOpts) Validate() error {
 }
 
 func (t *tunedOpts) Run() error {
-	return tunedOperandRun(t.inCluster, t.oneShot)
+	return tunedOperandRun(t.inCluster, t.oneShot, t.dbus)
 }
 
-func tunedOperandRun(inCluster, oneShot bool) error {
+func tunedOperandRun(inCluster, oneShot, dbus bool) error {
 	stopCh := signals.SetupSignalHandler()
 
 	if inCluster {
 		if !oneShot {
-			return tuned.RunInCluster(stopCh, version.Version)
+			return tuned.RunInCluster(stopCh, version.Version, dbus)
 		}
 	} else {
 		if oneShot {
@@ -0,0 +1,192 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+
+	performancev2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components/tuned"
+	tunedpkg "github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+)
+
+const (
+	outputText = "text"
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+type renderEffectiveOpts struct {
+	assetsInDir      []string
+	profileName      string
+	tunedProfilesDir string
+	output           string
+}
+
+func NewRenderEffectiveProfileCommand() *cobra.Command {
+	renderOpts := renderEffectiveOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "render-effective-profile",
+		Short: "Render the effective TuneD profile",
+		Long: `Render the effective TuneD profile flattened from the TuneD profiles in Tuned CRs and
+PerformanceProfiles found in the input directories and the stock TuneD profiles.
+The include chain is resolved, the profiles merged and the TuneD variables and
+built-in functions expanded.  Note the built-in functions are evaluated on the
+current host.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := renderOpts.Validate(); err != nil {
+				klog.Fatal(err)
+			}
+
+			if err := renderOpts.Run(os.Stdout); err != nil {
+				klog.Fatal(err)
+			}
+		},
+	}
+
+	addKlogFlags(cmd)
+	renderOpts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (r *renderEffectiveOpts) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&r.assetsInDir, "asset-input-dir", r.assetsInDir, "Input path for the Tuned CR and PerformanceProfile manifests. (Can use it more than one to define multiple directories)")
+	fs.StringVar(&r.profileName, "profile", r.profileName, "TuneD profile to render.  Defaults to the profile recommended with the highest priority.")
+	fs.StringVar(&r.tunedProfilesDir, "tuned-profiles-dir", "/usr/lib/tuned", "Directory with the stock TuneD profiles.")
+	fs.StringVarP(&r.output, "output", "o", outputText, "Output format: text, yaml or json.")
+}
+
+func (r *renderEffectiveOpts) Validate() error {
+	var err string
+	if len(r.assetsInDir) == 0 {
+		err += "asset-input-dir must be specified. "
+	}
+	switch r.output {
+	case outputText, outputYAML, outputJSON:
+	default:
+		err += fmt.Sprintf("unsupported output format %q. ", r.output)
+	}
+
+	if len(err) == 0 {
+		return nil
+	}
+	return errors.New(err)
+}
+
+func (r *renderEffectiveOpts) Run(w io.Writer) error {
+	return renderEffective(r.assetsInDir, r.profileName, r.tunedProfilesDir, r.output, w)
+}
+
+func renderEffective(inputDir []string, profileName string, tunedProfilesDir string, output string, w io.Writer) error {
+	filePaths, err := util.ListFilesFromMultiplePaths(inputDir)
+	if err != nil {
+		return fmt.Errorf("error while listing files: %w", err)
+	}
+	klog.V(4).Infof("listed files: %v", filePaths)
+
+	var (
+		perfProfiles []*performancev2.PerformanceProfile
+		mcPools      []*mcfgv1.MachineConfigPool
+		mcConfigs    []*mcfgv1.MachineConfig
+		tuneD        []*tunedv1.Tuned
+	)
+
+	for _, path := range filePaths {
+		err = decodeManifestsFromPath(path, &perfProfiles, &mcPools, &mcConfigs, &tuneD)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pp := range perfProfiles {
+		tunedFromPP, err := tuned.NewNodePerformance(pp)
+		if err != nil {
+			return fmt.Errorf("unable to get tuned from PerformanceProfile:%s. error: %w", pp.Name, err)
+		}
+		tuneD = append(tuneD, tunedFromPP)
+	}
+
+	if err := loadDefaultCrTuneD(&tuneD); err != nil {
+		return fmt.Errorf("unable to load default cr tuned %w", err)
+	}
+
+	if len(profileName) == 0 {
+		profileName = operator.TunedRecommendStatic(operator.TunedRecommend(tuneD))
+		if len(profileName) == 0 {
+			return fmt.Errorf("unable to get recommended profile")
+		}
+		klog.Infof("RecommendedProfile found :%s", profileName)
+	}
+
+	tunedProfiles := []tunedv1.TunedProfile{}
+	for _, t := range tuneD {
+		tunedProfiles = append(tunedProfiles, t.Spec.Profile...)
+	}
+
+	ep, err := tunedpkg.ResolveEffectiveProfile(tunedProfiles, profileName, tunedProfilesDir)
+	if err != nil {
+		return fmt.Errorf("unable to resolve the effective TuneD profile %s: %w", profileName, err)
+	}
+
+	switch output {
+	case outputJSON:
+		b, err := json.MarshalIndent(ep, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case outputYAML:
+		b, err := yaml.Marshal(ep)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	_, err = fmt.Fprint(w, ep.String())
+	return err
+}
+
+// decodeManifestsFromPath decodes the manifests of the file at 'path' and
+// closes it before returning.
+func decodeManifestsFromPath(path string,
+	perfProfiles *[]*performancev2.PerformanceProfile,
+	mcPools *[]*mcfgv1.MachineConfigPool,
+	mcConfigs *[]*mcfgv1.MachineConfig,
+	tuneD *[]*tunedv1.Tuned) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return decodeManifestsFromFile(file.Name(), file, runtimeDecoder, perfProfiles, mcPools, mcConfigs, tuneD)
+}
@@ -221,14 +221,13 @@ func render(inputDir []string, outputDir string, mcpName string) error {
 		return fmt.Errorf("unable to create  %s : %w", outputDir, err)
 	}
 
-	tuneDrecommended := operator.TunedRecommend(tuneD)
-	if len(tuneDrecommended) == 0 {
+	recommendedProfile := operator.TunedRecommendStatic(operator.TunedRecommend(tuneD))
+	if len(recommendedProfile) == 0 {
 		e := fmt.Errorf("unable to get recommended profile")
 		klog.Error(e)
 		return e
 	}
 
-	recommendedProfile := *tuneDrecommended[0].Profile
 	klog.Infof("RecommendedProfile found :%s", recommendedProfile)
 
 	err = tunedpkg.TunedRecommendFileWrite(recommendedProfile)
@@ -13,16 +13,20 @@ import (
 	"os/exec" // os.Exec()
 	"path/filepath"
 	"sort"
-	"strings" // strings.Join()
-	"syscall" // syscall.SIGHUP, ...
-	"time"    // time.Second, ...
+	"strings"     // strings.Join()
+	"sync/atomic" // atomic.Bool
+	"syscall"     // syscall.SIGHUP, ...
+	"time"        // time.Second, ...
 
+	"github.com/godbus/dbus/v5"
 	fsnotify "gopkg.in/fsnotify.v1"
 	"gopkg.in/ini.v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kmeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -35,6 +39,7 @@ import (
 	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	tunedset "github.com/openshift/cluster-node-tuning-operator/pkg/generated/clientset/versioned"
 	tunedinformers "github.com/openshift/cluster-node-tuning-operator/pkg/generated/informers/externalversions"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 	"github.com/openshift/cluster-node-tuning-operator/version"
 )
@@ -48,6 +53,7 @@ const (
 	scSysctlOverride
 	scReloading // reloading is true during the TuneD daemon reload.
 	scDeferred
+	scDeferredWindow // set together with scDeferred when waiting for a maintenance window.
 	scUnknown
 )
 
@@ -88,8 +94,9 @@ const (
 	// 100ms, 200ms, 400ms, 800ms, 1.6s, 3.2s, 6.4s, 12.8s, 25.6s, 51.2s, 102.4s, 3.4m, 6.8m, 13.7m, 27.3m
 	maxRetries = 15
 	// workqueue related constants
-	wqKindDaemon  = "daemon"
-	wqKindProfile = "profile"
+	wqKindDaemon    = "daemon"
+	wqKindProfile   = "profile"
+	wqKindConfigMap = "configmap"
 
 	ocpTunedImageEnv           = ocpTunedHome + "/image.env"
 	tunedProfilesDirCustomHost = ocpTunedHome + "/profiles"
@@ -119,8 +126,11 @@ type Daemon struct {
 	status Bits
 	// stderr log from TuneD daemon to report back via API.
 	stderr string
-	// stopping is true while the controller tries to stop the TuneD daemon.
-	stopping bool
+	// warnings and errors issued by the TuneD daemon to report back via API.
+	diagnostics tunedDiagnostics
+	// stopping is true while the controller tries to stop the TuneD daemon.  Read by
+	// the TuneD log reader goroutine.
+	stopping atomic.Bool
 	// recommendedProfile is the TuneD profile the operator calculated to be applied.
 	// This variable is used to cache the value which was written to tunedRecommendFile.
 	recommendedProfile string
@@ -130,9 +140,17 @@ type Daemon struct {
 	// profileFingerprintEffective is the fingerprint of the profile effective on the node.
 	// Relevant in the startup flow with deferred updates.
 	profileFingerprintEffective string
+	// dbusActive is true once the state of the current TuneD daemon process is tracked
+	// by the TuneD D-Bus API signals.  Read by the TuneD log reader goroutine.
+	dbusActive atomic.Bool
+	// drift is the result of the last verification of the applied tuning, nil if not verified
+	// since the last TuneD reload.
+	drift *driftStatus
 	// recoveredRecommendedProfile is the TuneD profile which we detected to be in effect.
 	// Relevant in the deferred updates flow.
 	recoveredRecommendedProfile string
+	// applyStart is the time the TuneD daemon was last (re)started or reloaded.
+	applyStart time.Time
 }
 
 type Change struct {
@@ -147,6 +165,9 @@ type Change struct {
 	// Is this Change caused by a node restart?
 	nodeRestart bool
 
+	// Do we need to verify the applied tuning for drift?
+	verify bool
+
 	// The following keys are set when profile == true.
 	// Was debugging set in Profile k8s object?
 	debug bool
@@ -160,6 +181,8 @@ type Change struct {
 	// Mode of the deferred update. deferredMode == util.DeferNever if this is a change
 	// triggered by an object without deferred annotation, which is the default.
 	deferredMode util.DeferMode
+	// Is the maintenance window open?  Only relevant when deferredMode == util.DeferWindow.
+	windowOpen bool
 	// Text to convey in status message, if present.
 	message string
 }
@@ -178,6 +201,9 @@ func (ch Change) String() string {
 	if ch.nodeRestart {
 		items = append(items, "nodeRestart:true")
 	}
+	if ch.verify {
+		items = append(items, "verify:true")
+	}
 	if ch.debug {
 		items = append(items, "debug:true")
 	}
@@ -193,6 +219,9 @@ func (ch Change) String() string {
 	if ch.deferredMode != "" {
 		items = append(items, fmt.Sprintf("deferredMode:%q", string(ch.deferredMode)))
 	}
+	if ch.windowOpen {
+		items = append(items, "windowOpen:true")
+	}
 	if ch.message != "" {
 		items = append(items, fmt.Sprintf("message:%q", ch.message))
 	}
@@ -215,6 +244,7 @@ type Controller struct {
 	nodeName string
 
 	tunedCmd     *exec.Cmd       // external command (tuned) being prepared or run
+	tunedStarted bool            // a TuneD daemon process was started, the next start is a restart
 	tunedExit    chan bool       // bi-directional channel to signal and register TuneD daemon exit
 	stopCh       <-chan struct{} // receive-only channel to stop the ocp-tuned controller
 	changeCh     chan Change     // bi-directional channel to wake-up the main thread to process accrued changes
@@ -222,6 +252,10 @@ type Controller struct {
 	tunedMainCfg *ini.File       // global TuneD configuration as defined in tuned-main.conf
 
 	pendingChange *Change // pending deferred change to be applied on node restart (if any)
+
+	tunedDBus *tunedDBus // TuneD daemon D-Bus API client, nil if the API is not used
+
+	numaNodes int32 // number of online NUMA nodes of the node, 0 if unknown
 }
 
 type wqKeyKube struct {
@@ -278,8 +312,15 @@ func newController(nodeName string, stopCh <-chan struct{}) (*Controller, error)
 		return nil, err
 	}
 
+	// The kubelet credentials are not authorized to read the metrics client CA ConfigMap.
+	saKubeclient, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	listers := &ntoclient.Listers{}
 	clients := &ntoclient.Clients{
+		Kube:  saKubeclient,
 		Tuned: tunedclient,
 	}
 
@@ -292,6 +333,7 @@ func newController(nodeName string, stopCh <-chan struct{}) (*Controller, error)
 		stopCh:      stopCh,
 		changeCh:    make(chan Change),
 		changeChRet: make(chan bool),
+		numaNodes:   numaNodeCount(),
 	}
 
 	return controller, nil
@@ -350,7 +392,7 @@ func (c *Controller) sync(key wqKeyKube) error {
 
 		change.profile = true
 		change.provider = profile.Spec.Config.ProviderName
-		change.recommendedProfile = profile.Spec.Config.TunedProfile
+		change.recommendedProfile = util.StackedTunedProfiles(profile.Spec.Config.TunedProfile, profile.Spec.Config.AdditiveTunedProfiles)
 		change.debug = profile.Spec.Config.Debug
 		err = util.SetLogLevel(profile.Spec.Config.Verbosity)
 		if err != nil {
@@ -363,8 +405,34 @@ func (c *Controller) sync(key wqKeyKube) error {
 			change.reapplySysctl = *profile.Spec.Config.TuneDConfig.ReapplySysctl
 		}
 		change.deferredMode = util.GetDeferredUpdateAnnotation(profile.Annotations)
+		if change.deferredMode == util.DeferWindow {
+			change.windowOpen = c.maintenanceWindowOpen(key, profile.Spec.Config.MaintenanceWindow)
+		}
 		// Notify the event processor that the Profile k8s object containing information about which TuneD profile to apply changed.
 		c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: change})
+		if dd := profile.Spec.Config.DriftDetection; dd != nil {
+			// Schedule the verification of the applied tuning.
+			c.wqTuneD.AddAfter(wqKeyTuned{kind: wqKindDaemon, change: Change{verify: true}}, driftDetectionInterval(dd))
+		}
+
+		return nil
+
+	case key.kind == wqKindConfigMap:
+		if key.name != metrics.AuthConfigMapName {
+			return nil
+		}
+		klog.V(2).Infof("sync(): ConfigMap %s/%s", metrics.AuthConfigMapNamespace, key.name)
+
+		cm, err := c.listers.AuthConfigMapCA.Get(metrics.AuthConfigMapName)
+		if err != nil {
+			return fmt.Errorf("failed to get ConfigMap %s/%s: %v", metrics.AuthConfigMapNamespace, metrics.AuthConfigMapName, err)
+		}
+		ca, ok := cm.Data[metrics.AuthConfigMapClientCAKey]
+		if !ok {
+			return fmt.Errorf("failed to find key %s in ConfigMap %s/%s", metrics.AuthConfigMapClientCAKey, metrics.AuthConfigMapNamespace, metrics.AuthConfigMapName)
+		}
+		// Send the CA bundle to the metrics server for client authentication.
+		metrics.DumpCA(ca)
 
 		return nil
 
@@ -374,6 +442,32 @@ func (c *Controller) sync(key wqKeyKube) error {
 	return nil
 }
 
+// maintenanceWindowOpen returns true if the maintenance window 'window' is open.
+// If it is not, the Profile is requeued to be synced again when the next
+// maintenance window opens.
+func (c *Controller) maintenanceWindowOpen(key wqKeyKube, window *tunedv1.TunedMaintenanceWindow) bool {
+	if window == nil {
+		// This should not happen, the operator falls back to util.DeferAlways without a maintenance window.
+		klog.Warningf("deferred mode %q without a maintenance window", util.DeferWindow)
+		return false
+	}
+
+	now := time.Now()
+	open, next, err := util.MaintenanceWindowNext(window.Schedule, window.Duration.Duration, now)
+	if err != nil {
+		klog.Errorf("failed to evaluate the maintenance window: %v", err)
+		return false
+	}
+	if open {
+		klog.V(2).Infof("maintenance window opened at %v is open", next)
+		return true
+	}
+
+	klog.V(2).Infof("maintenance window closed, the next one opens at %v", next)
+	c.wqKube.AddAfter(key, next.Sub(now))
+	return false
+}
+
 func disableSystemTuned() {
 	var (
 		stdout bytes.Buffer
@@ -417,8 +511,10 @@ func ProfilesExtract(profiles []tunedv1.TunedProfile, recommendedProfile string)
 	klog.Infof("profilesExtract(): extracting %d TuneD profiles (recommended=%s)", len(profiles), recommendedProfile)
 	// Get a list of TuneD profiles names the recommended profile depends on.
 	deps := profileDepends(recommendedProfile)
-	// Add the recommended profile itself.
-	deps[recommendedProfile] = true
+	// Add the recommended profile(s) itself.
+	for _, name := range strings.Fields(recommendedProfile) {
+		deps[name] = true
+	}
 	klog.V(2).Infof("profilesExtract(): profile deps: %#v", deps)
 	return profilesExtractPathWithDeps(tunedProfilesDirCustom, profiles, recommendedProfile, deps)
 }
@@ -736,12 +832,12 @@ func overridenSysctl(data string) string {
 }
 
 func (c *Controller) tunedCreateCmd() *exec.Cmd {
-	command, args := TunedCreateCmdline((c.daemon.restart & ctrlDebug) != 0)
+	command, args := TunedCreateCmdline((c.daemon.restart&ctrlDebug) != 0, c.tunedDBus != nil)
 
 	return exec.Command(command, args...)
 }
 
-func (c *Controller) tunedRun() {
+func (c *Controller) tunedRun(cmd *exec.Cmd) {
 	klog.Infof("starting tuned...")
 
 	defer func() {
@@ -750,27 +846,63 @@ func (c *Controller) tunedRun() {
 
 	c.tunedExit = make(chan bool) // Once tunedStop() terminates, the tunedExit channel is closed!
 
-	onDaemonReload := func() {
-		// Notify the event processor that the TuneD daemon finished reloading and that we might need to update Profile status.
-		c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: Change{
-			profileStatus: true,
-			tunedReload:   true,
-		}})
-	}
-
-	err := TunedRun(c.tunedCmd, &c.daemon, onDaemonReload)
+	err := TunedRun(cmd, &c.daemon, c.onDaemonReload)
 	if err != nil {
 		klog.Errorf("Error while running tuned %v", err)
 	}
+	if cmd.ProcessState != nil && !c.daemon.stopping.Load() {
+		// The TuneD process ran and exited without being asked to.
+		metrics.TunedCrashed()
+	}
+}
+
+// onDaemonReload notifies the event processor that the TuneD daemon finished
+// reloading and that we might need to update Profile status.
+func (c *Controller) onDaemonReload() {
+	metrics.TunedProfileApplied(time.Since(c.daemon.applyStart), (c.daemon.status&scApplied) != 0 && (c.daemon.status&scError) == 0)
+	c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: Change{
+		profileStatus: true,
+		tunedReload:   true,
+	}})
+}
+
+// daemonProfileChanged processes the profile_changed signal 'pc' emitted by
+// the TuneD daemon over D-Bus.
+func (c *Controller) daemonProfileChanged(pc tunedProfileChanged) {
+	klog.V(1).Infof("TuneD profile_changed signal: profile=%q result=%v message=%q", pc.profile, pc.result, pc.message)
+
+	if c.daemon.stopping.Load() {
+		return
+	}
+	// From now on, track the state of the TuneD daemon by the D-Bus signals.
+	c.daemon.dbusActive.Store(true)
+
+	if pc.result {
+		c.daemon.status |= scApplied
+		if info, err := c.tunedDBus.profileInfo(pc.profile); err != nil {
+			klog.V(2).Infof("%v", err)
+		} else {
+			klog.V(2).Infof("applied TuneD profile %q: %s", info.Name, info.Summary)
+		}
+	} else {
+		c.daemon.status |= scError
+		c.daemon.stderr = pc.message
+		c.daemon.diagnostics.add(tunedv1.DiagnosticSeverityError, "", pc.message, time.Now())
+	}
+
+	if (c.daemon.status & scReloading) != 0 {
+		// TuneD has finished reloading.
+		c.daemon.status &= ^scReloading
+		c.onDaemonReload()
+	}
 }
 
 // tunedStop tries to gracefully stop the TuneD daemon process by sending it SIGTERM.
 // If the TuneD daemon does not respond by terminating within tunedGracefulExitWait
 // duration, SIGKILL is sent.
 func (c *Controller) tunedStop() error {
-	c.daemon.stopping = true
 	defer func() {
-		c.daemon.stopping = false
+		c.daemon.stopping.Store(false)
 		c.tunedCmd = nil // Cmd.Start() cannot be used more than once
 	}()
 
@@ -783,11 +915,13 @@ func (c *Controller) tunedStop() error {
 		klog.V(1).Infof("sending SIGTERM to PID %d", c.tunedCmd.Process.Pid)
 		if err := c.tunedCmd.Process.Signal(syscall.SIGTERM); err != nil {
 			if errors.Is(err, os.ErrProcessDone) {
-				// The TuneD process has already finished.
+				// The TuneD process has already finished on its own, see tunedRun().
 				return nil
 			}
 			return fmt.Errorf("failed to signal TuneD process: %v", err)
 		}
+		// The TuneD process exits as asked to from now on.
+		c.daemon.stopping.Store(true)
 	} else {
 		// This should never happen!
 		return fmt.Errorf("cannot find the TuneD process!")
@@ -820,11 +954,18 @@ func (c *Controller) tunedReload() error {
 	c.daemon.status = 0 // clear the set out of which Profile status conditions are created
 	c.daemon.status |= scReloading
 	c.daemon.stderr = ""
+	c.daemon.diagnostics.reset()
 	c.daemon.restart &= ^ctrlReload
+	c.daemon.applyStart = time.Now()
 
 	tunedStart := func() {
+		if c.tunedStarted {
+			metrics.TunedRestarted()
+		}
+		c.tunedStarted = true
 		c.tunedCmd = c.tunedCreateCmd()
-		go c.tunedRun()
+		c.daemon.dbusActive.Store(false) // a new TuneD process, wait for its first D-Bus signal
+		go c.tunedRun(c.tunedCmd)
 	}
 
 	if c.tunedCmd == nil {
@@ -833,6 +974,14 @@ func (c *Controller) tunedReload() error {
 		return nil
 	}
 
+	if c.daemon.dbusActive.Load() {
+		// The result is processed by changeWatcher().
+		klog.Infof("reloading tuned via D-Bus...")
+		c.tunedDBus.reload()
+		metrics.TunedReloaded()
+		return nil
+	}
+
 	klog.Infof("reloading tuned...")
 
 	if c.tunedCmd.Process != nil {
@@ -848,6 +997,7 @@ func (c *Controller) tunedReload() error {
 			}
 			return fmt.Errorf("error sending SIGHUP to PID %d: %v\n", c.tunedCmd.Process.Pid, err)
 		}
+		metrics.TunedReloaded()
 	} else {
 		// This should never happen!
 		return fmt.Errorf("cannot find the TuneD process!")
@@ -866,10 +1016,21 @@ func (c *Controller) tunedRestart() (err error) {
 		return err
 	}
 
-	if err = c.tunedReload(); err != nil {
-		return err
+	// The restart is counted by tunedReload() starting the TuneD daemon.
+	return c.tunedReload()
+}
+
+// activeProfile returns the profile currently in use by the TuneD daemon.
+// The TuneD D-Bus API is preferred over reading tunedActiveProfileFile.
+func (c *Controller) activeProfile() (string, error) {
+	if c.daemon.dbusActive.Load() {
+		profile, err := c.tunedDBus.activeProfile()
+		if err == nil {
+			return profile, nil
+		}
+		klog.Warningf("%v; falling back to reading %s", err, tunedActiveProfileFile)
 	}
-	return nil
+	return getActiveProfile()
 }
 
 // getActiveProfile returns active profile currently in use by the TuneD daemon.
@@ -940,7 +1101,80 @@ func (c *Controller) changeSyncerPostReloadOrRestart(change Change) {
 	klog.V(2).Infof("changeSyncerPostReloadOrRestart(): current effective profile fingerprint %q -> %q", c.daemon.profileFingerprintEffective, profileFP)
 
 	c.daemon.profileFingerprintEffective = profileFP
-	c.daemon.status &= ^scDeferred // force clear even if it was never set.
+	c.daemon.status &= ^(scDeferred | scDeferredWindow) // force clear even if it was never set.
+	if c.daemon.drift != nil && !c.daemon.drift.reapplied {
+		// A different tuning might be in effect now, verify it again.
+		c.daemon.drift = nil
+	}
+}
+
+// changeSyncerVerify verifies the tuning applied by the TuneD daemon did not
+// drift and requests a TuneD reload to re-apply the profile if configured so.
+// Reschedules the next verification.
+func (c *Controller) changeSyncerVerify(change Change) (synced bool) {
+	klog.V(2).Infof("changeSyncerVerify(%s)", change.String())
+	defer klog.V(2).Infof("changeSyncerVerify(%s) done", change.String())
+
+	if !change.verify {
+		return true
+	}
+
+	profile, err := c.listers.TunedProfiles.Get(c.nodeName)
+	if err != nil {
+		klog.Errorf("failed to get Profile %s: %v", c.nodeName, err)
+		return false
+	}
+	dd := profile.Spec.Config.DriftDetection
+	if dd == nil {
+		// Drift detection was turned off.
+		c.daemon.drift = nil
+		return true
+	}
+	defer c.wqTuneD.AddAfter(wqKeyTuned{kind: wqKindDaemon, change: Change{verify: true}}, driftDetectionInterval(dd))
+
+	if (c.daemon.status&scApplied) == 0 || (c.daemon.status&scDeferred) != 0 {
+		// Nothing to compare against yet or a different tuning is expected.
+		klog.V(2).Infof("changeSyncerVerify(): TuneD profile not applied or update deferred, skipping verification")
+		return true
+	}
+
+	ep, err := ResolveEffectiveProfile(profile.Spec.Profile, c.daemon.recommendedProfile, tunedProfilesDirSystem)
+	if err != nil {
+		klog.Errorf("failed to resolve the effective TuneD profile %q: %v", c.daemon.recommendedProfile, err)
+		return true
+	}
+	activeProfile, err := c.activeProfile()
+	if err != nil {
+		klog.Errorf("%s", err.Error())
+		return true
+	}
+
+	drift := &driftStatus{keys: verifyProfile(ep, activeProfile)}
+	if c.daemon.dbusActive.Load() {
+		// Also use the TuneD's own verification which covers all the TuneD plugins.
+		if ok, err := c.tunedDBus.verifyProfile(); err != nil {
+			klog.Warningf("%v", err)
+		} else if !ok {
+			drift.keys = append(drift.keys, "verify_profile=false (expected true)")
+		}
+	}
+	if len(drift.keys) > 0 {
+		klog.Warningf("runtime tuning drifted from TuneD profile %q: %s", ep.Name, driftMessage(drift.keys))
+		if dd.Reapply {
+			klog.Infof("re-applying TuneD profile %q", ep.Name)
+			drift.reapplied = true
+			c.daemon.restart |= ctrlReload
+		}
+	} else {
+		klog.V(2).Infof("no drift from TuneD profile %q detected", ep.Name)
+	}
+	c.daemon.drift = drift
+
+	if err := c.updateTunedProfileStatus(context.TODO(), change); err != nil {
+		klog.Error(err.Error())
+		return false // retry later
+	}
+	return true
 }
 
 func (c *Controller) changeSyncerProfileStatus(change Change) (synced bool) {
@@ -1023,6 +1257,9 @@ func (c *Controller) changeSyncerTuneD(change Change) (synced bool, err error) {
 		} else if util.IsImmediateUpdate(change.deferredMode) && (c.daemon.status&scDeferred != 0) {
 			klog.V(1).Infof("detected deferred update changed to immediate after object update")
 			reload = true
+		} else if change.windowOpen && (c.daemon.status&scDeferred != 0) {
+			klog.V(1).Infof("maintenance window open; applying the deferred update")
+			reload = true
 		} else {
 			klog.V(1).Infof("recommended profile (%s) matches current configuration", c.daemon.recommendedProfile)
 			// We do not need to reload the TuneD daemon, however, someone may have tampered with the k8s Profile status for this node.
@@ -1107,6 +1344,9 @@ func treatAsImmediate(change Change, inplaceUpdate bool) (bool, string) {
 	if !inplaceUpdate && change.deferredMode == util.DeferUpdate {
 		return true, "recommended profile change with deferredMode=" + util.DeferUpdate.String()
 	}
+	if change.deferredMode == util.DeferWindow && change.windowOpen {
+		return true, "maintenance window open"
+	}
 	return false, ""
 }
 
@@ -1117,6 +1357,12 @@ func (c *Controller) handleDaemonReloadRestartRequest(change Change, reload, res
 	}
 
 	if ok, reason := treatAsImmediate(change, inplaceUpdate); ok {
+		if change.windowOpen {
+			// The deferred update (if any) is about to be applied, it no longer needs to wait for a node restart.
+			if err := c.clearDeferredUpdate(); err != nil {
+				klog.Errorf("failed to clear the pending deferred update: %v", err)
+			}
+		}
 		if reload {
 			klog.V(2).Infof("%s: setting reload flag", reason)
 			c.daemon.restart |= ctrlReload
@@ -1137,7 +1383,7 @@ func (c *Controller) handleDaemonReloadRestartRequest(change Change, reload, res
 
 	err := c.storeDeferredUpdate(c.daemon.profileFingerprintUnpacked)
 	// on restart, we will have the deferred flag but the profileFingerprint will match. So the change must take effect immediately
-	klog.Infof("deferred update: TuneD daemon won't be reloaded until next restart or immediate update (err=%v)", err)
+	klog.Infof("deferred update: TuneD daemon won't be reloaded until next restart, maintenance window or immediate update (err=%v)", err)
 	if err != nil {
 		return err
 	}
@@ -1178,6 +1424,11 @@ func (c *Controller) changeSyncer(change Change) (bool, error) {
 		return false, nil
 	}
 
+	// Verify the applied tuning did not drift.
+	if !c.changeSyncerVerify(change) {
+		return false, nil
+	}
+
 	// Extract TuneD configuration from k8s objects and restart/reload TuneD as needed.
 	return c.changeSyncerTuneD(change)
 }
@@ -1324,7 +1575,7 @@ func (c *Controller) updateTunedProfile(change Change) (err error) {
 }
 
 func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change) error {
-	activeProfile, err := getActiveProfile()
+	activeProfile, err := c.activeProfile()
 	if err != nil {
 		return err
 	}
@@ -1334,14 +1585,22 @@ func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change
 		return fmt.Errorf("failed to get Profile %s: %v", c.nodeName, err)
 	}
 
-	var message string
-	wantsDeferred := util.IsDeferredUpdate(util.GetDeferredUpdateAnnotation(profile.Annotations))
+	var (
+		message       string
+		nextApplyTime *metav1.Time
+	)
+	deferredMode := util.GetDeferredUpdateAnnotation(profile.Annotations)
+	wantsDeferred := util.IsDeferredUpdate(deferredMode)
 	isApplied := (c.daemon.profileFingerprintUnpacked == c.daemon.profileFingerprintEffective)
 	daemonStatus := c.daemon.status
 
 	klog.V(4).Infof("daemonStatus(): change: deferred=%v applied=%v nodeRestart=%v", wantsDeferred, isApplied, change.nodeRestart)
 	if (wantsDeferred && !isApplied) && !change.nodeRestart { // avoid setting the flag on updates deferred -> immediate
 		daemonStatus |= scDeferred
+		if deferredMode == util.DeferWindow {
+			daemonStatus |= scDeferredWindow
+			nextApplyTime = maintenanceWindowNextApplyTime(profile.Spec.Config.MaintenanceWindow)
+		}
 		recommendProfile, err := TunedRecommendFileRead()
 		if err == nil {
 			klog.V(2).Infof("updateTunedProfileStatus(): recommended profile %q (deferred)", recommendProfile)
@@ -1353,11 +1612,23 @@ func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change
 	}
 
 	statusConditions := computeStatusConditions(daemonStatus, message, profile.Status.Conditions)
+	if profile.Spec.Config.DriftDetection == nil {
+		statusConditions = RemoveStatusCondition(statusConditions, tunedv1.TunedDrifted)
+	} else if c.daemon.drift != nil {
+		statusConditions = SetStatusCondition(statusConditions, computeDriftedCondition(c.daemon.drift))
+	}
 	klog.V(4).Infof("computed status conditions: %#v", statusConditions)
 	c.daemon.status = daemonStatus
+	metrics.TunedDeferredUpdatePending((daemonStatus & scDeferred) != 0)
+	diagnostics := c.daemon.diagnostics.list()
+	hugePages := numaHugePages()
 
 	if profile.Status.TunedProfile == activeProfile &&
-		ConditionsEqual(profile.Status.Conditions, statusConditions) {
+		ConditionsEqual(profile.Status.Conditions, statusConditions) &&
+		profile.Status.NextApplyTime.Equal(nextApplyTime) &&
+		equality.Semantic.DeepEqual(profile.Status.Diagnostics, diagnostics) &&
+		profile.Status.NUMANodes == c.numaNodes &&
+		equality.Semantic.DeepEqual(profile.Status.HugePages, hugePages) {
 		klog.V(2).Infof("updateTunedProfileStatus(): no need to update status of Profile %s", profile.Name)
 		return nil
 	}
@@ -1367,6 +1638,10 @@ func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change
 	profile.Status.TunedProfile = activeProfile
 	profile.Status.Conditions = statusConditions
 	profile.Status.ObservedGeneration = profile.Generation
+	profile.Status.NextApplyTime = nextApplyTime
+	profile.Status.Diagnostics = diagnostics
+	profile.Status.NUMANodes = c.numaNodes
+	profile.Status.HugePages = hugePages
 	_, err = c.clients.Tuned.TunedV1().Profiles(operandNamespace).UpdateStatus(ctx, profile, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to update Profile %s status: %v", profile.Name, err)
@@ -1374,6 +1649,20 @@ func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change
 	return nil
 }
 
+// maintenanceWindowNextApplyTime returns the time a deferred update waiting for
+// the maintenance window 'window' is going to be applied at or nil if unknown.
+func maintenanceWindowNextApplyTime(window *tunedv1.TunedMaintenanceWindow) *metav1.Time {
+	if window == nil {
+		return nil
+	}
+	_, next, err := util.MaintenanceWindowNext(window.Schedule, window.Duration.Duration, time.Now())
+	if err != nil {
+		klog.Errorf("failed to evaluate the maintenance window: %v", err)
+		return nil
+	}
+	return &metav1.Time{Time: next}
+}
+
 // storeDeferredUpdate sets the node state (on storage, like disk) to signal
 // there is a deferred update pending.
 func (c *Controller) storeDeferredUpdate(deferredFP string) (derr error) {
@@ -1411,6 +1700,17 @@ func (c *Controller) storeDeferredUpdate(deferredFP string) (derr error) {
 	return os.Rename(tmpName, tunedDeferredUpdatePersistentFilePath)
 }
 
+// clearDeferredUpdate removes the node state (on storage, like disk) signalling
+// there is a deferred update pending.
+func (c *Controller) clearDeferredUpdate() error {
+	for _, path := range []string{tunedDeferredUpdatePersistentFilePath, tunedDeferredUpdateEphemeralFilePath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 // recoverAndClearDeferredUpdate detects the presence and removes the persistent
 // deferred updates file.
 // Returns:
@@ -1528,9 +1828,24 @@ func (c *Controller) changeWatcher() (err error) {
 		return err
 	}
 
-	tunedInformerFactory.Start(c.stopCh) // Tuned/Profile
+	caConfigMapInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(c.clients.Kube,
+		ntoconfig.ResyncPeriod(),
+		kubeinformers.WithNamespace(metrics.AuthConfigMapNamespace),
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = "metadata.name=" + metrics.AuthConfigMapName
+		}))
+	caInformer := caConfigMapInformerFactory.Core().V1().ConfigMaps()
+	c.listers.AuthConfigMapCA = caInformer.Lister().ConfigMaps(metrics.AuthConfigMapNamespace)
+	if _, err = caInformer.Informer().AddEventHandler(c.informerEventHandler(wqKeyKube{kind: wqKindConfigMap})); err != nil {
+		return err
+	}
+
+	tunedInformerFactory.Start(c.stopCh)       // Tuned/Profile
+	caConfigMapInformerFactory.Start(c.stopCh) // metrics client CA ConfigMap
 
-	// Wait for the caches to be synced before starting worker(s).
+	// Wait for the caches to be synced before starting worker(s).  The metrics
+	// client CA ConfigMap is not waited for, the metrics server starts serving
+	// once the CA arrives.
 	klog.V(1).Info("waiting for informer caches to sync")
 	ok := cache.WaitForCacheSync(c.stopCh,
 		tpInformer.Informer().HasSynced,
@@ -1568,6 +1883,16 @@ func (c *Controller) changeWatcher() (err error) {
 		klog.Infof("monitoring filesystem events on %q", element)
 	}
 
+	// Signals and results of asynchronous calls from the TuneD D-Bus API; nil channels block forever.
+	var (
+		dbusSignals chan *dbus.Signal
+		dbusReloads chan *dbus.Call
+	)
+	if c.tunedDBus != nil {
+		dbusSignals = c.tunedDBus.signals
+		dbusReloads = c.tunedDBus.reloads
+	}
+
 	klog.Info("started controller")
 	for {
 		select {
@@ -1587,6 +1912,20 @@ func (c *Controller) changeWatcher() (err error) {
 		case err := <-wFs.Errors:
 			return fmt.Errorf("error watching filesystem: %v", err)
 
+		case sig := <-dbusSignals:
+			if pc, ok := parseProfileChanged(sig); ok {
+				c.daemonProfileChanged(pc)
+			}
+
+		case call := <-dbusReloads:
+			if err := reloadResult(call); err != nil {
+				klog.Errorf("%v; falling back to TuneD log parsing", err)
+				c.daemon.dbusActive.Store(false)
+				if err := c.tunedReload(); err != nil {
+					return err
+				}
+			}
+
 		case ch := <-c.changeCh:
 			klog.V(2).Infof("changeCh")
 
@@ -1663,7 +2002,7 @@ func retryLoop(c *Controller) (err error) {
 	}
 }
 
-func RunInCluster(stopCh <-chan struct{}, version string) error {
+func RunInCluster(stopCh <-chan struct{}, version string, useDBus bool) error {
 	klog.Infof("starting in-cluster %s %s", programName, version)
 
 	dirs := []string{
@@ -1705,6 +2044,28 @@ func RunInCluster(stopCh <-chan struct{}, version string) error {
 		panic(err.Error())
 	}
 
+	metrics.EnableAuth(c.clients.Kube, operandNamespace)
+	metrics.SetAuthConfig(metrics.AuthConfigFromEnv())
+	metricsCtx, metricsCancel := context.WithCancel(context.Background())
+	defer metricsCancel()
+	go func() {
+		<-stopCh
+		metricsCancel()
+	}()
+	go func() {
+		if err := metrics.RunOperandServer(metricsCtx); err != nil {
+			klog.Errorf("failed to run the metrics server: %v", err)
+		}
+	}()
+
+	if useDBus {
+		if c.tunedDBus, err = tunedDBusConnect(); err != nil {
+			klog.Errorf("unable to use the TuneD D-Bus API, falling back to TuneD log parsing: %v", err)
+		} else {
+			defer c.tunedDBus.close()
+		}
+	}
+
 	profiles, recommended, err := profilesRepackPath(tunedRecommendFile, tunedProfilesDirCustom)
 	if err != nil {
 		// keep going, immediate updates are expected to work as usual
@@ -292,11 +292,13 @@ func fullChange() Change {
 		profileStatus:      true,
 		tunedReload:        true,
 		nodeRestart:        true,
+		verify:             true,
 		debug:              true,
 		provider:           "test-provider",
 		reapplySysctl:      true,
 		recommendedProfile: "test-profile",
-		deferredMode:       util.DeferAlways,
+		deferredMode:       util.DeferWindow,
+		windowOpen:         true,
 		message:            "test-message",
 	}
 }
@@ -0,0 +1,151 @@
+package tuned
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Constants of the TuneD daemon D-Bus API.
+const (
+	tunedDBusName      = "com.redhat.tuned"
+	tunedDBusPath      = dbus.ObjectPath("/Tuned")
+	tunedDBusInterface = "com.redhat.tuned.control"
+
+	tunedDBusSignalProfileChanged = tunedDBusInterface + ".profile_changed"
+)
+
+// tunedDBus is a client of the TuneD daemon D-Bus API.
+type tunedDBus struct {
+	conn *dbus.Conn
+	obj  dbus.BusObject
+	// Signals emitted by the TuneD daemon.
+	signals chan *dbus.Signal
+	// Completed asynchronous calls of the reload method.
+	reloads chan *dbus.Call
+}
+
+// tunedProfileInfo is the information about a TuneD profile as returned by
+// the profile_info method.
+type tunedProfileInfo struct {
+	Success     bool
+	Name        string
+	Summary     string
+	Description string
+}
+
+// tunedProfileChanged is the content of the profile_changed signal emitted
+// by the TuneD daemon after a TuneD profile application.
+type tunedProfileChanged struct {
+	// Name of the applied TuneD profile.
+	profile string
+	// Was the TuneD profile successfully applied?
+	result bool
+	// Error message if the application failed.
+	message string
+}
+
+// tunedDBusConnect connects to the TuneD daemon D-Bus API on the system bus.
+func tunedDBusConnect() (*tunedDBus, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %v", err)
+	}
+
+	t, err := newTunedDBus(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// newTunedDBus returns a TuneD daemon D-Bus API client using connection 'conn'
+// and subscribes to the TuneD daemon signals.
+func newTunedDBus(conn *dbus.Conn) (*tunedDBus, error) {
+	err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(tunedDBusPath),
+		dbus.WithMatchInterface(tunedDBusInterface),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to TuneD D-Bus signals: %v", err)
+	}
+
+	t := &tunedDBus{
+		conn:    conn,
+		obj:     conn.Object(tunedDBusName, tunedDBusPath),
+		signals: make(chan *dbus.Signal, 16),
+		reloads: make(chan *dbus.Call, 1),
+	}
+	conn.Signal(t.signals)
+
+	return t, nil
+}
+
+func (t *tunedDBus) close() error {
+	return t.conn.Close()
+}
+
+// activeProfile returns the TuneD profile currently in use by the TuneD daemon.
+func (t *tunedDBus) activeProfile() (string, error) {
+	var profile string
+	if err := t.obj.Call(tunedDBusInterface+".active_profile", 0).Store(&profile); err != nil {
+		return "", fmt.Errorf("failed to call TuneD active_profile: %v", err)
+	}
+	return profile, nil
+}
+
+// profileInfo returns information about TuneD profile 'profile'.
+func (t *tunedDBus) profileInfo(profile string) (tunedProfileInfo, error) {
+	var info tunedProfileInfo
+	if err := t.obj.Call(tunedDBusInterface+".profile_info", 0, profile).Store(&info); err != nil {
+		return info, fmt.Errorf("failed to call TuneD profile_info: %v", err)
+	}
+	if !info.Success {
+		return info, fmt.Errorf("failed to get information about TuneD profile %q", profile)
+	}
+	return info, nil
+}
+
+// verifyProfile returns true if the TuneD daemon verified the tuning of the
+// current TuneD profile is in effect.
+func (t *tunedDBus) verifyProfile() (bool, error) {
+	var ok bool
+	if err := t.obj.Call(tunedDBusInterface+".verify_profile", 0).Store(&ok); err != nil {
+		return false, fmt.Errorf("failed to call TuneD verify_profile: %v", err)
+	}
+	return ok, nil
+}
+
+// reload asks the TuneD daemon to reload its configuration and re-apply the
+// TuneD profile.  The call is asynchronous, the completed call is sent to the
+// 'reloads' channel.
+func (t *tunedDBus) reload() {
+	t.obj.Go(tunedDBusInterface+".reload", 0, t.reloads)
+}
+
+// reloadResult returns the result of a completed reload 'call'.
+func reloadResult(call *dbus.Call) error {
+	var ok bool
+	if err := call.Store(&ok); err != nil {
+		return fmt.Errorf("failed to call TuneD reload: %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("TuneD failed to reload")
+	}
+	return nil
+}
+
+// parseProfileChanged returns the content of the profile_changed signal 'sig'
+// and true, or false if 'sig' is not a valid profile_changed signal.
+func parseProfileChanged(sig *dbus.Signal) (tunedProfileChanged, bool) {
+	var pc tunedProfileChanged
+
+	if sig == nil || sig.Name != tunedDBusSignalProfileChanged || sig.Path != tunedDBusPath {
+		return pc, false
+	}
+	if err := dbus.Store(sig.Body, &pc.profile, &pc.result, &pc.message); err != nil {
+		return pc, false
+	}
+	return pc, true
+}
@@ -0,0 +1,281 @@
+package tuned
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const dbusDaemonConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>custom</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// fakeTuneD is a fake TuneD daemon D-Bus service.
+type fakeTuneD struct {
+	conn     *dbus.Conn
+	profile  string
+	verified bool
+
+	// mu guards the result of the next profile application, which is read
+	// by the D-Bus method handlers.
+	mu      sync.Mutex
+	result  bool
+	message string
+}
+
+// setResult sets the result of the next profile application.
+func (f *fakeTuneD) setResult(result bool, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.result, f.message = result, message
+}
+
+func (f *fakeTuneD) ActiveProfile() (string, *dbus.Error) {
+	return f.profile, nil
+}
+
+func (f *fakeTuneD) ProfileInfo(profile string) (tunedProfileInfo, *dbus.Error) {
+	if profile != f.profile {
+		return tunedProfileInfo{Success: false}, nil
+	}
+	return tunedProfileInfo{Success: true, Name: profile, Summary: "fake profile", Description: ""}, nil
+}
+
+func (f *fakeTuneD) VerifyProfile() (bool, *dbus.Error) {
+	return f.verified, nil
+}
+
+func (f *fakeTuneD) Reload() (bool, *dbus.Error) {
+	f.mu.Lock()
+	result, message := f.result, f.message
+	f.mu.Unlock()
+	if err := f.conn.Emit(tunedDBusPath, tunedDBusSignalProfileChanged, f.profile, result, message); err != nil {
+		return false, dbus.MakeFailedError(err)
+	}
+	return result, nil
+}
+
+// startDBusDaemon starts a private D-Bus message bus and returns its address.
+func startDBusDaemon(t *testing.T) string {
+	t.Helper()
+
+	command, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found")
+	}
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "bus.conf")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf(dbusDaemonConfig, filepath.Join(dir, "bus.sock"))), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(command, "--config-file="+config, "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("failed to read the dbus-daemon address: %v", err)
+	}
+	return strings.TrimSpace(address)
+}
+
+// newFakeTuneD connects the fake TuneD daemon service 'f' and its client to
+// the private D-Bus message bus at 'address'.
+func newFakeTuneD(t *testing.T, address string, f *fakeTuneD) *tunedDBus {
+	t.Helper()
+
+	var err error
+	if f.conn, err = dbus.Connect(address); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.conn.Close() })
+
+	methods := map[string]string{
+		"ActiveProfile": "active_profile",
+		"ProfileInfo":   "profile_info",
+		"VerifyProfile": "verify_profile",
+		"Reload":        "reload",
+	}
+	if err := f.conn.ExportWithMap(f, methods, tunedDBusPath, tunedDBusInterface); err != nil {
+		t.Fatal(err)
+	}
+	if reply, err := f.conn.RequestName(tunedDBusName, dbus.NameFlagDoNotQueue); err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("failed to request name %s: %v", tunedDBusName, err)
+	}
+
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := newTunedDBus(conn)
+	if err != nil {
+		conn.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.close() })
+
+	return client
+}
+
+// receiveProfileChanged waits for the next profile_changed signal on 'client'.
+func receiveProfileChanged(t *testing.T, client *tunedDBus) tunedProfileChanged {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case sig := <-client.signals:
+			if pc, ok := parseProfileChanged(sig); ok {
+				return pc
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the profile_changed signal")
+		}
+	}
+}
+
+func TestTunedDBus(t *testing.T) {
+	address := startDBusDaemon(t)
+	f := &fakeTuneD{profile: "openshift-node", verified: true, result: true}
+	client := newFakeTuneD(t, address, f)
+
+	profile, err := client.activeProfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile != f.profile {
+		t.Errorf("active_profile: want %q, have %q", f.profile, profile)
+	}
+
+	info, err := client.profileInfo(f.profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != f.profile || info.Summary != "fake profile" {
+		t.Errorf("profile_info: unexpected %+v", info)
+	}
+	if _, err := client.profileInfo("missing"); err == nil {
+		t.Errorf("profile_info: expected an error for a missing profile")
+	}
+
+	ok, err := client.verifyProfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("verify_profile: want true, have false")
+	}
+
+	client.reload()
+	select {
+	case call := <-client.reloads:
+		if err := reloadResult(call); err != nil {
+			t.Errorf("reload: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reload call")
+	}
+	if pc := receiveProfileChanged(t, client); pc != (tunedProfileChanged{profile: f.profile, result: true}) {
+		t.Errorf("profile_changed: unexpected %+v", pc)
+	}
+
+	message := "Requested profile 'openshift-node' doesn't exist."
+	f.setResult(false, message)
+	client.reload()
+	if err := reloadResult(<-client.reloads); err == nil {
+		t.Errorf("reload: expected an error")
+	}
+	if pc := receiveProfileChanged(t, client); pc != (tunedProfileChanged{profile: f.profile, result: false, message: message}) {
+		t.Errorf("profile_changed: unexpected %+v", pc)
+	}
+}
+
+func TestDaemonProfileChanged(t *testing.T) {
+	address := startDBusDaemon(t)
+	f := &fakeTuneD{profile: "openshift-node"}
+	client := newFakeTuneD(t, address, f)
+
+	tests := []struct {
+		name     string
+		pc       tunedProfileChanged
+		status   Bits
+		stderr   string
+		queueLen int
+	}{
+		{
+			name:     "applied",
+			pc:       tunedProfileChanged{profile: "openshift-node", result: true},
+			status:   scApplied,
+			queueLen: 1,
+		},
+		{
+			name:     "failed",
+			pc:       tunedProfileChanged{profile: "missing", result: false, message: "Requested profile 'missing' doesn't exist."},
+			status:   scError,
+			stderr:   "Requested profile 'missing' doesn't exist.",
+			queueLen: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Controller{
+				tunedDBus: client,
+				wqTuneD:   workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[wqKeyTuned]()),
+			}
+			defer c.wqTuneD.ShutDown()
+			c.daemon.status = scReloading
+
+			c.daemonProfileChanged(tc.pc)
+
+			if !c.daemon.dbusActive.Load() {
+				t.Errorf("expected the D-Bus API to be active")
+			}
+			if c.daemon.status != tc.status {
+				t.Errorf("status: want %v, have %v", tc.status, c.daemon.status)
+			}
+			if c.daemon.stderr != tc.stderr {
+				t.Errorf("stderr: want %q, have %q", tc.stderr, c.daemon.stderr)
+			}
+			if c.wqTuneD.Len() != tc.queueLen {
+				t.Errorf("queue length: want %d, have %d", tc.queueLen, c.wqTuneD.Len())
+			}
+
+			// A signal without a pending reload must not trigger a Profile status update.
+			c.daemonProfileChanged(tc.pc)
+			if c.wqTuneD.Len() != tc.queueLen {
+				t.Errorf("queue length: want %d, have %d", tc.queueLen, c.wqTuneD.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package tuned
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	// Maximum number of TuneD diagnostics published in Profile status.
+	diagnosticsMax = 20
+	// Prefix of the TuneD plugin module names, e.g. "tuned.plugins.plugin_sysctl".
+	tunedPluginModulePrefix = "tuned.plugins.plugin_"
+)
+
+// tunedDiagnostics is a bounded and deduplicated list of warnings and errors
+// issued by the TuneD daemon.  The TuneD log is parsed in a separate goroutine,
+// hence the lock.
+type tunedDiagnostics struct {
+	mu    sync.Mutex
+	items []tunedv1.TunedDiagnostic
+}
+
+// add records a message issued by the TuneD daemon at time 'now'.  Repeated
+// messages only increase the count of the first occurrence.  When the list is
+// full, the oldest warning makes room for the new message; errors are only
+// replaced by newer errors.
+func (d *tunedDiagnostics) add(severity tunedv1.DiagnosticSeverity, plugin, message string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := range d.items {
+		item := &d.items[i]
+		if item.Severity == severity && item.Plugin == plugin && item.Message == message {
+			item.Count++
+			return
+		}
+	}
+
+	if len(d.items) >= diagnosticsMax {
+		evict := -1
+		for i := range d.items {
+			if d.items[i].Severity == tunedv1.DiagnosticSeverityWarning {
+				evict = i
+				break
+			}
+		}
+		if evict < 0 {
+			if severity == tunedv1.DiagnosticSeverityWarning {
+				// Do not replace errors by warnings.
+				return
+			}
+			evict = 0
+		}
+		d.items = append(d.items[:evict], d.items[evict+1:]...)
+	}
+
+	d.items = append(d.items, tunedv1.TunedDiagnostic{
+		Severity: severity,
+		Plugin:   plugin,
+		Message:  message,
+		Count:    1,
+		// Status timestamps have a second precision, avoid needless Profile status updates.
+		Timestamp: metav1.NewTime(now).Rfc3339Copy(),
+	})
+}
+
+// reset clears the list, e.g. before a new TuneD profile application.
+func (d *tunedDiagnostics) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.items = nil
+}
+
+// list returns a copy of the recorded messages or nil if there are none.
+func (d *tunedDiagnostics) list() []tunedv1.TunedDiagnostic {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.items) == 0 {
+		return nil
+	}
+	items := make([]tunedv1.TunedDiagnostic, len(d.items))
+	copy(items, d.items)
+	return items
+}
+
+// parseTunedLogMessage returns the TuneD plugin (or module) and the message
+// of TuneD log line 'l' following the log level 'level'.
+// Example log line to parse:
+// 2023-02-10 12:57:13,201 ERROR    tuned.plugins.plugin_sysctl: Failed to read sysctl parameter 'kernel.foo', the parameter does not exist
+func parseTunedLogMessage(l, level string) (plugin, message string) {
+	i := strings.Index(l, level)
+	if i < 0 {
+		return "", ""
+	}
+	message = strings.TrimSpace(l[i+len(level):])
+
+	module, rest, found := strings.Cut(message, ": ")
+	if !found || strings.ContainsAny(module, " \t") {
+		// Not a TuneD module name.
+		return "", message
+	}
+	return strings.TrimPrefix(module, tunedPluginModulePrefix), rest
+}
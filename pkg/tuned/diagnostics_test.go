@@ -0,0 +1,101 @@
+package tuned
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestParseTunedLogMessage(t *testing.T) {
+	var tests = []struct {
+		line    string
+		level   string
+		plugin  string
+		message string
+	}{
+		{
+			line:    "2023-02-10 12:57:13,201 ERROR    tuned.plugins.plugin_sysctl: Failed to read sysctl parameter 'kernel.foo', the parameter does not exist",
+			level:   " ERROR ",
+			plugin:  "sysctl",
+			message: "Failed to read sysctl parameter 'kernel.foo', the parameter does not exist",
+		},
+		{
+			line:    "2023-02-10 12:57:13,201 WARNING  tuned.daemon.daemon: Using one shot no daemon mode, most of the functionality will be not available",
+			level:   " WARNING ",
+			plugin:  "tuned.daemon.daemon",
+			message: "Using one shot no daemon mode, most of the functionality will be not available",
+		},
+		{
+			line:    "2023-02-10 12:57:13,201 ERROR    something went wrong: badly",
+			level:   " ERROR ",
+			plugin:  "",
+			message: "something went wrong: badly",
+		},
+		{
+			line:    "2023-02-10 12:57:13,201 INFO     tuned.daemon.daemon: starting tuning",
+			level:   " ERROR ",
+			plugin:  "",
+			message: "",
+		},
+	}
+
+	for _, tc := range tests {
+		plugin, message := parseTunedLogMessage(tc.line, tc.level)
+		if plugin != tc.plugin || message != tc.message {
+			t.Errorf("parseTunedLogMessage(%q): want (%q, %q), have (%q, %q)", tc.line, tc.plugin, tc.message, plugin, message)
+		}
+	}
+}
+
+func TestTunedDiagnostics(t *testing.T) {
+	var d tunedDiagnostics
+	now := time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)
+
+	if d.list() != nil {
+		t.Fatalf("expected no diagnostics")
+	}
+
+	// Duplicates are counted.
+	d.add(tunedv1.DiagnosticSeverityError, "sysctl", "error", now)
+	d.add(tunedv1.DiagnosticSeverityError, "sysctl", "error", now.Add(time.Minute))
+	d.add(tunedv1.DiagnosticSeverityWarning, "sysctl", "error", now)
+	items := d.list()
+	if len(items) != 2 {
+		t.Fatalf("want 2 diagnostics, have %d", len(items))
+	}
+	if items[0].Count != 2 || !items[0].Timestamp.Time.Equal(now.Truncate(time.Second)) {
+		t.Errorf("unexpected first diagnostic %+v", items[0])
+	}
+
+	// Warnings make room for new messages.
+	for i := 0; i < diagnosticsMax-1; i++ {
+		d.add(tunedv1.DiagnosticSeverityError, "cpu", fmt.Sprintf("error %d", i), now)
+	}
+	items = d.list()
+	if len(items) != diagnosticsMax {
+		t.Fatalf("want %d diagnostics, have %d", diagnosticsMax, len(items))
+	}
+	for _, item := range items {
+		if item.Severity != tunedv1.DiagnosticSeverityError {
+			t.Errorf("expected only errors, have %+v", item)
+		}
+	}
+	if items[0].Message != "error" || items[len(items)-1].Message != fmt.Sprintf("error %d", diagnosticsMax-2) {
+		t.Errorf("unexpected diagnostics: first %+v, last %+v", items[0], items[len(items)-1])
+	}
+
+	// Warnings do not replace errors, newer errors replace the oldest ones.
+	d.add(tunedv1.DiagnosticSeverityWarning, "cpu", "warning", now)
+	d.add(tunedv1.DiagnosticSeverityError, "cpu", "last", now)
+	items = d.list()
+	if items[0].Message != "error 0" || items[len(items)-1].Message != "last" {
+		t.Errorf("unexpected diagnostics: first %+v, last %+v", items[0], items[len(items)-1])
+	}
+
+	d.reset()
+	if d.list() != nil {
+		t.Errorf("expected no diagnostics after reset")
+	}
+}
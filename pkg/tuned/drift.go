@@ -0,0 +1,248 @@
+package tuned
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	// Default interval between verifications of the applied tuning.
+	driftDetectionIntervalDefault = 10 * time.Minute
+	// Maximum number of differences listed in the Drifted condition message.
+	driftMessageMaxKeys = 10
+
+	pluginScheduler = "scheduler"
+	pluginSysctl    = "sysctl"
+	pluginSysfs     = "sysfs"
+)
+
+var (
+	// Root of the filesystem with the /proc/sys and /sys trees.  Changed by unit tests only.
+	driftRootDir = "/"
+	// Option in brackets selected among a list of choices, e.g. "always madvise [never]".
+	sysfsSelectedRegex = regexp.MustCompile(`\[([^]]*)\]`)
+	// Options common to all TuneD plugins, these are not tuning targets.
+	pluginOptions = map[string]bool{
+		"cpuinfo_regex":      true,
+		"devices":            true,
+		"devices_udev_regex": true,
+		"enabled":            true,
+		"priority":           true,
+		"replace":            true,
+		"script_post":        true,
+		"script_pre":         true,
+		"type":               true,
+		"uname_regex":        true,
+	}
+)
+
+// driftStatus is the result of the last verification of the tuning applied
+// by the TuneD daemon.
+type driftStatus struct {
+	// Differences found between the applied TuneD profile and the runtime tuning.
+	keys []string
+	// Was the TuneD profile re-applied to correct the drift?
+	reapplied bool
+}
+
+// driftDetectionInterval returns the interval between verifications of the
+// applied tuning as configured by 'dd'.
+func driftDetectionInterval(dd *tunedv1.DriftDetection) time.Duration {
+	if dd == nil || dd.Interval == nil || dd.Interval.Duration <= 0 {
+		return driftDetectionIntervalDefault
+	}
+	return dd.Interval.Duration
+}
+
+// verifyProfile compares the runtime tuning against the effective TuneD
+// profile 'ep'.  Verified are the active TuneD profile 'active', the sysctl
+// and sysfs plugin targets and the IRQ affinities set by the scheduler
+// plugin.  Targets that cannot be read are skipped, the TuneD daemon reports
+// failures to apply them.
+// Returns the list of differences found.
+func verifyProfile(ep *EffectiveProfile, active string) []string {
+	var keys []string
+
+	if active != ep.Name {
+		keys = append(keys, fmt.Sprintf("active_profile=%s (expected %s)", active, ep.Name))
+	}
+
+	for _, section := range ep.Sections {
+		plugin := section.Name
+		enabled := true
+		for _, o := range section.Options {
+			switch o.Name {
+			case "type":
+				plugin = o.Value
+			case "enabled":
+				enabled, _ = strconv.ParseBool(o.Value)
+			}
+		}
+		if !enabled {
+			continue
+		}
+
+		for _, o := range section.Options {
+			if pluginOptions[o.Name] {
+				continue
+			}
+			switch plugin {
+			case pluginSysctl:
+				keys = append(keys, verifyFile(section.Name, o.Name, sysctlPath(o.Name), o.Value)...)
+			case pluginSysfs:
+				matches, _ := filepath.Glob(filepath.Join(driftRootDir, o.Name))
+				for _, path := range matches {
+					key := strings.TrimPrefix(path, strings.TrimSuffix(driftRootDir, "/"))
+					keys = append(keys, verifyFile(section.Name, key, path, o.Value)...)
+				}
+			}
+		}
+		if plugin == pluginScheduler {
+			keys = append(keys, verifyIRQAffinity(section)...)
+		}
+	}
+
+	return keys
+}
+
+// verifyIRQAffinity compares the IRQ affinities against the isolated_cores of
+// the scheduler plugin section 'section'.  As in the TuneD scheduler plugin,
+// the IRQs must not be served by the isolated cores unless irq_process is
+// disabled.  The default IRQ affinity must exclude the isolated cores too
+// ("calc"), match the CPUs listed or be left as is ("ignore") according to
+// default_irq_smp_affinity.  Returns the list of differences found.
+func verifyIRQAffinity(section EffectiveProfileSection) []string {
+	options := map[string]string{}
+	for _, o := range section.Options {
+		options[o.Name] = o.Value
+	}
+	isolated, err := cpulistUnpack(options["isolated_cores"])
+	if err != nil || len(isolated) == 0 {
+		// No isolated cores or unresolved TuneD variables.
+		return nil
+	}
+	isolatedCPUs := map[int]bool{}
+	for _, cpu := range isolated {
+		isolatedCPUs[cpu] = true
+	}
+	servedByIsolated := func(cpus []int) bool {
+		for _, cpu := range cpus {
+			if isolatedCPUs[cpu] {
+				return true
+			}
+		}
+		return false
+	}
+	drifted := func(path string, cpus []int, expected string) string {
+		key := strings.TrimPrefix(path, strings.TrimSuffix(driftRootDir, "/"))
+		return fmt.Sprintf("[%s] %s=%s (expected %s)", section.Name, key, cpulistPack(cpus), expected)
+	}
+	excluded := "excluding " + cpulistPack(isolated)
+
+	var keys []string
+	if irqProcess, err := strconv.ParseBool(options["irq_process"]); err != nil || irqProcess {
+		paths, _ := filepath.Glob(filepath.Join(driftRootDir, "proc/irq/*/smp_affinity_list"))
+		for _, path := range paths {
+			if cpus, err := cpulistFromFile(path); err == nil && servedByIsolated(cpus) {
+				keys = append(keys, drifted(path, cpus, excluded))
+			}
+		}
+	}
+
+	mode := strings.TrimSpace(options["default_irq_smp_affinity"])
+	if mode == "ignore" {
+		return keys
+	}
+	path := filepath.Join(driftRootDir, "proc/irq/default_smp_affinity")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return keys
+	}
+	cpus, err := hex2cpulist(string(content))
+	if err != nil {
+		return keys
+	}
+	switch mode {
+	case "", "calc":
+		if servedByIsolated(cpus) {
+			keys = append(keys, drifted(path, cpus, excluded))
+		}
+	default:
+		if expected, err := cpulistUnpack(mode); err == nil && cpulistPack(expected) != cpulistPack(cpus) {
+			keys = append(keys, drifted(path, cpus, cpulistPack(expected)))
+		}
+	}
+
+	return keys
+}
+
+// sysctlPath returns the /proc/sys path of sysctl 'name'.  As in the TuneD
+// sysctl plugin, dots separate directories and slashes stand for dots.
+func sysctlPath(name string) string {
+	parts := strings.Split(name, "/")
+	for i := range parts {
+		parts[i] = strings.ReplaceAll(parts[i], ".", "/")
+	}
+	return filepath.Join(driftRootDir, "proc/sys", strings.Join(parts, "."))
+}
+
+// verifyFile compares the content of file 'path' with the 'expected' value of
+// option 'key' in section 'section'.  Returns the difference found, if any.
+func verifyFile(section, key, path, expected string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	actual := string(content)
+	if valueMatches(expected, actual) {
+		return nil
+	}
+	return []string{fmt.Sprintf("[%s] %s=%s (expected %s)", section, key, normalizeValue(actual), normalizeValue(expected))}
+}
+
+// valueMatches returns true if the runtime value 'actual' satisfies the value
+// 'expected' set by a TuneD profile.  The "<" and ">" prefixes of the TuneD
+// plugins only lower or raise numeric values, whitespace is not significant and
+// for lists of choices like "always madvise [never]" the selected one counts.
+func valueMatches(expected, actual string) bool {
+	expected = normalizeValue(expected)
+	actual = normalizeValue(actual)
+	if m := sysfsSelectedRegex.FindStringSubmatch(actual); m != nil {
+		actual = m[1]
+	}
+
+	if len(expected) > 0 && (expected[0] == '<' || expected[0] == '>') {
+		e, errE := strconv.ParseInt(strings.TrimSpace(expected[1:]), 0, 64)
+		a, errA := strconv.ParseInt(actual, 0, 64)
+		if errE != nil || errA != nil {
+			// Not a number, nothing to compare.
+			return true
+		}
+		if expected[0] == '<' {
+			return a <= e
+		}
+		return a >= e
+	}
+
+	return expected == actual
+}
+
+// normalizeValue returns 'value' with whitespace sequences replaced by single spaces.
+func normalizeValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// driftMessage returns a human-readable list of the differences 'keys'.
+func driftMessage(keys []string) string {
+	if len(keys) <= driftMessageMaxKeys {
+		return strings.Join(keys, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(keys[:driftMessageMaxKeys], ", "), len(keys)-driftMessageMaxKeys)
+}
@@ -0,0 +1,188 @@
+package tuned
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyProfile(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"proc/sys/kernel/sched_rt_runtime_us":             "-1\n",
+		"proc/sys/vm/swappiness":                          "60\n",
+		"proc/sys/net/ipv4/tcp_rmem":                      "4096\t131072\t6291456\n",
+		"proc/sys/net/ipv4/conf/eth0.100/rp_filter":       "1\n",
+		"proc/sys/kernel/pid_max":                         "4194304\n",
+		"sys/kernel/mm/transparent_hugepage/enabled":      "always madvise [never]\n",
+		"sys/kernel/mm/ksm/run":                           "1\n",
+		"sys/devices/system/cpu/cpu0/cpufreq/energy_perf": "performance\n",
+		"sys/devices/system/cpu/cpu1/cpufreq/energy_perf": "balance_power\n",
+	}
+	for path, content := range files {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldRoot := driftRootDir
+	driftRootDir = root
+	defer func() { driftRootDir = oldRoot }()
+
+	ep := &EffectiveProfile{
+		Name: "openshift-node",
+		Sections: []EffectiveProfileSection{
+			{Name: "main", Options: []EffectiveProfileOption{{Name: "summary", Value: "test"}}},
+			{Name: "sysctl", Options: []EffectiveProfileOption{
+				{Name: "kernel.sched_rt_runtime_us", Value: "-1"},
+				{Name: "vm.swappiness", Value: "10"},
+				{Name: "net.ipv4.tcp_rmem", Value: "4096 131072  6291456"},
+				{Name: "net/ipv4/conf/eth0.100/rp_filter", Value: "1"},
+				{Name: "kernel.pid_max", Value: ">4194304"},
+				{Name: "kernel.missing", Value: "1"},
+			}},
+			{Name: "sysfs", Options: []EffectiveProfileOption{
+				{Name: "/sys/kernel/mm/transparent_hugepage/enabled", Value: "never"},
+				{Name: "/sys/devices/system/cpu/cpu*/cpufreq/energy_perf", Value: "performance"},
+			}},
+			{Name: "ksm", Options: []EffectiveProfileOption{
+				{Name: "type", Value: "sysfs"},
+				{Name: "/sys/kernel/mm/ksm/run", Value: "0"},
+			}},
+			{Name: "disabled", Options: []EffectiveProfileOption{
+				{Name: "type", Value: "sysctl"},
+				{Name: "enabled", Value: "false"},
+				{Name: "vm.swappiness", Value: "0"},
+			}},
+		},
+	}
+
+	expected := []string{
+		"active_profile=openshift-node-custom (expected openshift-node)",
+		"[sysctl] vm.swappiness=60 (expected 10)",
+		"[sysfs] /sys/devices/system/cpu/cpu1/cpufreq/energy_perf=balance_power (expected performance)",
+		"[ksm] /sys/kernel/mm/ksm/run=1 (expected 0)",
+	}
+	actual := verifyProfile(ep, "openshift-node-custom")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("want:\n%q\nhave:\n%q", expected, actual)
+	}
+
+	if actual := verifyProfile(&EffectiveProfile{Name: "openshift-node"}, "openshift-node"); len(actual) != 0 {
+		t.Errorf("expected no drift, have: %q", actual)
+	}
+}
+
+func TestVerifyIRQAffinity(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"proc/irq/default_smp_affinity":  "ff\n",
+		"proc/irq/1/smp_affinity_list":   "0-1\n",
+		"proc/irq/2/smp_affinity_list":   "0-7\n",
+		"proc/irq/3/smp_affinity_list":   "5\n",
+		"proc/irq/4/smp_affinity_list":   "1\n",
+		"proc/irq/bad/smp_affinity_list": "x\n",
+	}
+	for path, content := range files {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldRoot := driftRootDir
+	driftRootDir = root
+	defer func() { driftRootDir = oldRoot }()
+
+	var tests = []struct {
+		name     string
+		options  []EffectiveProfileOption
+		expected []string
+	}{
+		{
+			name:    "calc",
+			options: []EffectiveProfileOption{{Name: "isolated_cores", Value: "2-7"}},
+			expected: []string{
+				"[scheduler] /proc/irq/2/smp_affinity_list=0-7 (expected excluding 2-7)",
+				"[scheduler] /proc/irq/3/smp_affinity_list=5 (expected excluding 2-7)",
+				"[scheduler] /proc/irq/default_smp_affinity=0-7 (expected excluding 2-7)",
+			},
+		},
+		{
+			name: "irq_process disabled, default affinity ignored",
+			options: []EffectiveProfileOption{
+				{Name: "isolated_cores", Value: "2-7"},
+				{Name: "irq_process", Value: "false"},
+				{Name: "default_irq_smp_affinity", Value: "ignore"},
+			},
+		},
+		{
+			name: "explicit default affinity",
+			options: []EffectiveProfileOption{
+				{Name: "isolated_cores", Value: "1-7"},
+				{Name: "irq_process", Value: "0"},
+				{Name: "default_irq_smp_affinity", Value: "0"},
+			},
+			expected: []string{
+				"[scheduler] /proc/irq/default_smp_affinity=0-7 (expected 0)",
+			},
+		},
+		{
+			name: "unresolved variables",
+			options: []EffectiveProfileOption{
+				{Name: "isolated_cores", Value: "${isolated_cores}"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		section := EffectiveProfileSection{Name: "scheduler", Options: tc.options}
+		if actual := verifyIRQAffinity(section); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: want:\n%q\nhave:\n%q", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestValueMatches(t *testing.T) {
+	var tests = []struct {
+		expected string
+		actual   string
+		matches  bool
+	}{
+		{expected: "1", actual: "1\n", matches: true},
+		{expected: "1", actual: "0\n", matches: false},
+		{expected: "a  b", actual: "a\tb\n", matches: true},
+		{expected: "never", actual: "always madvise [never]\n", matches: true},
+		{expected: "always", actual: "always madvise [never]\n", matches: false},
+		{expected: ">100", actual: "200", matches: true},
+		{expected: ">100", actual: "50", matches: false},
+		{expected: "<100", actual: "50", matches: true},
+		{expected: "<100", actual: "200", matches: false},
+		{expected: ">a", actual: "b", matches: true},
+	}
+
+	for _, tc := range tests {
+		if matches := valueMatches(tc.expected, tc.actual); matches != tc.matches {
+			t.Errorf("valueMatches(%q, %q): want %v, have %v", tc.expected, tc.actual, tc.matches, matches)
+		}
+	}
+}
+
+func TestDriftMessage(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+
+	if actual, expected := driftMessage(keys[:2]), "a, b"; actual != expected {
+		t.Errorf("want: %q, have: %q", expected, actual)
+	}
+	if actual, expected := driftMessage(keys), "a, b, c, d, e, f, g, h, i, j and 2 more"; actual != expected {
+		t.Errorf("want: %q, have: %q", expected, actual)
+	}
+}
@@ -0,0 +1,79 @@
+package tuned
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// The list of online NUMA nodes and the directory of the NUMA nodes.  Variables for unit testing.
+var (
+	numaNodeOnlineFile = "/sys/devices/system/node/online"
+	numaNodeDir        = "/sys/devices/system/node"
+)
+
+// numaNodeCount returns the number of online NUMA nodes or 0 if unknown.
+func numaNodeCount() int32 {
+	nodes, err := cpulistFromFile(numaNodeOnlineFile)
+	if err != nil {
+		klog.Errorf("failed to detect the number of NUMA nodes: %v", err)
+		return 0
+	}
+	return int32(len(nodes))
+}
+
+// numaHugePages returns the huge pages allocated on the online NUMA nodes sorted
+// by the node and the page size.  Page sizes without pages allocated are left out.
+func numaHugePages() []tunedv1.NUMAHugePages {
+	nodes, err := cpulistFromFile(numaNodeOnlineFile)
+	if err != nil {
+		klog.Errorf("failed to detect the NUMA nodes: %v", err)
+		return nil
+	}
+
+	var hugePages []tunedv1.NUMAHugePages
+	for _, node := range nodes {
+		dirs, err := filepath.Glob(filepath.Join(numaNodeDir, fmt.Sprintf("node%d", node), "hugepages", "hugepages-*kB"))
+		if err != nil {
+			klog.Errorf("failed to list the huge pages of NUMA node %d: %v", node, err)
+			continue
+		}
+		for _, dir := range dirs {
+			size, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(dir), "hugepages-"), "kB"), 10, 64)
+			if err != nil {
+				klog.Errorf("failed to parse the huge page size of %s: %v", dir, err)
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir, "nr_hugepages"))
+			if err != nil {
+				klog.Errorf("failed to read the huge pages of NUMA node %d: %v", node, err)
+				continue
+			}
+			count, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 32)
+			if err != nil {
+				klog.Errorf("failed to parse the huge pages of NUMA node %d: %v", node, err)
+				continue
+			}
+			if count == 0 {
+				continue
+			}
+			hugePages = append(hugePages, tunedv1.NUMAHugePages{Node: int32(node), SizeKB: size, Count: int32(count)})
+		}
+	}
+
+	sort.Slice(hugePages, func(i, j int) bool {
+		if hugePages[i].Node != hugePages[j].Node {
+			return hugePages[i].Node < hugePages[j].Node
+		}
+		return hugePages[i].SizeKB < hugePages[j].SizeKB
+	})
+
+	return hugePages
+}
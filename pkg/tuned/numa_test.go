@@ -0,0 +1,74 @@
+package tuned
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestNumaNodeCount(t *testing.T) {
+	oldOnline := numaNodeOnlineFile
+	defer func() { numaNodeOnlineFile = oldOnline }()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string // the online file is missing if empty
+		want    int32
+	}{
+		{name: "single", content: "0\n", want: 1},
+		{name: "multiple", content: "0-1,3\n", want: 3},
+		{name: "unknown", want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			numaNodeOnlineFile = filepath.Join(dir, tc.name)
+			if tc.content != "" {
+				if err := os.WriteFile(numaNodeOnlineFile, []byte(tc.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if n := numaNodeCount(); n != tc.want {
+				t.Errorf("want %d NUMA nodes, have %d", tc.want, n)
+			}
+		})
+	}
+}
+
+func TestNumaHugePages(t *testing.T) {
+	oldOnline, oldDir := numaNodeOnlineFile, numaNodeDir
+	defer func() { numaNodeOnlineFile, numaNodeDir = oldOnline, oldDir }()
+
+	numaNodeDir = t.TempDir()
+	numaNodeOnlineFile = filepath.Join(numaNodeDir, "online")
+	files := map[string]string{
+		"online": "0-1\n",
+		"node0/hugepages/hugepages-2048kB/nr_hugepages":    "128\n",
+		"node0/hugepages/hugepages-1048576kB/nr_hugepages": "4\n",
+		"node1/hugepages/hugepages-2048kB/nr_hugepages":    "0\n",
+		"node1/hugepages/hugepages-1048576kB/nr_hugepages": "3\n",
+		"node2/hugepages/hugepages-1048576kB/nr_hugepages": "1\n", // offline
+	}
+	for name, content := range files {
+		path := filepath.Join(numaNodeDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []tunedv1.NUMAHugePages{
+		{Node: 0, SizeKB: 2048, Count: 128},
+		{Node: 0, SizeKB: 1048576, Count: 4},
+		{Node: 1, SizeKB: 1048576, Count: 3},
+	}
+	if have := numaHugePages(); !reflect.DeepEqual(have, want) {
+		t.Errorf("want huge pages %v, have %v", want, have)
+	}
+}
@@ -23,10 +23,16 @@ import (
 	"time"
 
 	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
 )
 
-func TunedCreateCmdline(debug bool) (string, []string) {
-	args := []string{"--no-dbus"}
+func TunedCreateCmdline(debug bool, dbus bool) (string, []string) {
+	args := []string{}
+	if !dbus {
+		args = append(args, "--no-dbus")
+	}
 	if debug {
 		args = append(args, "--debug")
 	}
@@ -99,7 +105,7 @@ func TunedRunNoDaemon(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	command, args := TunedCreateCmdline(false)
+	command, args := TunedCreateCmdline(false, false)
 	if timeout > 0 {
 		// CommandContext sets Cancel to call the Kill (SIGKILL) method on the command's Process.
 		cmd = exec.CommandContext(ctx, command, args...)
@@ -132,7 +138,7 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 
 			fmt.Printf("%s\n", l)
 
-			if daemon.stopping {
+			if daemon.stopping.Load() {
 				// We have decided to stop TuneD.  Apart from showing the logs it is
 				// now unnecessary/undesirable to perform any of the following actions.
 				// The undesirability comes from extra processing which will come if
@@ -141,9 +147,11 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 				continue
 			}
 
-			profileApplied := strings.Contains(l, " tuned.daemon.daemon: static tuning from profile ") && strings.Contains(l, " applied")
-			reloadFailed := strings.Contains(l, " tuned.daemon.controller: Failed to reload TuneD: ")
-			profileDoesNotExist := strings.Contains(l, " tuned.daemon.daemon: Cannot set initial profile. No tunings will be enabled: Requested profile ") &&
+			// When the TuneD D-Bus API is in use, the profile state is tracked by the TuneD
+			// D-Bus signals.  Parsing the log lines is a fallback.
+			profileApplied := !daemon.dbusActive.Load() && strings.Contains(l, " tuned.daemon.daemon: static tuning from profile ") && strings.Contains(l, " applied")
+			reloadFailed := !daemon.dbusActive.Load() && strings.Contains(l, " tuned.daemon.controller: Failed to reload TuneD: ")
+			profileDoesNotExist := !daemon.dbusActive.Load() && strings.Contains(l, " tuned.daemon.daemon: Cannot set initial profile. No tunings will be enabled: Requested profile ") &&
 				strings.Contains(l, " doesn't exist.")
 
 			if profileApplied {
@@ -153,6 +161,8 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 			strIndex := strings.Index(l, " WARNING ")
 			if strIndex >= 0 {
 				daemon.status |= scWarn
+				plugin, message := parseTunedLogMessage(l, " WARNING ")
+				daemon.diagnostics.add(tunedv1.DiagnosticSeverityWarning, plugin, message, time.Now())
 				prevError := ((daemon.status & scError) != 0)
 				if !prevError { // don't overwrite an error message
 					daemon.stderr = l[strIndex:] // trim timestamp from log
@@ -163,12 +173,17 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 			if strIndex >= 0 {
 				daemon.status |= scError
 				daemon.stderr = l[strIndex:] // trim timestamp from log
+				plugin, message := parseTunedLogMessage(l, " ERROR ")
+				daemon.diagnostics.add(tunedv1.DiagnosticSeverityError, plugin, message, time.Now())
 			}
 
 			sysctl := overridenSysctl(l)
 			if sysctl != "" {
 				daemon.status |= scSysctlOverride
 				daemon.stderr = sysctl
+				metrics.TunedSysctlOverride()
+				plugin, message := parseTunedLogMessage(l, " INFO ")
+				daemon.diagnostics.add(tunedv1.DiagnosticSeverityWarning, plugin, message, time.Now())
 			}
 
 			if (daemon.status & scReloading) != 0 {
@@ -187,6 +202,7 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 	daemon.status = 0
 	daemon.status |= scReloading
 	daemon.stderr = ""
+	daemon.diagnostics.reset()
 	if err = cmd.Start(); err != nil {
 		return fmt.Errorf("error starting tuned: %w", err)
 	}
@@ -106,7 +106,11 @@ func computeStatusConditions(status Bits, message string, conditions []tunedv1.S
 		deferredMessage = ": " + message
 	}
 
-	if (status & scDeferred) != 0 {
+	if (status & scDeferredWindow) != 0 {
+		tunedProfileAppliedCondition.Status = corev1.ConditionFalse
+		tunedProfileAppliedCondition.Reason = "Deferred"
+		tunedProfileAppliedCondition.Message = "The TuneD daemon profile is waiting for the next maintenance window or node restart" + deferredMessage
+	} else if (status & scDeferred) != 0 {
 		tunedProfileAppliedCondition.Status = corev1.ConditionFalse
 		tunedProfileAppliedCondition.Reason = "Deferred"
 		tunedProfileAppliedCondition.Message = "The TuneD daemon profile is waiting for the next node restart" + deferredMessage
@@ -124,6 +128,10 @@ func computeStatusConditions(status Bits, message string, conditions []tunedv1.S
 		tunedDegradedCondition.Status = corev1.ConditionTrue
 		tunedDegradedCondition.Reason = "TunedError"
 		tunedDegradedCondition.Message = "TuneD daemon issued one or more error message(s) during profile application. TuneD stderr: " + message
+	} else if (status & scDeferredWindow) != 0 {
+		tunedDegradedCondition.Status = corev1.ConditionTrue
+		tunedDegradedCondition.Reason = "TunedDeferredUpdate"
+		tunedDegradedCondition.Message = "Profile will be applied in the next maintenance window or at the next node restart" + deferredMessage
 	} else if (status & scDeferred) != 0 {
 		tunedDegradedCondition.Status = corev1.ConditionTrue
 		tunedDegradedCondition.Reason = "TunedDeferredUpdate"
@@ -147,3 +155,38 @@ func computeStatusConditions(status Bits, message string, conditions []tunedv1.S
 
 	return conditions
 }
+
+// computeDriftedCondition returns the Drifted condition based on the result
+// of the last verification of the applied tuning 'drift'.
+func computeDriftedCondition(drift *driftStatus) *tunedv1.StatusCondition {
+	condition := &tunedv1.StatusCondition{
+		Type: tunedv1.TunedDrifted,
+	}
+
+	if len(drift.keys) == 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "AsExpected"
+		condition.Message = "No drift from the applied TuneD profile detected."
+	} else if drift.reapplied {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "Reapplied"
+		condition.Message = "Runtime tuning drifted from the applied TuneD profile, the profile was re-applied: " + driftMessage(drift.keys)
+	} else {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "DriftDetected"
+		condition.Message = "Runtime tuning differs from the applied TuneD profile: " + driftMessage(drift.keys)
+	}
+
+	return condition
+}
+
+// RemoveStatusCondition returns 'conditions' without the condition of type 'conditionType'.
+func RemoveStatusCondition(conditions []tunedv1.StatusCondition, conditionType tunedv1.ConditionType) []tunedv1.StatusCondition {
+	newConditions := []tunedv1.StatusCondition{}
+	for _, c := range conditions {
+		if c.Type != conditionType {
+			newConditions = append(newConditions, c)
+		}
+	}
+	return newConditions
+}
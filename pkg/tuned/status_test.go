@@ -66,6 +66,30 @@ func TestComputeStatusConditions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "only-deferred-window",
+			status: scDeferred | scDeferredWindow,
+			expected: []tunedv1.StatusCondition{
+				{
+					Type:   tunedv1.TunedProfileApplied,
+					Status: corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{
+						Time: testTime(),
+					},
+					Reason:  "Deferred",
+					Message: "The TuneD daemon profile is waiting for the next maintenance window or node restart",
+				},
+				{
+					Type:   tunedv1.TunedDegraded,
+					Status: corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{
+						Time: testTime(),
+					},
+					Reason:  "TunedDeferredUpdate",
+					Message: "Profile will be applied in the next maintenance window or at the next node restart",
+				},
+			},
+		},
 		{
 			name:   "error-deferred",
 			status: scError | scDeferred,
@@ -152,6 +176,53 @@ func TestComputeStatusConditions(t *testing.T) {
 	}
 }
 
+func TestComputeDriftedCondition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		drift    *driftStatus
+		expected tunedv1.StatusCondition
+	}{
+		{
+			name:  "no-drift",
+			drift: &driftStatus{},
+			expected: tunedv1.StatusCondition{
+				Type:    tunedv1.TunedDrifted,
+				Status:  corev1.ConditionFalse,
+				Reason:  "AsExpected",
+				Message: "No drift from the applied TuneD profile detected.",
+			},
+		},
+		{
+			name:  "drift",
+			drift: &driftStatus{keys: []string{"[sysctl] vm.swappiness=60 (expected 10)"}},
+			expected: tunedv1.StatusCondition{
+				Type:    tunedv1.TunedDrifted,
+				Status:  corev1.ConditionTrue,
+				Reason:  "DriftDetected",
+				Message: "Runtime tuning differs from the applied TuneD profile: [sysctl] vm.swappiness=60 (expected 10)",
+			},
+		},
+		{
+			name:  "drift-reapplied",
+			drift: &driftStatus{keys: []string{"[sysctl] vm.swappiness=60 (expected 10)"}, reapplied: true},
+			expected: tunedv1.StatusCondition{
+				Type:    tunedv1.TunedDrifted,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Reapplied",
+				Message: "Runtime tuning drifted from the applied TuneD profile, the profile was re-applied: [sysctl] vm.swappiness=60 (expected 10)",
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeDriftedCondition(tt.drift)
+			if !reflect.DeepEqual(*got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", *got, tt.expected)
+			}
+		})
+	}
+}
+
 func clearTimestamps(conds []tunedv1.StatusCondition) []tunedv1.StatusCondition {
 	ret := make([]tunedv1.StatusCondition, 0, len(conds))
 	for idx := range conds {
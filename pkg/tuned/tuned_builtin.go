@@ -0,0 +1,485 @@
+package tuned
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// Files and commands used by the TuneD built-in functions.  Variables for unit testing.
+var (
+	cpuOnlineFile  = "/sys/devices/system/cpu/online"
+	cpuPresentFile = "/sys/devices/system/cpu/present"
+	cpuInfoFile    = "/proc/cpuinfo"
+	lscpuCommand   = []string{"lscpu"}
+	virtWhatCmd    = []string{"virt-what"}
+)
+
+// tunedBuiltin is a Go-native implementation of a TuneD built-in function.
+type tunedBuiltin struct {
+	// Minimum number of arguments.
+	minArgs int
+	// Maximum number of arguments or -1 for an arbitrary number of arguments.
+	maxArgs int
+	// The function expanding the built-in.  Expansion fails if error is returned.
+	fn func(args []string) (string, error)
+}
+
+// tunedBuiltins maps the names of the TuneD built-in functions to their
+// implementations.  Built-ins which depend on the detailed system topology or
+// hardware (calc_isolated_cores, check_net_queue_count, intel_recommended_pstate,
+// package2cpus, package2uncores) are not supported.
+var tunedBuiltins = map[string]tunedBuiltin{
+	"assertion":            {minArgs: 3, maxArgs: 3, fn: builtinAssertion},
+	"assertion_non_equal":  {minArgs: 3, maxArgs: 3, fn: builtinAssertionNonEqual},
+	"cpuinfo_check":        {minArgs: 1, maxArgs: -1, fn: builtinCpuinfoCheck},
+	"cpulist2devs":         {minArgs: 0, maxArgs: -1, fn: builtinCpulist2devs},
+	"cpulist2hex":          {minArgs: 0, maxArgs: -1, fn: builtinCpulist2hex},
+	"cpulist2hex_invert":   {minArgs: 0, maxArgs: -1, fn: builtinCpulist2hexInvert},
+	"cpulist_invert":       {minArgs: 0, maxArgs: -1, fn: builtinCpulistInvert},
+	"cpulist_online":       {minArgs: 0, maxArgs: -1, fn: builtinCpulistOnline},
+	"cpulist_pack":         {minArgs: 0, maxArgs: -1, fn: builtinCpulistPack},
+	"cpulist_present":      {minArgs: 0, maxArgs: -1, fn: builtinCpulistPresent},
+	"cpulist_unpack":       {minArgs: 0, maxArgs: -1, fn: builtinCpulistUnpack},
+	"exec":                 {minArgs: 1, maxArgs: -1, fn: execCmd},
+	"hex2cpulist":          {minArgs: 1, maxArgs: 1, fn: builtinHex2cpulist},
+	"kb2s":                 {minArgs: 1, maxArgs: 1, fn: builtinKb2s},
+	"log":                  {minArgs: 0, maxArgs: -1, fn: builtinLog},
+	"lscpu_check":          {minArgs: 1, maxArgs: -1, fn: builtinLscpuCheck},
+	"regex_search_ternary": {minArgs: 4, maxArgs: 4, fn: builtinRegexSearchTernary},
+	"s2kb":                 {minArgs: 1, maxArgs: 1, fn: builtinS2kb},
+	"strip":                {minArgs: 1, maxArgs: -1, fn: builtinStrip},
+	// The virt-what script needed by "virt_check" must be run as root user.
+	"virt_check": {minArgs: 2, maxArgs: 2, fn: builtinVirtCheck},
+}
+
+// argsString returns a human-readable description of the number of arguments
+// the built-in requires.
+func (b tunedBuiltin) argsString() string {
+	switch {
+	case b.minArgs == b.maxArgs:
+		return fmt.Sprintf("%d", b.minArgs)
+	case b.maxArgs < 0:
+		return fmt.Sprintf("at least %d", b.minArgs)
+	}
+	return fmt.Sprintf("%d to %d", b.minArgs, b.maxArgs)
+}
+
+// builtinAssertion fails the expansion if the arguments 2 and 3 differ,
+// argument 1 is the assertion message.  Expands to an empty string.
+func builtinAssertion(args []string) (string, error) {
+	if args[1] != args[2] {
+		return "", fmt.Errorf("assertion %q failed: %q != %q", args[0], args[1], args[2])
+	}
+	return "", nil
+}
+
+// builtinAssertionNonEqual fails the expansion if the arguments 2 and 3 are
+// equal, argument 1 is the assertion message.  Expands to an empty string.
+func builtinAssertionNonEqual(args []string) (string, error) {
+	if args[1] == args[2] {
+		return "", fmt.Errorf("assertion non equal %q failed: %q == %q", args[0], args[1], args[2])
+	}
+	return "", nil
+}
+
+// builtinCpuinfoCheck checks regexes against /proc/cpuinfo.  See regexCheck.
+func builtinCpuinfoCheck(args []string) (string, error) {
+	cpuinfo, err := os.ReadFile(cpuInfoFile)
+	if err != nil {
+		return "", err
+	}
+	return regexCheck(string(cpuinfo), args)
+}
+
+// builtinLscpuCheck checks regexes against the output of lscpu.  See regexCheck.
+func builtinLscpuCheck(args []string) (string, error) {
+	lscpu, err := execCmd(lscpuCommand)
+	if err != nil {
+		return "", err
+	}
+	return regexCheck(lscpu, args)
+}
+
+// regexCheck takes arguments 'args' in the form REGEX1:STR1:REGEX2:STR2:...[:STRn]
+// and checks the regexes against multi-line string 's' one by one.  Returns STR
+// of the first matching REGEX.  If there is an odd number of arguments, the last
+// argument is the default value, otherwise the default value is an empty string.
+func regexCheck(s string, args []string) (string, error) {
+	for i := 0; i+1 < len(args); i += 2 {
+		re, err := regexp.Compile("(?m)" + args[i])
+		if err != nil {
+			return "", err
+		}
+		if re.MatchString(s) {
+			return args[i+1], nil
+		}
+	}
+	if len(args)%2 == 1 {
+		return args[len(args)-1], nil
+	}
+	return "", nil
+}
+
+// builtinCpulist2devs converts a CPU list into a comma-separated list of
+// devices, e.g. "0-2" -> "cpu0,cpu1,cpu2".
+func builtinCpulist2devs(args []string) (string, error) {
+	cpus, err := cpulistUnpack(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	devs := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		devs = append(devs, fmt.Sprintf("cpu%d", cpu))
+	}
+	return strings.Join(devs, ","), nil
+}
+
+// builtinCpulist2hex converts a CPU list into a hexadecimal CPU mask, e.g.
+// "0-3" -> "0000000f".
+func builtinCpulist2hex(args []string) (string, error) {
+	cpus, err := cpulistUnpack(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	return cpulist2hex(cpus), nil
+}
+
+// builtinCpulist2hexInvert converts the complement of a CPU list into
+// a hexadecimal CPU mask.  See builtinCpulistInvert.
+func builtinCpulist2hexInvert(args []string) (string, error) {
+	cpus, err := cpulistInvert(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	return cpulist2hex(cpus), nil
+}
+
+// builtinCpulistInvert inverts a CPU list (makes its complement) relative to
+// the online CPUs, e.g. "0,2,3" -> "1" on a system with 4 online CPUs.
+func builtinCpulistInvert(args []string) (string, error) {
+	cpus, err := cpulistInvert(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	return cpulistString(cpus), nil
+}
+
+// builtinCpulistOnline returns the online CPUs from a CPU list.
+func builtinCpulistOnline(args []string) (string, error) {
+	return cpulistFilter(strings.Join(args, ","), cpuOnlineFile)
+}
+
+// builtinCpulistPresent returns the present CPUs from a CPU list.
+func builtinCpulistPresent(args []string) (string, error) {
+	return cpulistFilter(strings.Join(args, ","), cpuPresentFile)
+}
+
+// builtinCpulistPack packs a CPU list, e.g. "1,2,3,5" -> "1-3,5".
+func builtinCpulistPack(args []string) (string, error) {
+	cpus, err := cpulistUnpack(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	return cpulistPack(cpus), nil
+}
+
+// builtinCpulistUnpack unpacks a CPU list, e.g. "1-3,5" -> "1,2,3,5".
+func builtinCpulistUnpack(args []string) (string, error) {
+	cpus, err := cpulistUnpack(strings.Join(args, ",,"))
+	if err != nil {
+		return "", err
+	}
+	return cpulistString(cpus), nil
+}
+
+// builtinHex2cpulist converts a hexadecimal CPU mask into a CPU list, e.g.
+// "0x0000000f" -> "0,1,2,3".
+func builtinHex2cpulist(args []string) (string, error) {
+	cpus, err := hex2cpulist(args[0])
+	if err != nil {
+		return "", err
+	}
+	return cpulistString(cpus), nil
+}
+
+// builtinKb2s converts kilobytes to 512-byte sectors.
+func builtinKb2s(args []string) (string, error) {
+	kb, err := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(kb*2, 10), nil
+}
+
+// builtinS2kb converts 512-byte sectors to kilobytes.
+func builtinS2kb(args []string) (string, error) {
+	s, err := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(s/2, 10), nil
+}
+
+// builtinLog logs its arguments and expands to an empty string.
+func builtinLog(args []string) (string, error) {
+	klog.Infof("built-in log: %s", strings.Join(args, " "))
+	return "", nil
+}
+
+// builtinRegexSearchTernary expands to argument 3 if regex argument 2 matches
+// string argument 1, otherwise it expands to argument 4.
+func builtinRegexSearchTernary(args []string) (string, error) {
+	re, err := regexp.Compile(args[1])
+	if err != nil {
+		return "", err
+	}
+	if re.MatchString(args[0]) {
+		return args[2], nil
+	}
+	return args[3], nil
+}
+
+// builtinStrip concatenates its arguments and strips the leading and trailing
+// white space.
+func builtinStrip(args []string) (string, error) {
+	return strings.TrimSpace(strings.Join(args, "")), nil
+}
+
+// builtinVirtCheck checks whether running inside virtual machine (VM) or on
+// bare metal.  If running inside a VM expand to argument 1, otherwise expand
+// to argument 2.  Note the expansion to argument 2 is done also on error to
+// match the semantics of the TuneD "virt_check".
+func builtinVirtCheck(args []string) (string, error) {
+	out, err := execCmd(virtWhatCmd)
+	if err == nil && len(out) > 0 {
+		return args[0], nil
+	}
+	if err != nil {
+		klog.Errorf("failure calling built-in exec: %v", err)
+	}
+
+	return args[1], nil
+}
+
+// cpulistUnpack unpacks CPU list 'l', e.g. "1-3,5" -> [1 2 3 5].  CPUs prefixed
+// by '^' are excluded from the result.  Hexadecimal CPU masks need to be prefixed
+// by "0x" and may contain commas.  When combining them with other CPUs, they need
+// to be separated by ",,", e.g. "0xf,,6".  Returns a sorted list of unique CPUs.
+func cpulistUnpack(l string) ([]int, error) {
+	var (
+		items   []string
+		hexmask bool
+		hv      string
+	)
+
+	// Remove the commas from hexadecimal CPU masks.
+	for _, v := range strings.Split(l, ",") {
+		v = strings.TrimSpace(v)
+		if hexmask {
+			if len(v) == 0 {
+				hexmask = false
+				items = append(items, hv)
+				hv = ""
+			} else {
+				hv += v
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(v), "0x") {
+			hexmask = true
+			hv = v
+		} else if len(v) > 0 {
+			items = append(items, v)
+		}
+	}
+	if len(hv) > 0 {
+		items = append(items, hv)
+	}
+
+	cpus := map[int]bool{}
+	negated := map[int]bool{}
+	for _, v := range items {
+		set := cpus
+		if strings.HasPrefix(v, "^") {
+			set = negated
+			v = v[1:]
+		}
+
+		if strings.HasPrefix(strings.ToLower(v), "0x") {
+			hcpus, err := hex2cpulist(v)
+			if err != nil {
+				return nil, err
+			}
+			for _, cpu := range hcpus {
+				set[cpu] = true
+			}
+			continue
+		}
+
+		bounds := strings.SplitN(v, "-", 2)
+		first, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list %q: %v", l, err)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+				return nil, fmt.Errorf("invalid CPU list %q: %v", l, err)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			set[cpu] = true
+		}
+	}
+
+	ret := []int{}
+	for cpu := range cpus {
+		if !negated[cpu] {
+			ret = append(ret, cpu)
+		}
+	}
+	sort.Ints(ret)
+
+	return ret, nil
+}
+
+// cpulistPack packs a sorted list of unique CPUs 'cpus', e.g. [1 2 3 5] -> "1-3,5".
+func cpulistPack(cpus []int) string {
+	var ranges []string
+
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1]-cpus[j] == 1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(cpus[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(ranges, ",")
+}
+
+// cpulistInvert returns the online CPUs which are not in CPU list 'l'.
+func cpulistInvert(l string) ([]int, error) {
+	cpus, err := cpulistUnpack(l)
+	if err != nil {
+		return nil, err
+	}
+	online, err := cpulistFromFile(cpuOnlineFile)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[int]bool{}
+	for _, cpu := range cpus {
+		excluded[cpu] = true
+	}
+	ret := []int{}
+	for _, cpu := range online {
+		if !excluded[cpu] {
+			ret = append(ret, cpu)
+		}
+	}
+
+	return ret, nil
+}
+
+// cpulistFilter returns the CPUs from CPU list 'l' which are also listed in
+// the CPU list file 'file'.
+func cpulistFilter(l string, file string) (string, error) {
+	cpus, err := cpulistUnpack(l)
+	if err != nil {
+		return "", err
+	}
+	available, err := cpulistFromFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	availableSet := map[int]bool{}
+	for _, cpu := range available {
+		availableSet[cpu] = true
+	}
+	ret := []int{}
+	for _, cpu := range cpus {
+		if availableSet[cpu] {
+			ret = append(ret, cpu)
+		}
+	}
+
+	return cpulistString(ret), nil
+}
+
+// cpulistFromFile reads and unpacks CPU list file 'file' such as
+// /sys/devices/system/cpu/online.
+func cpulistFromFile(file string) ([]int, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return cpulistUnpack(strings.TrimSpace(string(content)))
+}
+
+// cpulistString returns a comma-separated list of CPUs 'cpus'.
+func cpulistString(cpus []int) string {
+	s := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		s = append(s, strconv.Itoa(cpu))
+	}
+	return strings.Join(s, ",")
+}
+
+// cpulist2hex converts CPUs 'cpus' into a hexadecimal CPU mask with
+// comma-separated groups of 8 hexadecimal digits, e.g. [0 1 2 3 32] -> "00000001,0000000f".
+func cpulist2hex(cpus []int) string {
+	mask := new(big.Int)
+	for _, cpu := range cpus {
+		mask.SetBit(mask, cpu, 1)
+	}
+
+	s := mask.Text(16)
+	if pad := len(s) % 8; pad != 0 {
+		s = strings.Repeat("0", 8-pad) + s
+	}
+
+	groups := make([]string, 0, len(s)/8)
+	for i := 0; i < len(s); i += 8 {
+		groups = append(groups, s[i:i+8])
+	}
+
+	return strings.Join(groups, ",")
+}
+
+// hex2cpulist converts hexadecimal CPU mask 'mask' with optional "0x" prefix
+// and commas into a sorted list of CPUs, e.g. "0x0000000f" -> [0 1 2 3].
+func hex2cpulist(mask string) ([]int, error) {
+	m := strings.ReplaceAll(strings.TrimSpace(mask), ",", "")
+	if strings.HasPrefix(strings.ToLower(m), "0x") {
+		m = m[2:]
+	}
+
+	n, ok := new(big.Int).SetString(m, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hexadecimal CPU mask %q", mask)
+	}
+
+	cpus := []int{}
+	for i := 0; i < n.BitLen(); i++ {
+		if n.Bit(i) == 1 {
+			cpus = append(cpus, i)
+		}
+	}
+
+	return cpus, nil
+}
@@ -0,0 +1,225 @@
+package tuned
+
+import (
+	"reflect"
+	"testing"
+)
+
+const builtinTestdataDir = "testdata/builtin"
+
+// setBuiltinTestdata points the files and commands used by the TuneD built-in
+// functions to captured testdata.  Returns a function restoring the defaults.
+func setBuiltinTestdata(lscpu string) func() {
+	oldOnline, oldPresent, oldCpuinfo, oldLscpu := cpuOnlineFile, cpuPresentFile, cpuInfoFile, lscpuCommand
+
+	cpuOnlineFile = builtinTestdataDir + "/cpu-online"
+	cpuPresentFile = builtinTestdataDir + "/cpu-present"
+	cpuInfoFile = builtinTestdataDir + "/cpuinfo"
+	lscpuCommand = []string{"cat", builtinTestdataDir + "/" + lscpu}
+
+	return func() {
+		cpuOnlineFile, cpuPresentFile, cpuInfoFile, lscpuCommand = oldOnline, oldPresent, oldCpuinfo, oldLscpu
+	}
+}
+
+func TestBuiltinFunctions(t *testing.T) {
+	defer setBuiltinTestdata("lscpu-intel-x86_64")()
+
+	var tests = []struct {
+		input          string
+		expectedOutput string
+	}{
+		// CPU lists.
+		{
+			input:          "${f:cpulist_unpack:1-3,5}",
+			expectedOutput: "1,2,3,5",
+		},
+		{
+			input:          "${f:cpulist_unpack:0-7,^2-3:9}",
+			expectedOutput: "0,1,4,5,6,7,9",
+		},
+		{
+			input:          "${f:cpulist_unpack:0x0000000f,,6}",
+			expectedOutput: "0,1,2,3,6",
+		},
+		{
+			input:          "${f:cpulist_unpack:0x00000001,00000000}",
+			expectedOutput: "32",
+		},
+		{
+			input:          "${f:cpulist_pack:1,2,3,5,7-8}",
+			expectedOutput: "1-3,5,7-8",
+		},
+		{
+			input:          "${f:cpulist_invert:0,2-3}",
+			expectedOutput: "1,4,5,6,7",
+		},
+		{
+			input:          "${f:cpulist_online:6-9}",
+			expectedOutput: "6,7",
+		},
+		{
+			input:          "${f:cpulist_present:6-17}",
+			expectedOutput: "6,7,8,9,10,11,12,13,14,15",
+		},
+		{
+			input:          "${f:cpulist2hex:0-3,32}",
+			expectedOutput: "00000001,0000000f",
+		},
+		{
+			input:          "${f:cpulist2hex_invert:0-3}",
+			expectedOutput: "000000f0",
+		},
+		{
+			input:          "${f:hex2cpulist:0x00000001,0000000f}",
+			expectedOutput: "0,1,2,3,32",
+		},
+		{
+			input:          "${f:cpulist2devs:0-2}",
+			expectedOutput: "cpu0,cpu1,cpu2",
+		},
+		{
+			input:          "${f:cpulist_pack:${f:cpulist_invert:${f:cpulist_unpack:4-7}}}",
+			expectedOutput: "0-3",
+		},
+		{
+			// Variables are not expanded, the expansion fails.
+			input:          "${f:cpulist_invert:${isolated_cores_expanded}}",
+			expectedOutput: "${f:cpulist_invert:${isolated_cores_expanded}}",
+		},
+		// Unit conversions and strings.
+		{
+			input:          "${f:kb2s:1024}",
+			expectedOutput: "2048",
+		},
+		{
+			input:          "${f:s2kb:2048}",
+			expectedOutput: "1024",
+		},
+		{
+			input:          "${f:s2kb:many}",
+			expectedOutput: "${f:s2kb:many}",
+		},
+		{
+			input:          "${f:strip:  a:b  }",
+			expectedOutput: "ab",
+		},
+		{
+			input:          "x${f:assertion:equal:a:a}${f:assertion_non_equal:different:a:b}${f:log:hello}",
+			expectedOutput: "x",
+		},
+		{
+			input:          "${f:assertion:equal:a:b}",
+			expectedOutput: "${f:assertion:equal:a:b}",
+		},
+		// Regular expressions against captured uname, lscpu and /proc/cpuinfo output.
+		{
+			input:          "${f:regex_search_ternary:${f:exec:cat:" + builtinTestdataDir + "/uname-r-rt}:rt:realtime:default}",
+			expectedOutput: "realtime",
+		},
+		{
+			input:          "${f:regex_search_ternary:${f:exec:cat:" + builtinTestdataDir + "/uname-r}:rt:realtime:default}",
+			expectedOutput: "default",
+		},
+		{
+			input:          "${f:regex_search_ternary:a:b:c}",
+			expectedOutput: "${f:regex_search_ternary:a:b:c}",
+		},
+		{
+			input:          `${f:lscpu_check:Vendor ID\:\s*AuthenticAMD:amd:Vendor ID\:\s*GenuineIntel:intel}`,
+			expectedOutput: "intel",
+		},
+		{
+			input:          `${f:lscpu_check:^Architecture\:\s*aarch64$:arm:other}`,
+			expectedOutput: "other",
+		},
+		{
+			input:          `${f:lscpu_check:^Architecture\:\s*aarch64$:arm}`,
+			expectedOutput: "",
+		},
+		{
+			input:          `${f:cpuinfo_check:^flags\s*\:.*\bpdpe1gb\b:1g:2m}`,
+			expectedOutput: "1g",
+		},
+		{
+			input:          "${f:unsupported:arg}",
+			expectedOutput: "${f:unsupported:arg}",
+		},
+	}
+
+	for i, tc := range tests {
+		actual := expandTuneDBuiltin(tc.input)
+
+		if actual != tc.expectedOutput {
+			t.Errorf(
+				"failed test case %d:\n\t  in: %s\n\twant: %s\n\thave: %s",
+				i+1,
+				tc.input,
+				tc.expectedOutput,
+				actual,
+			)
+		}
+	}
+}
+
+func TestProfileIncludesExpand(t *testing.T) {
+	// The "include" statement of the openshift-node-performance profile with
+	// `uname -r` replaced by the captured output.
+	include := func(uname string) string {
+		return "openshift-node,cpu-partitioning${f:regex_search_ternary:${f:exec:cat:" + builtinTestdataDir + "/" + uname + "}:rt:,openshift-node-performance-rt-pp:};\n" +
+			`openshift-node-performance-${f:lscpu_check:Vendor ID\:\s*GenuineIntel:intel:Vendor ID\:\s*AuthenticAMD:amd:Architecture\:\s*aarch64:arm}-${f:lscpu_check:Architecture\:\s*x86_64:x86:Architecture\:\s*aarch64:aarch64}-pp`
+	}
+
+	var tests = []struct {
+		name     string
+		include  string
+		lscpu    string
+		expected []string
+	}{
+		{
+			name:     "intel",
+			include:  include("uname-r"),
+			lscpu:    "lscpu-intel-x86_64",
+			expected: []string{"openshift-node", "cpu-partitioning", "openshift-node-performance-intel-x86-pp"},
+		},
+		{
+			name:     "amd realtime",
+			include:  include("uname-r-rt"),
+			lscpu:    "lscpu-amd-x86_64",
+			expected: []string{"openshift-node", "cpu-partitioning", "openshift-node-performance-rt-pp", "openshift-node-performance-amd-x86-pp"},
+		},
+		{
+			name:     "arm",
+			include:  include("uname-r"),
+			lscpu:    "lscpu-arm-aarch64",
+			expected: []string{"openshift-node", "cpu-partitioning", "openshift-node-performance-arm-aarch64-pp"},
+		},
+		{
+			name:     "conditional include",
+			include:  " -provider-${f:exec:printf:gcp} , openshift",
+			lscpu:    "lscpu-intel-x86_64",
+			expected: []string{"provider-gcp", "openshift"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer setBuiltinTestdata(tc.lscpu)()
+
+			actual := profileIncludesExpand(tc.include)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("want: %v, have: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetIniFileSectionValueMultiline(t *testing.T) {
+	data := "[main]\nsummary=test\ninclude=a,b;\n    c\n[sysctl]\nkernel.foo=1\n"
+
+	actual := profileIncludesExpand(getIniFileSectionValue(&data, "main", "include"))
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("want: %v, have: %v", expected, actual)
+	}
+}
@@ -0,0 +1,454 @@
+package tuned
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	// Sections with a special meaning to TuneD.
+	sectionMain       = "main"
+	sectionVariables  = "variables"
+	sectionBootloader = "bootloader"
+)
+
+var (
+	// TuneD variables in the form ${name}.
+	tunedVariableRegex = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z_0-9]*)\}`)
+	// Separator of the option names in the "drop" unit option.
+	tunedDropSeparatorRegex = regexp.MustCompile(`\s*[,;]\s*`)
+)
+
+// EffectiveProfile is a TuneD profile flattened by resolving its include chain.
+type EffectiveProfile struct {
+	// Name of the resolved TuneD profile.
+	Name string `json:"name"`
+	// Names of the merged TuneD profiles in the order of merging.  Included
+	// profiles are merged before the profiles including them.
+	Merged []string `json:"merged"`
+	// Sections of the flattened profile in the order of their first appearance.
+	// TuneD variables and built-in functions in the option values are expanded.
+	Sections []EffectiveProfileSection `json:"sections"`
+	// Kernel command line computed from the [bootloader] cmdline* options.
+	Bootcmdline string `json:"bootcmdline"`
+}
+
+// EffectiveProfileSection is a section of the flattened TuneD profile.
+type EffectiveProfileSection struct {
+	Name    string                   `json:"name"`
+	Options []EffectiveProfileOption `json:"options"`
+}
+
+// EffectiveProfileOption is an option of a section of the flattened TuneD profile.
+type EffectiveProfileOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// profileSection is a TuneD profile section with options kept in the order
+// of their first appearance.
+type profileSection struct {
+	name   string
+	keys   []string
+	values map[string]string
+}
+
+func newProfileSection(name string) *profileSection {
+	return &profileSection{name: name, values: map[string]string{}}
+}
+
+func (s *profileSection) set(key, value string) {
+	if _, ok := s.values[key]; !ok {
+		s.keys = append(s.keys, key)
+	}
+	s.values[key] = value
+}
+
+func (s *profileSection) pop(key string) (string, bool) {
+	value, ok := s.values[key]
+	if !ok {
+		return "", false
+	}
+	delete(s.values, key)
+	for i, k := range s.keys {
+		if k == key {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			break
+		}
+	}
+	return value, true
+}
+
+func (s *profileSection) copy() *profileSection {
+	c := newProfileSection(s.name)
+	for _, key := range s.keys {
+		c.set(key, s.values[key])
+	}
+	return c
+}
+
+// profileConfig is a TuneD profile with sections kept in the order of their
+// first appearance.
+type profileConfig struct {
+	name     string
+	sections []*profileSection
+}
+
+func (p *profileConfig) section(name string) *profileSection {
+	for _, s := range p.sections {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// profileConfigLoad parses TuneD profile 'name' data 'data'.  Internal variable
+// ${i:PROFILE_DIR} is replaced by 'profileDir'.
+func profileConfigLoad(name string, data string, profileDir string) (*profileConfig, error) {
+	cfg, err := tunedv1.ProfileIniLoad([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TuneD profile %s: %v", name, err)
+	}
+
+	p := &profileConfig{name: name}
+	for _, s := range cfg.Sections() {
+		if s.Name() == ini.DefaultSection {
+			continue
+		}
+		section := newProfileSection(s.Name())
+		for _, k := range s.Keys() {
+			section.set(k.Name(), strings.ReplaceAll(k.Value(), "${i:PROFILE_DIR}", profileDir))
+		}
+		p.sections = append(p.sections, section)
+	}
+
+	return p, nil
+}
+
+// profileLoader loads TuneD profiles and their includes for profileDependsLoop.
+// The profiles are identified by their names, or by the path of the system
+// profile directory when a custom profile includes the profile of the same name.
+type profileLoader struct {
+	custom map[string]string
+	//                ^^^^^^ TuneD profile data
+	//         ^^^^^^ TuneD profile name
+	systemDir string
+	// Loaded TuneD profiles by their identifiers.
+	configs map[string]*profileConfig
+	// Loaded TuneD profiles in the order TuneD merges them.
+	profiles []*profileConfig
+	// The first error hit while loading the profiles.
+	err error
+}
+
+// find returns the data, name and directory of the TuneD profile identified by
+// 'id'.  Custom profiles take priority over the system ones.  Returns false
+// if no such profile exists.
+func (l *profileLoader) find(id string) (data, name, profileDir string, custom, found bool) {
+	if strings.Contains(id, "/") {
+		// A system profile included by the custom profile of the same name.
+		content, err := os.ReadFile(filepath.Join(id, tunedConfFile))
+		return string(content), filepath.Base(id), id, false, err == nil
+	}
+
+	if d, ok := l.custom[id]; ok {
+		return d, id, filepath.Join(tunedProfilesDirCustom, id), true, true
+	}
+
+	profileDir = filepath.Join(l.systemDir, id)
+	content, err := os.ReadFile(filepath.Join(profileDir, tunedConfFile))
+	return string(content), id, profileDir, false, err == nil
+}
+
+// includes loads the TuneD profile identified by 'id' and returns the
+// identifiers of the profiles it includes.
+func (l *profileLoader) includes(id string) []string {
+	var includes []string
+
+	data, name, profileDir, custom, found := l.find(id)
+	if !found {
+		klog.V(2).Infof("skipping TuneD profile %s", id)
+		return nil
+	}
+
+	p, err := profileConfigLoad(name, data, profileDir)
+	if err != nil {
+		if l.err == nil {
+			l.err = err
+		}
+		return nil
+	}
+	l.configs[id] = p
+
+	main := p.section(sectionMain)
+	if main == nil {
+		return nil
+	}
+	include, ok := main.pop("include")
+	if !ok {
+		return nil
+	}
+
+	// Note: TuneD variables are not known at this point and only built-in functions are expanded.
+	for _, included := range tunedv1.ProfileNamesSeparatorRegex.Split(expandTuneDBuiltin(include), -1) {
+		optional := strings.HasPrefix(included, "-")
+		included = strings.TrimPrefix(included, "-")
+		switch {
+		case len(included) == 0:
+		case included == name && custom:
+			// Custom profile 'name' includes the system profile of the same name.
+			includes = append(includes, filepath.Join(l.systemDir, name))
+		case optional:
+			includes = append(includes, included)
+		default:
+			if _, _, _, _, found := l.find(included); !found && l.err == nil {
+				l.err = fmt.Errorf("cannot find TuneD profile %s", included)
+			}
+			includes = append(includes, included)
+		}
+	}
+
+	return includes
+}
+
+// loaded records the TuneD profile identified by 'id' as the next one to merge.
+func (l *profileLoader) loaded(id string) {
+	if p, ok := l.configs[id]; ok {
+		l.profiles = append(l.profiles, p)
+	}
+}
+
+// mergeProfiles merges TuneD profiles 'profiles' in the given order.  Options
+// of the later profiles override the options of the earlier ones.  Sections
+// with the "replace" option set replace the sections of the earlier profiles
+// and options listed in the "drop" option are removed from the merged section.
+func mergeProfiles(profiles []*profileConfig) *profileConfig {
+	merged := &profileConfig{}
+
+	for _, p := range profiles {
+		for _, s := range p.sections {
+			s = s.copy()
+			replace := false
+			if value, ok := s.pop("replace"); ok {
+				replace = value == "1" || strings.EqualFold(value, "true")
+			}
+			drop, _ := s.pop("drop")
+
+			m := merged.section(s.name)
+			switch {
+			case m == nil:
+				merged.sections = append(merged.sections, s)
+				continue
+			case replace && s.name != sectionMain:
+				*m = *s
+				continue
+			}
+
+			for _, option := range tunedDropSeparatorRegex.Split(drop, -1) {
+				m.pop(option)
+			}
+			for _, key := range s.keys {
+				m.set(key, s.values[key])
+			}
+		}
+	}
+
+	return merged
+}
+
+// expandTuneDVariables expands TuneD variables 'variables' in the form ${name}
+// in string 's'.  Unknown variables and variables escaped by '\' are not expanded.
+func expandTuneDVariables(s string, variables map[string]string) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, m := range tunedVariableRegex.FindAllStringSubmatchIndex(s, -1) {
+		value, ok := variables[s[m[2]:m[3]]]
+		if !ok || (m[0] > 0 && s[m[0]-1] == '\\') {
+			continue
+		}
+		b.WriteString(s[last:m[0]])
+		b.WriteString(value)
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+// expandTuneD expands TuneD variables 'variables' and built-in functions in string 's'.
+func expandTuneD(s string, variables map[string]string) string {
+	return expandTuneDBuiltin(expandTuneDVariables(s, variables))
+}
+
+// resolveVariables resolves the variables from the [variables] section 'section'.
+// Each variable can refer to the variables defined before it.  Variables can also
+// be read from files referenced by the "include" option.
+func resolveVariables(section *profileSection) map[string]string {
+	variables := map[string]string{}
+	if section == nil {
+		return variables
+	}
+
+	for _, key := range section.keys {
+		value := section.values[key]
+		if key != "include" {
+			variables[key] = expandTuneD(value, variables)
+			section.values[key] = variables[key]
+			continue
+		}
+
+		content, err := os.ReadFile(expandTuneD(value, variables))
+		if err != nil {
+			klog.Errorf("failed to read TuneD variables file: %v", err)
+			continue
+		}
+		cfg, err := tunedv1.ProfileIniLoad(content)
+		if err != nil {
+			klog.Errorf("failed to parse TuneD variables file %s: %v", value, err)
+			continue
+		}
+		for _, k := range cfg.Section(ini.DefaultSection).Keys() {
+			variables[k.Name()] = expandTuneD(k.Value(), variables)
+		}
+	}
+
+	return variables
+}
+
+// bootcmdline computes the kernel command line from the cmdline* options of
+// [bootloader] section 'section' in the order of their appearance.  The values
+// prefixed by '+' are appended, the parameters in values prefixed by '-' are
+// removed from the command line computed so far.
+func bootcmdline(section *profileSection, variables map[string]string) string {
+	var cmdline string
+
+	if section == nil {
+		return ""
+	}
+
+	for _, key := range section.keys {
+		val := section.values[key]
+		if !strings.HasPrefix(key, "cmdline") || len(val) == 0 {
+			continue
+		}
+		op, op1, vals := val[0:1], "", strings.TrimSpace(val[1:])
+		if len(val) > 1 {
+			op1 = val[1:2]
+		}
+		switch {
+		case op == "+" || (op == "\\" && (op1 == "\\" || op1 == "+" || op1 == "-")):
+			if len(vals) > 0 {
+				cmdline += " " + vals
+			}
+		case op == "-":
+			for _, p := range strings.Fields(vals) {
+				re := regexp.MustCompile(`(^|\s)` + regexp.QuoteMeta(p) + `(\s|$)`)
+				cmdline = re.ReplaceAllString(cmdline, "$2")
+			}
+		default:
+			cmdline += " " + val
+		}
+	}
+
+	return strings.Join(strings.Fields(expandTuneD(unquote(strings.TrimSpace(cmdline)), variables)), " ")
+}
+
+// unquote removes the matching surrounding quotes from string 's'.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// ResolveEffectiveProfile flattens TuneD profile 'profileName' into the
+// effective profile TuneD would run.  The include chain is resolved using the
+// custom TuneD profiles 'profiles' and the stock profiles in 'systemDir', the
+// loaded profiles are merged, the TuneD variables and built-in functions
+// expanded and the kernel command line computed.  Note the built-in functions
+// are evaluated on the current host.
+func ResolveEffectiveProfile(profiles []tunedv1.TunedProfile, profileName string, systemDir string) (*EffectiveProfile, error) {
+	l := &profileLoader{
+		custom:    map[string]string{},
+		systemDir: systemDir,
+		configs:   map[string]*profileConfig{},
+	}
+	for _, profile := range profiles {
+		if profile.Name == nil || profile.Data == nil {
+			continue
+		}
+		l.custom[*profile.Name] = *profile.Data
+	}
+
+	// TuneD accepts multiple profile names separated by spaces.
+	seen := map[string]bool{}
+	for _, name := range strings.Fields(profileName) {
+		if _, _, _, _, found := l.find(name); !found {
+			return nil, fmt.Errorf("cannot find TuneD profile %s", name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		profileDependsLoop(name, seen, l.includes, l.loaded)
+		if l.err != nil {
+			return nil, l.err
+		}
+	}
+	if len(l.profiles) == 0 {
+		return nil, fmt.Errorf("no TuneD profile %q found", profileName)
+	}
+
+	merged := mergeProfiles(l.profiles)
+	variables := resolveVariables(merged.section(sectionVariables))
+
+	ep := &EffectiveProfile{
+		Name:        profileName,
+		Bootcmdline: bootcmdline(merged.section(sectionBootloader), variables),
+	}
+	for _, p := range l.profiles {
+		ep.Merged = append(ep.Merged, p.name)
+	}
+	for _, s := range merged.sections {
+		section := EffectiveProfileSection{Name: s.name}
+		for _, key := range s.keys {
+			value := s.values[key]
+			if s.name != sectionMain && s.name != sectionVariables {
+				value = expandTuneD(value, variables)
+			}
+			section.Options = append(section.Options, EffectiveProfileOption{Name: key, Value: value})
+		}
+		ep.Sections = append(ep.Sections, section)
+	}
+
+	return ep, nil
+}
+
+// String returns the effective profile in the TuneD profile INI format.
+func (ep *EffectiveProfile) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Effective TuneD profile: %s\n", ep.Name)
+	fmt.Fprintf(&b, "# Merged TuneD profiles: %s\n", strings.Join(ep.Merged, ", "))
+	fmt.Fprintf(&b, "# Kernel command line: %s\n", ep.Bootcmdline)
+	for _, s := range ep.Sections {
+		fmt.Fprintf(&b, "\n[%s]\n", s.Name)
+		for _, o := range s.Options {
+			fmt.Fprintf(&b, "%s=%s\n", o.Name, o.Value)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package tuned
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func newTunedProfile(name, data string) tunedv1.TunedProfile {
+	return tunedv1.TunedProfile{
+		Name: &name,
+		Data: &data,
+	}
+}
+
+func TestResolveEffectiveProfile(t *testing.T) {
+	systemDir := "testdata/effective"
+	profiles := []tunedv1.TunedProfile{
+		newTunedProfile("same", `[main]
+summary=Custom profile including the stock profile of the same name
+include=same
+`),
+		newTunedProfile("top", `[main]
+summary=Top profile
+include=-missing,${f:regex_search_ternary:x86_64:x86:same:missing}
+
+[variables]
+isolated_cores=2-3
+
+[sysctl]
+drop=kernel.b
+kernel.a=10
+
+[scheduler]
+replace=1
+baz=3
+
+[bootloader]
+cmdline_top=+nohz=on
+cmdline_remove=-quiet
+`),
+	}
+
+	expected := &EffectiveProfile{
+		Name:   "top",
+		Merged: []string{"base", "same", "same", "top"},
+		Sections: []EffectiveProfileSection{
+			{Name: "main", Options: []EffectiveProfileOption{{Name: "summary", Value: "Top profile"}}},
+			{Name: "variables", Options: []EffectiveProfileOption{
+				{Name: "isolated_cores", Value: "2-3"},
+				{Name: "isolated_cores_expanded", Value: "2,3"},
+			}},
+			{Name: "sysctl", Options: []EffectiveProfileOption{
+				{Name: "kernel.a", Value: "10"},
+				{Name: "kernel.c", Value: "3"},
+			}},
+			{Name: "scheduler", Options: []EffectiveProfileOption{{Name: "baz", Value: "3"}}},
+			{Name: "script", Options: []EffectiveProfileOption{{Name: "script", Value: filepath.Join(systemDir, "base") + "/script.sh"}}},
+			{Name: "bootloader", Options: []EffectiveProfileOption{
+				{Name: "cmdline_base", Value: "+isolcpus=2-3 quiet"},
+				{Name: "cmdline_top", Value: "+nohz=on"},
+				{Name: "cmdline_remove", Value: "-quiet"},
+			}},
+		},
+		Bootcmdline: "isolcpus=2-3 nohz=on",
+	}
+
+	actual, err := ResolveEffectiveProfile(profiles, "top", systemDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("want:\n%s\nhave:\n%s", expected, actual)
+	}
+
+	if _, err := ResolveEffectiveProfile(profiles, "missing", systemDir); err == nil {
+		t.Errorf("expected an error resolving a missing profile")
+	}
+}
+
+func TestBootcmdline(t *testing.T) {
+	var tests = []struct {
+		name     string
+		options  map[string]string
+		keys     []string
+		expected string
+	}{
+		{
+			name:     "plain and appended values",
+			keys:     []string{"cmdline", "cmdline_b"},
+			options:  map[string]string{"cmdline": "a=1 b", "cmdline_b": "+c"},
+			expected: "a=1 b c",
+		},
+		{
+			name:     "removed values",
+			keys:     []string{"cmdline_a", "cmdline_b", "other"},
+			options:  map[string]string{"cmdline_a": "+a b c", "cmdline_b": "-b a", "other": "+d"},
+			expected: "c",
+		},
+		{
+			name:     "escaped operator",
+			keys:     []string{"cmdline"},
+			options:  map[string]string{"cmdline": `\-a`},
+			expected: "-a",
+		},
+		{
+			name:     "quoted value",
+			keys:     []string{"cmdline"},
+			options:  map[string]string{"cmdline": `"a b"`},
+			expected: "a b",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			section := newProfileSection(sectionBootloader)
+			for _, key := range tc.keys {
+				section.set(key, tc.options[key])
+			}
+			if actual := bootcmdline(section, nil); actual != tc.expected {
+				t.Errorf("want: %q, have: %q", tc.expected, actual)
+			}
+		})
+	}
+}
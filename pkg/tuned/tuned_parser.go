@@ -6,14 +6,22 @@ import (
 	"io"      // io.EOF
 	"os"      // os.Stat()
 	"os/exec" // os.Exec()
-	"strings" // strings.Split()
+	"regexp"  // regexp.MustCompile()
+	"sort"    // sort.Strings()
+	"strings" // strings.TrimPrefix()
 	"syscall" // syscall.SIGHUP, ...
 	"time"    // time.Second, ...
+	"unicode" // unicode.IsSpace()
 
 	"gopkg.in/ini.v1"
 	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 )
 
+// TuneD built-in function arguments and profile names separator.
+var builtinArgsSeparatorRegex = regexp.MustCompile(`[\s,;:]+`)
+
 // iniFileLoad reads INI file `iniFile` into ini.v1 internal data structures.
 // Returns the internal data structures and error if any.
 func iniFileLoad(iniFile string) (*ini.File, error) {
@@ -80,36 +88,32 @@ func iniCfgSetKey(cfg *ini.File, key string, value interface{}) error {
 	return nil
 }
 
-// getIniFileSectionSlice searches INI file `data` inside [`section`]
-// for key `key`.  It takes the key's value and uses separator
-// `separator` to return a slice of strings.
-func getIniFileSectionSlice(data *string, section, key, separator string) []string {
-	var ret []string
-
+// getIniFileSectionValue searches INI file `data` inside [`section`]
+// for key `key` and returns its value.  Python-style multi-line values
+// (continuation lines indented by whitespace) are supported as in TuneD.
+func getIniFileSectionValue(data *string, section, key string) string {
 	if data == nil {
-		return ret
+		return ""
 	}
 
-	cfg, err := ini.Load([]byte(*data))
+	cfg, err := ini.LoadSources(ini.LoadOptions{AllowPythonMultilineValues: true}, []byte(*data))
 	if err != nil || cfg == nil {
 		// This looks like an invalid INI data or parser error.
 		klog.Errorf("unable to read INI file data: %v", err)
-		return ret
+		return ""
 	}
 
 	if !cfg.Section(section).HasKey(key) {
-		return ret
+		return ""
 	}
 
-	ret = strings.Split(cfg.Section(section).Key(key).String(), separator)
-
-	return ret
+	return cfg.Section(section).Key(key).String()
 }
 
-// profileIncludesRaw returns a slice of strings containing TuneD profile names
-// profile <tunedProfilesDir>/<profileName> includes.  The profile names may
-// contain built-in functions that still need to be expanded.
-func profileIncludesRaw(profileName string, tunedProfilesDir string) []string {
+// profileIncludesRaw returns the value of the "include" key of the [main]
+// section of profile <tunedProfilesDir>/<profileName>.  The value may contain
+// built-in functions that still need to be expanded.
+func profileIncludesRaw(profileName string, tunedProfilesDir string) string {
 	profileFile := fmt.Sprintf("%s/%s/%s", tunedProfilesDir, profileName, tunedConfFile)
 
 	content, err := os.ReadFile(profileFile)
@@ -119,7 +123,27 @@ func profileIncludesRaw(profileName string, tunedProfilesDir string) []string {
 
 	s := string(content)
 
-	return getIniFileSectionSlice(&s, "main", "include", ",")
+	return getIniFileSectionValue(&s, "main", "include")
+}
+
+// profileIncludesExpand returns a slice of strings containing TuneD profile
+// names from the value 'includes' of the "include" key.  As in TuneD, the
+// built-in functions are expanded first and the result is split into profile
+// names, because the expansion itself may add or remove profile names.
+// Optional loading characters ('-') are removed.
+func profileIncludesExpand(includes string) []string {
+	var profiles []string
+
+	for _, profile := range tunedv1.ProfileNamesSeparatorRegex.Split(expandTuneDBuiltin(includes), -1) {
+		// Conditional profile loading, strip the '-' from profile name.
+		profile = strings.TrimPrefix(profile, "-")
+		if len(profile) == 0 {
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles
 }
 
 // profileIncludes returns a slice of strings containing TuneD profile names
@@ -133,46 +157,29 @@ func profileIncludes(profileName string) []string {
 	var (
 		custom   bool
 		system   bool
-		profiles []string
 		expanded []string
 	)
 
 	if profileExists(profileName, tunedProfilesDirCustom) {
 		custom = true
-		profiles = profileIncludesRaw(profileName, tunedProfilesDirCustom)
+		expanded = profileIncludesExpand(profileIncludesRaw(profileName, tunedProfilesDirCustom))
 	} else {
-		profiles = profileIncludesRaw(profileName, tunedProfilesDirSystem)
+		expanded = profileIncludesExpand(profileIncludesRaw(profileName, tunedProfilesDirSystem))
 	}
 
-	realProfileName := func(profile string) string {
-		if profile[0:1] == "-" {
-			// Conditional profile loading, strip the '-' from profile name.
-			profile = profile[1:]
-		}
-
-		return expandTuneDBuiltin(profile)
-	}
-
-	for _, profile := range profiles {
-		p := realProfileName(profile)
+	for _, p := range expanded {
 		if profileName == p && custom {
 			// Custom profile 'profileName' includes profile of the same name.
 			// We need to get included profiles from system profile 'profileName' too.
 			system = true
 		}
-		expanded = append(expanded, p)
 	}
 
 	if !system {
 		return expanded
 	}
 
-	for _, profile := range profileIncludesRaw(profileName, tunedProfilesDirSystem) {
-		p := realProfileName(profile)
-		expanded = append(expanded, p)
-	}
-
-	return expanded
+	return append(expanded, profileIncludesExpand(profileIncludesRaw(profileName, tunedProfilesDirSystem))...)
 }
 
 // profileExists returns true if TuneD profile <tunedProfilesDir>/<profileName> exists.
@@ -187,29 +194,210 @@ func profileExists(profileName string, tunedProfilesDir string) bool {
 // (/etc/tuned/<profileName>/) profile 'profileName' depends on as keys.
 // The dependency is resolved by finding all the "parent" profiles which are
 // included by using the "include" keyword in the profile's [main] section.
-// Note: only basic expansion of TuneD built-in functions into profiles is
-// performed.  See expandTuneDBuiltin for more detail.
+// Multiple stacked TuneD profiles in 'profileName' separated by spaces are
+// supported.
+// Note: TuneD variables are not expanded.  See expandTuneDBuiltin for
+// more detail.
 func profileDepends(profileName string) map[string]bool {
 	deps := map[string]bool{}
-	if len(profileName) == 0 {
-		return deps
+	for _, name := range tunedv1.ProfileNamesSeparatorRegex.Split(profileName, -1) {
+		if len(name) == 0 {
+			continue
+		}
+		deps = profileDependsLoop(name, deps, profileIncludes, nil)
 	}
-	return profileDependsLoop(profileName, deps)
+	return deps
 }
 
-func profileDependsLoop(profileName string, seenProfiles map[string]bool) map[string]bool {
-	profiles := profileIncludes(profileName)
+// profileDependsLoop adds the TuneD profiles profile 'profileName' recursively
+// includes to 'seenProfiles'.  Function 'includes' returns the names of the
+// profiles a profile includes directly.  Optional function 'loaded' is called
+// for each profile once all the profiles it includes were processed, i.e. in
+// the order TuneD loads the profiles.
+func profileDependsLoop(profileName string, seenProfiles map[string]bool, includes func(string) []string, loaded func(string)) map[string]bool {
+	profiles := includes(profileName)
 	for _, profile := range profiles {
 		if seenProfiles[profile] {
 			// We have already seen/processed custom profile 'p'.
 			continue
 		}
 		seenProfiles[profile] = true
-		seenProfiles = profileDependsLoop(profile, seenProfiles)
+		seenProfiles = profileDependsLoop(profile, seenProfiles, includes, loaded)
+	}
+	if loaded != nil {
+		loaded(profileName)
 	}
 	return seenProfiles
 }
 
+// ProfileDependencies returns "TuneD profile name"->bool map of TuneD profiles
+// the recommended profile(s) 'recommendedProfile' depend on, including the
+// recommended profile(s) themselves.  Unlike profileDepends, the custom profiles
+// are taken from 'profiles' and not from the filesystem, so the dependencies can
+// be resolved by the operator.  System profiles are scanned in
+// tunedProfilesDirSystem if present.  The built-in functions cannot be expanded
+// outside of the node, see profileIncludesResolve.
+func ProfileDependencies(profiles []tunedv1.TunedProfile, recommendedProfile string, providerName string) map[string]bool {
+	custom := map[string]string{}
+	var customNames []string
+	for _, profile := range profiles {
+		if profile.Name != nil && profile.Data != nil {
+			custom[*profile.Name] = *profile.Data
+			customNames = append(customNames, *profile.Name)
+		}
+	}
+	sort.Strings(customNames)
+
+	resolve := func(includes string) []string {
+		return profileIncludesResolve(includes, providerName, customNames)
+	}
+	includes := func(profileName string) []string {
+		data, ok := custom[profileName]
+		if !ok {
+			return resolve(profileIncludesRaw(profileName, tunedProfilesDirSystem))
+		}
+		expanded := resolve(getIniFileSectionValue(&data, "main", "include"))
+		for _, p := range expanded {
+			if p == profileName {
+				// Custom profile 'profileName' includes the system profile of the same name.
+				return append(expanded, resolve(profileIncludesRaw(profileName, tunedProfilesDirSystem))...)
+			}
+		}
+		return expanded
+	}
+
+	deps := map[string]bool{}
+	for _, name := range tunedv1.ProfileNamesSeparatorRegex.Split(recommendedProfile, -1) {
+		if len(name) == 0 || deps[name] {
+			continue
+		}
+		deps[name] = true
+		deps = profileDependsLoop(name, deps, includes, nil)
+	}
+	return deps
+}
+
+// profileIncludesResolve returns a slice of strings containing TuneD profile
+// names from the value 'includes' of the "include" key without expanding the
+// TuneD built-in functions on the node.  The built-in function reading the cloud
+// provider name extracted by the operand is replaced by 'providerName'.  The
+// names of the other profiles with unexpanded built-in functions or variables
+// are not known, all the profiles out of 'known' they may expand to are
+// returned instead: the profiles matching the name with any value of the
+// built-in functions and the profiles mentioned in the built-in functions.
+// Optional loading characters ('-') are removed.
+func profileIncludesResolve(includes string, providerName string, known []string) []string {
+	var profiles []string
+
+	includes = strings.ReplaceAll(includes, "${f:exec:cat:"+ocpTunedProvider+"}", providerName)
+	for _, profile := range profileIncludesSplit(includes) {
+		// Conditional profile loading, strip the '-' from profile name.
+		profile = strings.TrimPrefix(profile, "-")
+		if len(profile) == 0 {
+			continue
+		}
+		if !strings.Contains(profile, "${") {
+			profiles = append(profiles, profile)
+			continue
+		}
+		profiles = append(profiles, profileIncludesCandidates(profile, known)...)
+	}
+
+	return profiles
+}
+
+// profileIncludesSplit splits the value 'includes' of the "include" key into
+// TuneD profile names.  Unlike tunedv1.ProfileNamesSeparatorRegex, the separators within
+// the unexpanded built-in functions and variables "${...}" are ignored.
+func profileIncludesSplit(includes string) []string {
+	var (
+		names []string
+		sb    strings.Builder
+		depth int
+	)
+
+	for i := 0; i < len(includes); i++ {
+		c := includes[i]
+		switch {
+		case c == '$' && i+1 < len(includes) && includes[i+1] == '{':
+			depth++
+			sb.WriteString("${")
+			i++
+			continue
+		case c == '}' && depth > 0:
+			depth--
+		case depth == 0 && (c == ',' || c == ';' || unicode.IsSpace(rune(c))):
+			if sb.Len() > 0 {
+				names = append(names, sb.String())
+				sb.Reset()
+			}
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	if sb.Len() > 0 {
+		names = append(names, sb.String())
+	}
+
+	return names
+}
+
+// profileIncludesCandidates returns the TuneD profiles out of 'known' the
+// profile name 'profile' with unexpanded built-in functions or variables may
+// expand to.  These are the profiles matching 'profile' with the built-in
+// functions replaced by any string and the profiles mentioned as arguments of
+// the built-in functions, e.g. by regex_search_ternary.
+func profileIncludesCandidates(profile string, known []string) []string {
+	var (
+		pattern strings.Builder
+		args    strings.Builder
+		depth   int
+	)
+
+	pattern.WriteString("^")
+	for i := 0; i < len(profile); i++ {
+		c := profile[i]
+		switch {
+		case c == '$' && i+1 < len(profile) && profile[i+1] == '{':
+			if depth == 0 {
+				pattern.WriteString(".*")
+			}
+			depth++
+			i++
+			args.WriteByte(' ')
+		case depth == 0:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		case c == '}':
+			depth--
+			args.WriteByte(' ')
+		default:
+			args.WriteByte(c)
+		}
+	}
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		// This should never happen, all the literal parts are quoted.
+		klog.Errorf("failed to compile TuneD profile name pattern %q: %v", pattern.String(), err)
+		return nil
+	}
+
+	mentioned := map[string]bool{}
+	for _, arg := range builtinArgsSeparatorRegex.Split(args.String(), -1) {
+		mentioned[strings.TrimPrefix(arg, "-")] = true
+	}
+
+	var profiles []string
+	for _, name := range known {
+		if re.MatchString(name) || mentioned[name] {
+			profiles = append(profiles, name)
+		}
+	}
+
+	return profiles
+}
+
 // execCmd starts command 'command' and waits for it to complete.
 // Optional arguments for the command start at command[1].
 // If the command does not exit within 'waitSeconds' seconds, SIGTERM
@@ -313,50 +501,38 @@ func execCmd(command []string) (string, error) {
 // arguments 'args'.  Returns the result/expansion of running the built-in.
 // If the execution of the built-in fails, returns the string 'onFail'.
 func execTuneDBuiltin(function string, args []string, onFail string) string {
-	switch {
-	case function == "exec":
-		out, err := execCmd(args)
-		if err != nil {
-			klog.Errorf("error calling built-in exec: %v", err)
-			return onFail
-		}
-		return out
-
-	// The virt-what script needed by "virt_check" must be run as root user.
-	// Exclude it from unit testing.
-	case function == "virt_check":
-		// Check whether running inside virtual machine (VM) or on bare metal.
-		// If running inside a VM expand to argument 1, otherwise expand to
-		// argument 2.  Note the expansion to argument 2 is done also on error
-		// to match the semantics of the TuneD "virt_check".
-		if len(args) != 2 {
-			klog.Errorf("built-in \"virt_check\" requires 2 arguments")
-			return onFail
-		}
-		out, err := execCmd([]string{"virt-what"})
-		if err == nil && len(out) > 0 {
-			return args[0]
-		}
-		if err != nil {
-			klog.Errorf("failure calling built-in exec: %v", err)
-		}
+	builtin, ok := tunedBuiltins[function]
+	if !ok {
+		// unsupported built-in
+		klog.Errorf("calling unsupported built-in: %v", function)
+		return onFail
+	}
 
-		return args[1]
+	if len(args) < builtin.minArgs || (builtin.maxArgs >= 0 && len(args) > builtin.maxArgs) {
+		klog.Errorf("built-in %q called with %d arguments, requires %s", function, len(args), builtin.argsString())
+		return onFail
+	}
 
-	default:
-		klog.Errorf("calling unsupported built-in: %v", function)
-		// unsupported built-in
+	for i := range args {
+		// Unescape the escaped argument separators.
+		args[i] = strings.ReplaceAll(args[i], `\:`, ":")
+	}
+
+	out, err := builtin.fn(args)
+	if err != nil {
+		klog.Errorf("error calling built-in %s: %v", function, err)
+		return onFail
 	}
 
-	return onFail
+	return out
 }
 
-// expandTuneDBuiltin is a naive and incomplete parser of TuneD built-in
-// functions in the form ${f:function(:argN)*}.  A typical use case is
-// evaluating ${f:virt_check:profile-a:profile-b} and ${f:exec(:argN)}
-// TuneD built-in functions in "include" statements.  If (parts of)
-// the expansion fail, the function returns the original string for the
-// parts that failed the expansion.
+// expandTuneDBuiltin is a naive parser of TuneD built-in functions in the
+// form ${f:function(:argN)*}.  A typical use case is evaluating built-in
+// functions such as ${f:virt_check:profile-a:profile-b} or
+// ${f:lscpu_check:regex:profile-a} in "include" statements.  TuneD variables
+// are not expanded.  If (parts of) the expansion fail, the function returns
+// the original string for the parts that failed the expansion.
 func expandTuneDBuiltin(s string) string {
 	const (
 		sInit   = 0
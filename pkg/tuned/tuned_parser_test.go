@@ -1,7 +1,12 @@
 package tuned
 
 import (
+	"reflect"
 	"testing"
+
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 )
 
 func TestBuiltinExpansion(t *testing.T) {
@@ -67,3 +72,72 @@ func TestBuiltinExpansion(t *testing.T) {
 		}
 	}
 }
+
+func TestProfileDependencies(t *testing.T) {
+	// The include of the PerformanceProfile TuneD profile, see assets/performanceprofile/tuned/openshift-node-performance.
+	const performanceInclude = `openshift-node,cpu-partitioning${f:regex_search_ternary:${f:exec:uname:-r}:rt:,openshift-node-performance-rt-pp:};
+    openshift-node-performance-${f:lscpu_check:Vendor ID\:\s*GenuineIntel:intel:Vendor ID\:\s*AuthenticAMD:amd:Architecture\:\s*aarch64:arm}-${f:lscpu_check:Architecture\:\s*x86_64:x86:Architecture\:\s*aarch64:aarch64}-pp`
+
+	profiles := []tunedv1.TunedProfile{
+		{Name: ptr.To("openshift"), Data: ptr.To("[main]\nsummary=Base\ninclude=-provider-${f:exec:cat:/var/lib/ocp-tuned/provider},openshift\n")},
+		{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\nsummary=Node\ninclude=openshift\n")},
+		{Name: ptr.To("network"), Data: ptr.To("[main]\nsummary=Network\ninclude=-network-base, openshift\n")},
+		{Name: ptr.To("network-base"), Data: ptr.To("[main]\nsummary=Network base\n")},
+		{Name: ptr.To("provider-aws"), Data: ptr.To("[main]\nsummary=AWS\n")},
+		{Name: ptr.To("provider-gce"), Data: ptr.To("[main]\nsummary=GCE\n")},
+		{Name: ptr.To("openshift-node-performance-pp"), Data: ptr.To("[main]\nsummary=Performance\ninclude=" + performanceInclude + "\n")},
+		{Name: ptr.To("openshift-node-performance-rt-pp"), Data: ptr.To("[main]\nsummary=Performance RT\n")},
+		{Name: ptr.To("openshift-node-performance-intel-x86-pp"), Data: ptr.To("[main]\nsummary=Performance Intel\n")},
+		{Name: ptr.To("openshift-node-performance-arm-aarch64-pp"), Data: ptr.To("[main]\nsummary=Performance ARM\n")},
+		{Name: ptr.To("openshift-node-performance-other"), Data: ptr.To("[main]\nsummary=Performance other\n")},
+		{Name: ptr.To("unrelated"), Data: ptr.To("[main]\nsummary=Unrelated\n")},
+	}
+
+	var tests = []struct {
+		recommended string
+		provider    string
+		deps        map[string]bool
+	}{
+		{
+			// Unknown cloud provider, the optional "provider-" profile does not exist.
+			recommended: "openshift-node",
+			deps:        map[string]bool{"openshift-node": true, "openshift": true, "provider-": true},
+		},
+		{
+			recommended: "openshift-node",
+			provider:    "aws",
+			deps:        map[string]bool{"openshift-node": true, "openshift": true, "provider-aws": true},
+		},
+		{
+			recommended: "openshift-node network",
+			provider:    "azure",
+			deps:        map[string]bool{"openshift-node": true, "openshift": true, "network": true, "network-base": true, "provider-azure": true},
+		},
+		{
+			// System profile, not found in the test environment.
+			recommended: "throughput-performance",
+			deps:        map[string]bool{"throughput-performance": true},
+		},
+		{
+			// The built-in functions are only expanded on the node, all the custom profile candidates are included.
+			recommended: "openshift-node-performance-pp",
+			provider:    "gce",
+			deps: map[string]bool{
+				"openshift-node-performance-pp":             true,
+				"openshift-node-performance-rt-pp":          true,
+				"openshift-node-performance-intel-x86-pp":   true,
+				"openshift-node-performance-arm-aarch64-pp": true,
+				"openshift-node":                            true,
+				"openshift":                                 true,
+				"provider-gce":                              true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		deps := ProfileDependencies(profiles, tc.recommended, tc.provider)
+		if !reflect.DeepEqual(deps, tc.deps) {
+			t.Errorf("ProfileDependencies(%q, %q): want %v, have %v", tc.recommended, tc.provider, tc.deps, deps)
+		}
+	}
+}
@@ -16,6 +16,8 @@ const (
 	DeferAlways DeferMode = "always"
 	// DeferUpdate means in-place updates/changes to only the contents of the currently used profile. Switches to different TuneD profiles are processed immediately without the need to reboot.
 	DeferUpdate DeferMode = "update"
+	// DeferWindow means the changes carried will be deferred until the next maintenance window defined in the Tuned CR opens or until the next node restart, whichever comes first.
+	DeferWindow DeferMode = "window"
 )
 
 func (dm DeferMode) String() string {
@@ -27,7 +29,7 @@ func IsImmediateUpdate(value DeferMode) bool {
 }
 
 func IsDeferredUpdate(value DeferMode) bool {
-	return value == DeferAlways || value == DeferUpdate
+	return value == DeferAlways || value == DeferUpdate || value == DeferWindow
 }
 
 func GetDeferredUpdateAnnotation(anns map[string]string) DeferMode {
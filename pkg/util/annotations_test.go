@@ -49,6 +49,13 @@ func TestHasDeferredUpdateAnnotation(t *testing.T) {
 			},
 			expected: DeferAlways,
 		},
+		{
+			name: "found-window",
+			anns: map[string]string{
+				"tuned.openshift.io/deferred": "window",
+			},
+			expected: DeferWindow,
+		},
 		{
 			name: "found-wrong-case",
 			anns: map[string]string{
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ParseMaintenanceWindowSchedule parses the standard 5-field cron 'schedule'
+// of a maintenance window.
+func ParseMaintenanceWindowSchedule(schedule string) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance window schedule %q: %v", schedule, err)
+	}
+	return sched, nil
+}
+
+// MaintenanceWindowNext evaluates the maintenance window starting at the times
+// defined by the standard 5-field cron 'schedule' in UTC and lasting 'duration'.
+// Returns:
+//   - true if the maintenance window is open at time 'now', false otherwise.
+//   - the start of the currently open maintenance window, or the start of the
+//     next maintenance window if the window is not open at time 'now'.
+//   - error if any.
+func MaintenanceWindowNext(schedule string, duration time.Duration, now time.Time) (bool, time.Time, error) {
+	if duration <= 0 {
+		return false, time.Time{}, fmt.Errorf("invalid maintenance window duration %v", duration)
+	}
+	sched, err := ParseMaintenanceWindowSchedule(schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	now = now.UTC()
+	// The cron Schedule only provides the next activation time strictly after
+	// the time given.  A window is open if it started no longer than 'duration'
+	// ago.
+	start := sched.Next(now.Add(-duration))
+	if !start.IsZero() && !start.After(now) {
+		return true, start, nil
+	}
+
+	return false, sched.Next(now), nil
+}
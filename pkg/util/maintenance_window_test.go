@@ -0,0 +1,104 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowNext(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 1, 30, 0, 0, time.UTC) // Monday
+
+	testCases := []struct {
+		name         string
+		schedule     string
+		duration     time.Duration
+		now          time.Time
+		expectedOpen bool
+		expectedNext time.Time
+		expectedErr  bool
+	}{
+		{
+			name:         "open",
+			schedule:     "0 1 * * *",
+			duration:     time.Hour,
+			now:          now,
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.March, 4, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "open at start",
+			schedule:     "30 1 * * *",
+			duration:     time.Minute,
+			now:          now,
+			expectedOpen: true,
+			expectedNext: now,
+		},
+		{
+			name:         "closed at end",
+			schedule:     "0 1 * * *",
+			duration:     30 * time.Minute,
+			now:          now,
+			expectedOpen: false,
+			expectedNext: time.Date(2024, time.March, 5, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "closed before start",
+			schedule:     "0 2 * * *",
+			duration:     2 * time.Hour,
+			now:          now,
+			expectedOpen: false,
+			expectedNext: time.Date(2024, time.March, 4, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "weekend window",
+			schedule:     "0 22 * * 6",
+			duration:     4 * time.Hour,
+			now:          now,
+			expectedOpen: false,
+			expectedNext: time.Date(2024, time.March, 9, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "window spanning midnight",
+			schedule:     "0 23 * * 0",
+			duration:     3 * time.Hour,
+			now:          now,
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.March, 3, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "non-UTC time",
+			schedule:     "0 1 * * *",
+			duration:     time.Hour,
+			now:          now.In(time.FixedZone("UTC+5", 5*60*60)),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.March, 4, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "invalid schedule",
+			schedule:    "0 25 * * *",
+			duration:    time.Hour,
+			now:         now,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid duration",
+			schedule:    "0 1 * * *",
+			now:         now,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := MaintenanceWindowNext(tt.schedule, tt.duration, tt.now)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if open != tt.expectedOpen {
+				t.Errorf("open: want %v, have %v", tt.expectedOpen, open)
+			}
+			if !next.Equal(tt.expectedNext) {
+				t.Errorf("next: want %v, have %v", tt.expectedNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package util
+
+import "strings"
+
+// StackedTunedProfiles returns TuneD profile 'base' with TuneD profiles
+// 'additive' stacked on top of it in the TuneD notation of multiple profile
+// names separated by spaces, e.g. "openshift-node network storage".
+func StackedTunedProfiles(base string, additive []string) string {
+	if len(additive) == 0 {
+		return base
+	}
+	return strings.Join(append([]string{base}, additive...), " ")
+}
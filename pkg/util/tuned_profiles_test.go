@@ -0,0 +1,32 @@
+package util
+
+import "testing"
+
+func TestStackedTunedProfiles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     string
+		additive []string
+		expected string
+	}{
+		{
+			name:     "no-additive",
+			base:     "openshift-node",
+			expected: "openshift-node",
+		},
+		{
+			name:     "additive",
+			base:     "openshift-node",
+			additive: []string{"network", "storage"},
+			expected: "openshift-node network storage",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StackedTunedProfiles(tt.base, tt.additive)
+			if got != tt.expected {
+				t.Errorf("got=%q expected=%q", got, tt.expected)
+			}
+		})
+	}
+}